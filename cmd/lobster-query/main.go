@@ -20,6 +20,7 @@ import (
 	"flag"
 	"net/http"
 
+	"github.com/naver/lobster/pkg/lobster/auth"
 	"github.com/naver/lobster/pkg/lobster/hash"
 	"github.com/naver/lobster/pkg/lobster/logline"
 	"github.com/naver/lobster/pkg/lobster/metrics"
@@ -51,7 +52,17 @@ func main() {
 	receiver := middleware.Receiver{Id: querier.Id, Operator: hash.HashOperator{Modulus: querier.Modulus}}
 	router := server.Router()
 
-	webHandler := web.WebHandler{Querier: querier}
+	authenticator := middleware.Authenticator{}
+	if auth.Enabled() {
+		authorizer, err := auth.NewAuthorizer()
+		if err != nil {
+			panic(err)
+		}
+		authenticator.Authorizer = authorizer
+	}
+
+	// the page is served without logs to callers without tokens, who enter them in the page
+	webHandler := web.WebHandler{Querier: querier, Authorizer: authenticator.Authorizer}
 	router.Handle("/", webHandler)
 
 	router.PathPrefix("/static/").Handler(http.StripPrefix("/static/", http.FileServer(http.Dir("/web/static/"))))
@@ -59,13 +70,16 @@ func main() {
 		httpSwagger.URL("/static/docs/swagger.json"),
 	))
 
+	deadline := middleware.Deadline{Timeout: server.RequestTimeout()}
 	versionedRouter := router.PathPrefix(log.PathApi).Subrouter()
-	versionedRouter.Use(middleware.Inspector{}.Middleware)
-	versionedRouter.Handle(log.PathLogs, log.ListHandler{Querier: querier})
-	versionedRouter.Handle(log.PathLogSeries, log.SeriesHandler{Querier: querier})
-	versionedRouter.Handle(log.PathLogRange, log.RangeHandler{Querier: querier})
+	versionedRouter.Use(middleware.Inspector{}.Middleware, authenticator.Middleware)
+	versionedRouter.Handle(log.PathLogs, deadline.Middleware(log.ListHandler{Querier: querier}))
+	versionedRouter.Handle(log.PathLogSeries, deadline.Middleware(log.SeriesHandler{Querier: querier}))
+	versionedRouter.Handle(log.PathLogAggregate, deadline.Middleware(log.AggregateHandler{Querier: querier}))
+	versionedRouter.Handle(log.PathLogRange, deadline.Middleware(log.RangeHandler{Querier: querier}))
+	versionedRouter.Handle(log.PathLogTail, log.TailHandler{Querier: querier})
 
-	router.Handle(push.PathPush, receiver.Middleware(push.PushHandler{Querier: querier}))
+	router.Handle(push.PathPush, middleware.ClientCertVerifier{}.Middleware(receiver.Middleware(push.PushHandler{Querier: querier})))
 
 	server := server.NewApiServer(router)
 
@@ -24,9 +24,10 @@ import (
 	"github.com/naver/lobster/pkg/lobster/distributor"
 	"github.com/naver/lobster/pkg/lobster/logline"
 	"github.com/naver/lobster/pkg/lobster/metrics"
-	"github.com/naver/lobster/pkg/lobster/proto"
+	"github.com/naver/lobster/pkg/lobster/proto/service"
 	"github.com/naver/lobster/pkg/lobster/push"
 	"github.com/naver/lobster/pkg/lobster/server"
+	"github.com/naver/lobster/pkg/lobster/server/handler/limit"
 	"github.com/naver/lobster/pkg/lobster/server/handler/log"
 	"github.com/naver/lobster/pkg/lobster/server/handler/web"
 	"github.com/naver/lobster/pkg/lobster/server/middleware"
@@ -49,9 +50,9 @@ func main() {
 		panic(err)
 	}
 
-	grpcServer := &proto.ProtoServer{Service: proto.ChunkService{Store: store}}
 	distributor := distributor.NewDistributor(store)
 	limiter := middleware.Limiter{Limit: store.ReqMaxBurst, CooldownSecond: store.ReqCooldownDuration}
+	grpcServer := &service.ProtoServer{Service: service.ChunkService{Store: store, Limiter: &limiter}}
 	router := server.Router()
 
 	if *enableWeb {
@@ -60,11 +61,16 @@ func main() {
 		router.PathPrefix("/static/").Handler(http.StripPrefix("/static/", http.FileServer(http.Dir("/web/static/"))))
 	}
 
+	router.Handle(limit.PathLimits, middleware.ClientCertVerifier{}.Middleware(limit.LimitHandler{Provider: store}))
+
+	deadline := middleware.Deadline{Timeout: server.RequestTimeout()}
 	versionedRouter := router.PathPrefix(log.PathApi).Subrouter()
-	versionedRouter.Use(middleware.Inspector{}.Middleware)
-	versionedRouter.Handle(log.PathLogs, log.ListHandler{Querier: store})
-	versionedRouter.Handle(log.PathLogSeries, log.SeriesHandler{Querier: store})
-	versionedRouter.Handle(log.PathLogRange, limiter.Middleware(log.RangeHandler{Querier: store, ShouldResponseStringContentsOnly: true}))
+	versionedRouter.Use(middleware.Inspector{}.Middleware, middleware.ClientCertVerifier{}.Middleware)
+	versionedRouter.Handle(log.PathLogs, deadline.Middleware(log.ListHandler{Querier: store}))
+	versionedRouter.Handle(log.PathLogSeries, deadline.Middleware(log.SeriesHandler{Querier: store}))
+	versionedRouter.Handle(log.PathLogAggregate, deadline.Middleware(log.AggregateHandler{Querier: store}))
+	versionedRouter.Handle(log.PathLogRange, deadline.Middleware(limiter.Middleware(log.RangeHandler{Querier: store, ShouldResponseStringContentsOnly: true})))
+	versionedRouter.Handle(log.PathLogTail, log.TailHandler{Querier: store})
 
 	server := server.NewApiServer(router)
 	ep := server.GetLocalEndpoint()
@@ -23,6 +23,7 @@ import (
 
 	"github.com/naver/lobster/pkg/lobster/server"
 	"github.com/naver/lobster/pkg/lobster/server/handler/sync"
+	"github.com/naver/lobster/pkg/lobster/server/middleware"
 )
 
 func main() {
@@ -33,7 +34,7 @@ func main() {
 	s.Run(stopChan)
 
 	router := server.Router()
-	router.Handle(sync.PathSync, sync.SyncHandler{Syncer: s})
+	router.Handle(sync.PathSync, middleware.ClientCertVerifier{}.Middleware(sync.SyncHandler{Syncer: s}))
 
 	server := server.NewApiServer(router)
 	server.Run(func() {
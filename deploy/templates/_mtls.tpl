@@ -0,0 +1,31 @@
+{{/* mTLS between components by a secret having tls.crt, tls.key and ca.crt; e.g. a cert-manager certificate */}}
+{{- define "lobster.mtls.enabled" -}}
+{{- if (.Values.mtls | default dict).secretName }}true{{ end }}
+{{- end }}
+
+{{- define "lobster.mtls.args" -}}
+{{- if include "lobster.mtls.enabled" . }}
+- --mtls.certFile=/etc/lobster/mtls/tls.crt
+- --mtls.keyFile=/etc/lobster/mtls/tls.key
+- --mtls.caFile=/etc/lobster/mtls/ca.crt
+{{- with .Values.mtls.serverName }}
+- --mtls.serverName={{ . }}
+{{- end }}
+{{- end }}
+{{- end }}
+
+{{- define "lobster.mtls.volumeMounts" -}}
+{{- if include "lobster.mtls.enabled" . }}
+- name: mtls
+  mountPath: /etc/lobster/mtls
+  readOnly: true
+{{- end }}
+{{- end }}
+
+{{- define "lobster.mtls.volumes" -}}
+{{- if include "lobster.mtls.enabled" . }}
+- name: mtls
+  secret:
+    secretName: {{ .Values.mtls.secretName }}
+{{- end }}
+{{- end }}
@@ -0,0 +1,292 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/naver/lobster/pkg/lobster/client"
+	"github.com/naver/lobster/pkg/lobster/model"
+	"github.com/naver/lobster/pkg/lobster/query"
+	"github.com/naver/lobster/pkg/lobster/server/errors"
+	pkgErrors "github.com/pkg/errors"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// TokenCookie has the bearer token of browsers, which is set by the token field of the web page
+	TokenCookie = "lobster_token"
+
+	allNamespaces = ""
+	maxCacheSize  = 10000
+)
+
+var conf config
+
+func init() {
+	conf = setup()
+	log.Println("auth configuration is loaded")
+}
+
+func Enabled() bool {
+	return *conf.Enabled
+}
+
+// ChunkLister lists chunks to find namespaces of requests without namespaces.
+type ChunkLister interface {
+	GetChunksWithinRange(context.Context, query.Request) ([]model.Chunk, error)
+}
+
+// Authorizer authenticates callers by bearer tokens and limits what they read to namespaces they may get `pods/log`.
+// Host logs are read by callers who may get `nodes/log`.
+type Authorizer struct {
+	reviewer client.Reviewer
+	ttl      time.Duration
+	lock     sync.Mutex
+	users    map[string]cached[authenticationv1.UserInfo]
+	accesses map[string]cached[bool]
+}
+
+type cached[T any] struct {
+	value  T
+	expiry time.Time
+}
+
+type callerKey struct{}
+
+type caller struct {
+	authorizer *Authorizer
+	user       authenticationv1.UserInfo
+	token      string
+}
+
+func NewAuthorizer() (*Authorizer, error) {
+	clientset, err := client.NewClientset()
+	if err != nil {
+		return nil, err
+	}
+
+	return newAuthorizer(clientset, *conf.CacheTTL), nil
+}
+
+func newAuthorizer(clientset kubernetes.Interface, ttl time.Duration) *Authorizer {
+	return &Authorizer{
+		reviewer: client.NewReviewer(clientset),
+		ttl:      ttl,
+		users:    map[string]cached[authenticationv1.UserInfo]{},
+		accesses: map[string]cached[bool]{},
+	}
+}
+
+// Authenticate returns a context of the request having the caller of the bearer token.
+// The token is read from TokenCookie if the request has no `Authorization` header.
+func (a *Authorizer) Authenticate(r *http.Request) (context.Context, error) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		if cookie, err := r.Cookie(TokenCookie); err == nil {
+			token = cookie.Value
+		}
+	}
+	if len(token) == 0 {
+		return nil, pkgErrors.Wrap(errors.ErrUnauthorized, "bearer token is required")
+	}
+
+	sum := sha256.Sum256([]byte(token))
+	key := hex.EncodeToString(sum[:])
+
+	user, ok := getCached(a, a.users, key)
+	if !ok {
+		reviewed, err := a.reviewer.ReviewToken(r.Context(), token)
+		if err != nil {
+			return nil, pkgErrors.Wrap(errors.ErrUnauthorized, err.Error())
+		}
+		user = reviewed
+		setCached(a, a.users, key, user)
+	}
+
+	return context.WithValue(r.Context(), callerKey{}, caller{a, user, token}), nil
+}
+
+// Forward sets the bearer token of the caller in the context to a request for other queriers, which authorize the caller again.
+// It does nothing if the context has no caller.
+func Forward(ctx context.Context, r *http.Request) {
+	c, ok := ctx.Value(callerKey{}).(caller)
+	if !ok {
+		return
+	}
+
+	if r.Header == nil {
+		r.Header = http.Header{}
+	}
+	r.Header.Set("Authorization", "Bearer "+c.token)
+}
+
+func (a *Authorizer) canRead(ctx context.Context, user authenticationv1.UserInfo, namespace string) (bool, error) {
+	key := user.Username + "/" + user.UID + "/" + namespace
+
+	if allowed, ok := getCached(a, a.accesses, key); ok {
+		return allowed, nil
+	}
+
+	var (
+		allowed bool
+		err     error
+	)
+
+	if namespace == model.HostNamespace {
+		allowed, err = a.reviewer.CanReadNodeLogs(ctx, user)
+	} else {
+		allowed, err = a.reviewer.CanReadLogs(ctx, user, namespace)
+	}
+	if err != nil {
+		return false, err
+	}
+	setCached(a, a.accesses, key, allowed)
+
+	return allowed, nil
+}
+
+// LimitRequest limits namespaces of the request to what the caller in the context may read.
+// Namespaces of requests without them are found by the lister unless the caller may read all namespaces and host logs.
+// It returns false without an error if the lister finds no chunks, so the request has nothing to read.
+// Requests are forbidden only if the caller may read none of the namespaces.
+// It does nothing if the context has no caller, which means authorization is disabled.
+func LimitRequest(ctx context.Context, req *query.Request, lister ChunkLister) (bool, error) {
+	c, ok := ctx.Value(callerKey{}).(caller)
+	if !ok {
+		return true, nil
+	}
+
+	namespaces := slices.Clone(req.Namespaces)
+	if len(req.Namespace) > 0 {
+		namespaces = append(namespaces, req.Namespace)
+	}
+
+	if len(namespaces) == 0 {
+		all, err := c.authorizer.canRead(ctx, c.user, allNamespaces)
+		if err != nil {
+			return false, err
+		}
+		if all {
+			host, err := c.authorizer.canRead(ctx, c.user, model.HostNamespace)
+			if err != nil || host {
+				return err == nil, err
+			}
+		}
+		if lister == nil {
+			return false, pkgErrors.Wrap(errors.ErrForbidden, "namespaces are required")
+		}
+
+		chunks, err := lister.GetChunksWithinRange(ctx, *req)
+		if err != nil {
+			return false, err
+		}
+		if len(chunks) == 0 {
+			return false, nil
+		}
+		for _, chunk := range chunks {
+			namespaces = append(namespaces, chunk.Namespace)
+		}
+	}
+
+	slices.Sort(namespaces)
+	allowed := []string{}
+	for _, namespace := range slices.Compact(namespaces) {
+		ok, err := c.authorizer.canRead(ctx, c.user, namespace)
+		if err != nil {
+			return false, err
+		}
+		if ok {
+			allowed = append(allowed, namespace)
+		}
+	}
+
+	if len(allowed) == 0 {
+		return false, pkgErrors.Wrapf(errors.ErrForbidden, "%s may not read logs of the namespaces", c.user.Username)
+	}
+
+	req.Namespaces = allowed
+	req.Namespace = ""
+
+	return true, nil
+}
+
+// FilterChunks returns chunks in namespaces which the caller in the context may read.
+func FilterChunks(ctx context.Context, chunks []model.Chunk) ([]model.Chunk, error) {
+	c, ok := ctx.Value(callerKey{}).(caller)
+	if !ok {
+		return chunks, nil
+	}
+
+	all, err := c.authorizer.canRead(ctx, c.user, allNamespaces)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := []model.Chunk{}
+	for _, chunk := range chunks {
+		// reading all namespaces does not include host logs
+		if all && chunk.Namespace != model.HostNamespace {
+			filtered = append(filtered, chunk)
+			continue
+		}
+
+		ok, err := c.authorizer.canRead(ctx, c.user, chunk.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			filtered = append(filtered, chunk)
+		}
+	}
+
+	return filtered, nil
+}
+
+func getCached[T any](a *Authorizer, cache map[string]cached[T], key string) (T, bool) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	entry, ok := cache[key]
+	if !ok || time.Now().After(entry.expiry) {
+		delete(cache, key)
+		var zero T
+		return zero, false
+	}
+
+	return entry.value, true
+}
+
+func setCached[T any](a *Authorizer, cache map[string]cached[T], key string, value T) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	// expired entries are dropped at once instead of being tracked one by one
+	if len(cache) >= maxCacheSize {
+		clear(cache)
+	}
+	cache[key] = cached[T]{value, time.Now().Add(a.ttl)}
+}
@@ -0,0 +1,187 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auth
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/naver/lobster/pkg/lobster/model"
+	"github.com/naver/lobster/pkg/lobster/query"
+	"github.com/naver/lobster/pkg/lobster/server/errors"
+	pkgErrors "github.com/pkg/errors"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+type fakeLister []model.Chunk
+
+func (l fakeLister) GetChunksWithinRange(context.Context, query.Request) ([]model.Chunk, error) {
+	return l, nil
+}
+
+// newFakeAuthorizer authenticates `token-{user}` as the user who may read logs of namespaces in readable;
+// `*` reads all namespaces and `@host` reads host logs.
+func newFakeAuthorizer(readable map[string][]string) *Authorizer {
+	clientset := fake.NewSimpleClientset()
+	clientset.PrependReactor("create", "tokenreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authenticationv1.TokenReview)
+		user := review.Spec.Token[len("token-"):]
+		if _, ok := readable[user]; ok {
+			review.Status = authenticationv1.TokenReviewStatus{Authenticated: true, User: authenticationv1.UserInfo{Username: user}}
+		}
+		return true, review, nil
+	})
+	clientset.PrependReactor("create", "subjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+		namespaces := readable[review.Spec.User]
+		if review.Spec.ResourceAttributes.Resource == "nodes" {
+			review.Status.Allowed = slices.Contains(namespaces, model.HostNamespace)
+		} else {
+			review.Status.Allowed = slices.Contains(namespaces, "*") || slices.Contains(namespaces, review.Spec.ResourceAttributes.Namespace)
+		}
+		return true, review, nil
+	})
+
+	return newAuthorizer(clientset, time.Minute)
+}
+
+func newAuthenticatedRequest(t *testing.T, a *Authorizer, token string) *http.Request {
+	r, _ := http.NewRequest(http.MethodPost, "/api/v2/logs", nil)
+	r.Header.Set("Authorization", "Bearer "+token)
+
+	ctx, err := a.Authenticate(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return r.WithContext(ctx)
+}
+
+func TestAuthenticate(t *testing.T) {
+	a := newFakeAuthorizer(map[string][]string{"alice": {"pay"}})
+
+	r, _ := http.NewRequest(http.MethodPost, "/api/v2/logs", nil)
+	if _, err := a.Authenticate(r); pkgErrors.Cause(err) != errors.ErrUnauthorized {
+		t.Errorf("requests without tokens must be unauthorized: %v", err)
+	}
+
+	r.Header.Set("Authorization", "Bearer token-bob")
+	if _, err := a.Authenticate(r); pkgErrors.Cause(err) != errors.ErrUnauthorized {
+		t.Errorf("requests with unknown tokens must be unauthorized: %v", err)
+	}
+
+	r, _ = http.NewRequest(http.MethodGet, "/", nil)
+	r.AddCookie(&http.Cookie{Name: TokenCookie, Value: "token-alice"})
+	ctx, err := a.Authenticate(r)
+	if err != nil {
+		t.Fatalf("requests with tokens in cookies must be authenticated: %v", err)
+	}
+	if c, ok := ctx.Value(callerKey{}).(caller); !ok || c.user.Username != "alice" {
+		t.Errorf("expected the caller of the cookie but %v", ctx.Value(callerKey{}))
+	}
+}
+
+func TestLimitRequest(t *testing.T) {
+	a := newFakeAuthorizer(map[string][]string{"alice": {"pay", "shop"}, "admin": {"*", model.HostNamespace}, "operator": {"*"}})
+	lister := fakeLister{{Namespace: "pay"}, {Namespace: "infra"}, {Namespace: "pay"}}
+	alice := newAuthenticatedRequest(t, a, "token-alice")
+
+	req := query.Request{Namespaces: []string{"pay", "infra"}}
+	if _, err := LimitRequest(alice.Context(), &req, lister); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(req.Namespaces, []string{"pay"}) {
+		t.Errorf("namespaces must be limited: %v", req.Namespaces)
+	}
+
+	req = query.Request{Namespace: "infra"}
+	if _, err := LimitRequest(alice.Context(), &req, lister); pkgErrors.Cause(err) != errors.ErrForbidden {
+		t.Errorf("requests without readable namespaces must be forbidden: %v", err)
+	}
+
+	req = query.Request{}
+	if _, err := LimitRequest(alice.Context(), &req, lister); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(req.Namespaces, []string{"pay"}) {
+		t.Errorf("namespaces of chunks must be limited: %v", req.Namespaces)
+	}
+
+	req = query.Request{}
+	if found, err := LimitRequest(alice.Context(), &req, fakeLister{}); err != nil || found {
+		t.Errorf("requests without chunks must have nothing to read but not be forbidden: %v %v", found, err)
+	}
+
+	req = query.Request{}
+	if _, err := LimitRequest(alice.Context(), &req, nil); pkgErrors.Cause(err) != errors.ErrForbidden {
+		t.Errorf("namespaces must be required without listers: %v", err)
+	}
+
+	admin := newAuthenticatedRequest(t, a, "token-admin")
+	req = query.Request{}
+	if _, err := LimitRequest(admin.Context(), &req, lister); err != nil || len(req.Namespaces) > 0 {
+		t.Errorf("requests of users reading all namespaces must not be limited: %v %v", req.Namespaces, err)
+	}
+
+	operator := newAuthenticatedRequest(t, a, "token-operator")
+	req = query.Request{}
+	if _, err := LimitRequest(operator.Context(), &req, fakeLister{{Namespace: "pay"}, {Namespace: model.HostNamespace}}); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(req.Namespaces, []string{"pay"}) {
+		t.Errorf("host logs must be limited without the permission: %v", req.Namespaces)
+	}
+
+	r, _ := http.NewRequest(http.MethodPost, "/api/v2/logs", nil)
+	req = query.Request{}
+	if _, err := LimitRequest(r.Context(), &req, lister); err != nil || len(req.Namespaces) > 0 {
+		t.Errorf("requests must not be limited without callers: %v %v", req.Namespaces, err)
+	}
+}
+
+func TestFilterChunks(t *testing.T) {
+	a := newFakeAuthorizer(map[string][]string{"alice": {"pay"}})
+	alice := newAuthenticatedRequest(t, a, "token-alice")
+
+	chunks, err := FilterChunks(alice.Context(), []model.Chunk{{Namespace: "pay"}, {Namespace: "infra"}, {Namespace: model.HostNamespace}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(chunks) != 1 || chunks[0].Namespace != "pay" {
+		t.Errorf("unexpected chunks %v", chunks)
+	}
+
+	a = newFakeAuthorizer(map[string][]string{"operator": {"*"}, "admin": {"*", model.HostNamespace}})
+	for user, expected := range map[string]int{"operator": 2, "admin": 3} {
+		r := newAuthenticatedRequest(t, a, "token-"+user)
+
+		chunks, err := FilterChunks(r.Context(), []model.Chunk{{Namespace: "pay"}, {Namespace: "infra"}, {Namespace: model.HostNamespace}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(chunks) != expected {
+			t.Errorf("expected %d chunks for %s but %v", expected, user, chunks)
+		}
+	}
+}
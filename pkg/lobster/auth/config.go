@@ -0,0 +1,37 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auth
+
+import (
+	"flag"
+	"time"
+)
+
+type config struct {
+	Enabled  *bool
+	CacheTTL *time.Duration
+}
+
+func setup() config {
+	enabled := flag.Bool("auth.enabled", false, "Authenticate bearer tokens by TokenReview and authorize namespaces of queries by SubjectAccessReview on pods/log")
+	cacheTTL := flag.Duration("auth.cacheTTL", time.Minute, "Time to cache results of token and access reviews")
+
+	return config{
+		Enabled:  enabled,
+		CacheTTL: cacheTTL,
+	}
+}
@@ -0,0 +1,93 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auth_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/naver/lobster/pkg/lobster/auth"
+	"github.com/naver/lobster/pkg/lobster/model"
+	"github.com/naver/lobster/pkg/lobster/querier/broker"
+	"github.com/naver/lobster/pkg/lobster/query"
+	"github.com/naver/lobster/pkg/lobster/server/handler/log"
+	"github.com/naver/lobster/pkg/lobster/server/middleware"
+	"github.com/naver/lobster/pkg/lobster/util"
+)
+
+type fakeQuerier struct {
+	query.Queryable
+	chunks []model.Chunk
+}
+
+func (q fakeQuerier) GetChunksWithinRange(context.Context, query.Request) ([]model.Chunk, error) {
+	return q.chunks, nil
+}
+
+// TestForwardToOtherQueriers requests chunks of a querier from another querier, both authorizing callers.
+func TestForwardToOtherQueriers(t *testing.T) {
+	authorizer := auth.NewFakeAuthorizer(map[string][]string{"alice": {"pay"}})
+
+	router := mux.NewRouter()
+	versionedRouter := router.PathPrefix(log.PathApi).Subrouter()
+	versionedRouter.Use(middleware.Authenticator{Authorizer: authorizer}.Middleware)
+	versionedRouter.Handle(log.PathLogs, log.ListHandler{Querier: fakeQuerier{chunks: []model.Chunk{
+		{Namespace: "pay", Pod: "api-0"},
+		{Namespace: "shop", Pod: "web-0"},
+	}}})
+	peer := httptest.NewServer(router)
+	defer peer.Close()
+
+	b := broker.NewBroker([]broker.RemoteAddr{{Address: strings.TrimPrefix(peer.URL, "http://")}})
+	now := time.Now()
+	req := query.Request{
+		Namespaces: []string{"pay"},
+		Start:      util.Timestamp{Time: now.Add(-time.Hour)},
+		End:        util.Timestamp{Time: now},
+		Version:    log.ApiV2,
+		Local:      true,
+	}
+
+	r := httptest.NewRequest(http.MethodPost, "/api/v2/logs", nil)
+	r.Header.Set("Authorization", "Bearer token-alice")
+	ctx, err := authorizer.Authenticate(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chunks, err := b.RequestChunksWithinRange(ctx, req, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(chunks) != 1 || chunks[0].Namespace != "pay" {
+		t.Errorf("expected chunks of pay from the other querier but got %v", chunks)
+	}
+
+	// the other querier rejects requests without callers
+	chunks, err = b.RequestChunksWithinRange(context.Background(), req, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(chunks) != 0 {
+		t.Errorf("expected no chunks without the bearer token but got %v", chunks)
+	}
+}
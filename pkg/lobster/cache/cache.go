@@ -0,0 +1,226 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/naver/lobster/pkg/lobster/metrics"
+)
+
+// the number of keys of a chunk deleted by Invalidate; the others are left to expire with an old generation
+const maxKeysPerChunk = 1000
+
+var conf config
+
+func init() {
+	conf = setup()
+	log.Println("cache configuration is loaded")
+}
+
+// Enabled returns whether a backend is configured.
+func Enabled() bool {
+	return len(*conf.Backend) > 0
+}
+
+// Backend keeps values of keys; values may be dropped at any time.
+type Backend interface {
+	// Get returns values of keys found
+	Get(keys []string) map[string][]byte
+	Set(key string, value []byte, ttl time.Duration)
+	Delete(keys []string)
+}
+
+// Cache keeps results of closed time ranges, which are not changed by logs written later.
+type Cache struct {
+	backend       Backend
+	ttl           time.Duration
+	maxFreshness  time.Duration
+	splitInterval time.Duration
+	// keys written for each chunk to delete them when the chunk disappears
+	keys *lru.Cache
+	lock sync.Mutex
+}
+
+func NewCache() (*Cache, error) {
+	var backend Backend
+
+	switch *conf.Backend {
+	case BackendMemory:
+		memory, err := NewMemoryBackend(*conf.Size)
+		if err != nil {
+			return nil, err
+		}
+		backend = memory
+	case BackendMemcached:
+		if len(*conf.MemcachedAddrs) == 0 {
+			return nil, fmt.Errorf("memcached addresses are required")
+		}
+		backend = NewMemcachedBackend(strings.Split(*conf.MemcachedAddrs, ","), *conf.MemcachedTimeout)
+	default:
+		return nil, fmt.Errorf("unsupported cache backend %s", *conf.Backend)
+	}
+
+	return newCache(backend, *conf.Size, *conf.TTL, *conf.MaxFreshness, *conf.SplitInterval)
+}
+
+func newCache(backend Backend, size int, ttl, maxFreshness, splitInterval time.Duration) (*Cache, error) {
+	if splitInterval < time.Second {
+		return nil, fmt.Errorf("split interval should be at least a second")
+	}
+
+	keys, err := lru.New(size)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Cache{
+		backend:       backend,
+		ttl:           ttl,
+		maxFreshness:  maxFreshness,
+		splitInterval: splitInterval,
+		keys:          keys,
+	}, nil
+}
+
+// Cutoff returns the time before which results can be cached.
+func (c *Cache) Cutoff(now time.Time) time.Time {
+	return now.Add(-c.maxFreshness).Truncate(time.Second)
+}
+
+// Window returns the range of whole intervals within the range closed by the cutoff.
+func (c *Cache) Window(start, end, cutoff time.Time) (time.Time, time.Time) {
+	windowStart := start.Truncate(c.splitInterval)
+	if windowStart.Before(start) {
+		windowStart = windowStart.Add(c.splitInterval)
+	}
+
+	if cutoff.Before(end) {
+		end = cutoff
+	}
+
+	return windowStart, end.Truncate(c.splitInterval)
+}
+
+// Split divides the range into intervals aligned to the split interval;
+// only the first and the last intervals may be partial if the range is not aligned.
+func (c *Cache) Split(start, end time.Time) [][2]time.Time {
+	ranges := [][2]time.Time{}
+
+	for from := start; from.Before(end); {
+		to := from.Truncate(c.splitInterval).Add(c.splitInterval)
+		if to.After(end) {
+			to = end
+		}
+		ranges = append(ranges, [2]time.Time{from, to})
+		from = to
+	}
+
+	return ranges
+}
+
+// Get returns values of keys found.
+func (c *Cache) Get(keys []string) map[string][]byte {
+	values := c.backend.Get(keys)
+	metrics.AddCacheRequests(len(values), len(keys)-len(values))
+
+	return values
+}
+
+// Set keeps the value of the chunk.
+func (c *Cache) Set(chunkKey, key string, value []byte) {
+	c.backend.Set(key, value, c.ttl)
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	keys := map[string]bool{}
+	if v, ok := c.keys.Get(chunkKey); ok {
+		keys = v.(map[string]bool)
+	} else {
+		c.keys.Add(chunkKey, keys)
+	}
+	if len(keys) < maxKeysPerChunk {
+		keys[key] = true
+	}
+}
+
+// Generations returns generations of chunks changed by Invalidate, which are parts of keys of their values;
+// chunks never invalidated are missing.
+func (c *Cache) Generations(chunkKeys []string) map[string]string {
+	keys := make([]string, len(chunkKeys))
+	for i, chunkKey := range chunkKeys {
+		keys[i] = generationKey(chunkKey)
+	}
+
+	values := c.backend.Get(keys)
+	generations := map[string]string{}
+	for i, chunkKey := range chunkKeys {
+		if value, ok := values[keys[i]]; ok {
+			generations[chunkKey] = string(value)
+		}
+	}
+
+	return generations
+}
+
+// Invalidate drops values kept for the chunk.
+// The generation of the chunk is changed in the backend as well, so values kept by other queriers sharing it are not read either.
+func (c *Cache) Invalidate(chunkKey string) {
+	c.backend.Set(generationKey(chunkKey), []byte(strconv.FormatInt(time.Now().UnixNano(), 10)), c.ttl)
+
+	c.lock.Lock()
+	v, ok := c.keys.Get(chunkKey)
+	c.keys.Remove(chunkKey)
+	c.lock.Unlock()
+
+	if !ok {
+		return
+	}
+
+	keys := []string{}
+	for key := range v.(map[string]bool) {
+		keys = append(keys, key)
+	}
+	c.backend.Delete(keys)
+}
+
+func generationKey(chunkKey string) string {
+	return Key("generation", chunkKey)
+}
+
+// Key returns a key of values identified by parts.
+func Key(parts ...any) string {
+	data, err := json.Marshal(parts)
+	if err != nil {
+		glog.Error(err)
+	}
+
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:])
+}
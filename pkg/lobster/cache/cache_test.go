@@ -0,0 +1,236 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cache
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWindow(t *testing.T) {
+	c, err := newCache(nil, 10, time.Hour, 10*time.Minute, 10*time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	start, end := c.Window(base.Add(5*time.Minute), base.Add(time.Hour), base.Add(47*time.Minute))
+
+	if !start.Equal(base.Add(10*time.Minute)) || !end.Equal(base.Add(40*time.Minute)) {
+		t.Fatalf("unexpected window %s ~ %s", start, end)
+	}
+
+	intervals := c.Split(start, end)
+	if len(intervals) != 3 {
+		t.Fatalf("expected 3 intervals but got %d", len(intervals))
+	}
+
+	for i, interval := range intervals {
+		if !interval[0].Equal(start.Add(time.Duration(i)*10*time.Minute)) || interval[1].Sub(interval[0]) != 10*time.Minute {
+			t.Errorf("unexpected interval %s ~ %s", interval[0], interval[1])
+		}
+	}
+}
+
+func TestInvalidate(t *testing.T) {
+	backend, err := NewMemoryBackend(10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := newCache(backend, 10, time.Hour, 0, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.Set("chunk-a", "a1", []byte("1"))
+	c.Set("chunk-a", "a2", []byte("2"))
+	c.Set("chunk-b", "b1", []byte("3"))
+
+	c.Invalidate("chunk-a")
+
+	values := c.Get([]string{"a1", "a2", "b1"})
+	if len(values) != 1 || string(values["b1"]) != "3" {
+		t.Fatalf("expected only values of chunk-b but got %v", values)
+	}
+}
+
+func TestInvalidateSharedBackend(t *testing.T) {
+	backend, err := NewMemoryBackend(10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	caches := make([]*Cache, 2)
+	for i := range caches {
+		if caches[i], err = newCache(backend, 10, time.Hour, 0, time.Minute); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if generations := caches[1].Generations([]string{"chunk-a"}); len(generations) != 0 {
+		t.Fatalf("expected no generations but got %v", generations)
+	}
+
+	caches[0].Invalidate("chunk-a")
+
+	generations := caches[1].Generations([]string{"chunk-a", "chunk-b"})
+	if _, ok := generations["chunk-a"]; !ok || len(generations) != 1 {
+		t.Fatalf("generations must be shared through the backend: %v", generations)
+	}
+}
+
+func TestSetBoundsKeysPerChunk(t *testing.T) {
+	backend, err := NewMemoryBackend(maxKeysPerChunk * 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := newCache(backend, 10, time.Hour, 0, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < maxKeysPerChunk+10; i++ {
+		c.Set("chunk-a", strconv.Itoa(i), []byte("1"))
+	}
+	c.Set("chunk-a", "0", []byte("1"))
+
+	if v, _ := c.keys.Get("chunk-a"); len(v.(map[string]bool)) != maxKeysPerChunk {
+		t.Errorf("expected %d keys but got %d", maxKeysPerChunk, len(v.(map[string]bool)))
+	}
+}
+
+func TestMemoryBackendExpires(t *testing.T) {
+	backend, err := NewMemoryBackend(10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	backend.Set("expired", []byte("1"), -time.Second)
+	backend.Set("alive", []byte("2"), time.Hour)
+
+	values := backend.Get([]string{"expired", "alive"})
+	if _, ok := values["expired"]; ok || string(values["alive"]) != "2" {
+		t.Fatalf("unexpected values %v", values)
+	}
+}
+
+// fakeMemcached serves get, set and delete commands of the text protocol.
+type fakeMemcached struct {
+	values map[string][]byte
+	lock   sync.Mutex
+}
+
+func (f *fakeMemcached) serve(t *testing.T) string {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = lis.Close() })
+
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			go f.handle(conn)
+		}
+	}()
+
+	return lis.Addr().String()
+}
+
+func (f *fakeMemcached) handle(conn net.Conn) {
+	defer func() { _ = conn.Close() }()
+	rw := bufio.NewReadWriter(bufio.NewReader(conn), bufio.NewWriter(conn))
+
+	for {
+		line, err := readLine(rw.Reader)
+		if err != nil {
+			return
+		}
+
+		fields := strings.Fields(line)
+		f.lock.Lock()
+		switch fields[0] {
+		case "get":
+			for _, key := range fields[1:] {
+				if value, ok := f.values[key]; ok {
+					fmt.Fprintf(rw, "VALUE %s 0 %d\r\n%s\r\n", key, len(value), value)
+				}
+			}
+			fmt.Fprint(rw, "END\r\n")
+		case "set":
+			size, _ := strconv.Atoi(fields[4])
+			data := make([]byte, size+2)
+			if _, err := io.ReadFull(rw, data); err != nil {
+				f.lock.Unlock()
+				return
+			}
+			f.values[fields[1]] = data[:size]
+			fmt.Fprint(rw, "STORED\r\n")
+		case "delete":
+			if _, ok := f.values[fields[1]]; ok {
+				delete(f.values, fields[1])
+				fmt.Fprint(rw, "DELETED\r\n")
+			} else {
+				fmt.Fprint(rw, "NOT_FOUND\r\n")
+			}
+		}
+		f.lock.Unlock()
+
+		if err := rw.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+func TestMemcachedBackend(t *testing.T) {
+	servers := []*fakeMemcached{{values: map[string][]byte{}}, {values: map[string][]byte{}}}
+	backend := NewMemcachedBackend([]string{servers[0].serve(t), servers[1].serve(t)}, time.Second)
+
+	keys := []string{}
+	for i := 0; i < maxKeysPerGet*3; i++ {
+		key := fmt.Sprintf("key-%d", i)
+		keys = append(keys, key)
+		backend.Set(key, []byte(strconv.Itoa(i)), time.Hour)
+	}
+
+	values := backend.Get(append(keys, "unknown"))
+	if len(values) != len(keys) {
+		t.Fatalf("expected %d values but got %d", len(keys), len(values))
+	}
+	for i, key := range keys {
+		if string(values[key]) != strconv.Itoa(i) {
+			t.Errorf("unexpected value of %s: %s", key, values[key])
+		}
+	}
+
+	backend.Delete(keys[:10])
+	if values := backend.Get(keys[:10]); len(values) != 0 {
+		t.Errorf("expected deleted values but got %v", values)
+	}
+}
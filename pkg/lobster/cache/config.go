@@ -0,0 +1,57 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cache
+
+import (
+	"flag"
+	"time"
+)
+
+const (
+	BackendMemory    = "memory"
+	BackendMemcached = "memcached"
+)
+
+type config struct {
+	Backend          *string
+	Size             *int
+	MemcachedAddrs   *string
+	MemcachedTimeout *time.Duration
+	TTL              *time.Duration
+	MaxFreshness     *time.Duration
+	SplitInterval    *time.Duration
+}
+
+func setup() config {
+	backend := flag.String("cache.backend", "", "Backend to cache results of series and logs fetched from stores(memory or memcached); disabled if empty")
+	size := flag.Int("cache.size", 10000, "The maximum number of results kept in memory")
+	memcachedAddrs := flag.String("cache.memcachedAddrs", "", "Comma separated addresses of memcached servers shared by queriers")
+	memcachedTimeout := flag.Duration("cache.memcachedTimeout", 200*time.Millisecond, "Timeout of requests to memcached servers")
+	ttl := flag.Duration("cache.ttl", time.Hour, "Time to keep a result")
+	maxFreshness := flag.Duration("cache.maxFreshness", 10*time.Minute, "Results within this duration from now are always fetched since logs may still be written")
+	splitInterval := flag.Duration("cache.splitInterval", 10*time.Minute, "Interval to split series requests into, each of which is cached separately")
+
+	return config{
+		Backend:          backend,
+		Size:             size,
+		MemcachedAddrs:   memcachedAddrs,
+		MemcachedTimeout: memcachedTimeout,
+		TTL:              ttl,
+		MaxFreshness:     maxFreshness,
+		SplitInterval:    splitInterval,
+	}
+}
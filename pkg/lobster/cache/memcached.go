@@ -0,0 +1,217 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cache
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cespare/xxhash/v2"
+	"github.com/golang/glog"
+)
+
+const (
+	maxIdleConns  = 16
+	maxKeysPerGet = 100
+)
+
+// MemcachedBackend keeps values in memcached servers shared by queriers; each key is kept by a server chosen by its hash.
+type MemcachedBackend struct {
+	servers []*memcachedServer
+}
+
+type memcachedServer struct {
+	addr    string
+	timeout time.Duration
+	idle    chan net.Conn
+}
+
+func NewMemcachedBackend(addrs []string, timeout time.Duration) *MemcachedBackend {
+	servers := []*memcachedServer{}
+	for _, addr := range addrs {
+		servers = append(servers, &memcachedServer{strings.TrimSpace(addr), timeout, make(chan net.Conn, maxIdleConns)})
+	}
+
+	return &MemcachedBackend{servers}
+}
+
+func (m *MemcachedBackend) serverOf(key string) *memcachedServer {
+	return m.servers[xxhash.Sum64String(key)%uint64(len(m.servers))]
+}
+
+func (m *MemcachedBackend) Get(keys []string) map[string][]byte {
+	values := map[string][]byte{}
+	keysByServer := map[*memcachedServer][]string{}
+
+	for _, key := range keys {
+		server := m.serverOf(key)
+		keysByServer[server] = append(keysByServer[server], key)
+	}
+
+	for server, serverKeys := range keysByServer {
+		for start := 0; start < len(serverKeys); start += maxKeysPerGet {
+			end := min(start+maxKeysPerGet, len(serverKeys))
+
+			if err := server.do(func(rw *bufio.ReadWriter) error {
+				return getValues(rw, serverKeys[start:end], values)
+			}); err != nil {
+				glog.V(3).Infof("failed to get values from %s: %s", server.addr, err.Error())
+			}
+		}
+	}
+
+	return values
+}
+
+// getValues sends a get command of keys and puts values found.
+func getValues(rw *bufio.ReadWriter, keys []string, values map[string][]byte) error {
+	if _, err := fmt.Fprintf(rw, "get %s\r\n", strings.Join(keys, " ")); err != nil {
+		return err
+	}
+	if err := rw.Flush(); err != nil {
+		return err
+	}
+
+	for {
+		line, err := readLine(rw.Reader)
+		if err != nil {
+			return err
+		}
+		if line == "END" {
+			return nil
+		}
+
+		// VALUE <key> <flags> <bytes>
+		fields := strings.Fields(line)
+		if len(fields) != 4 || fields[0] != "VALUE" {
+			return fmt.Errorf("unexpected response: %s", line)
+		}
+
+		size, err := strconv.Atoi(fields[3])
+		if err != nil {
+			return err
+		}
+
+		data := make([]byte, size+2)
+		if _, err := io.ReadFull(rw, data); err != nil {
+			return err
+		}
+		values[fields[1]] = data[:size]
+	}
+}
+
+func (m *MemcachedBackend) Set(key string, value []byte, ttl time.Duration) {
+	err := m.serverOf(key).do(func(rw *bufio.ReadWriter) error {
+		if _, err := fmt.Fprintf(rw, "set %s 0 %d %d\r\n", key, int(ttl.Seconds()), len(value)); err != nil {
+			return err
+		}
+		if _, err := rw.Write(value); err != nil {
+			return err
+		}
+		if _, err := rw.WriteString("\r\n"); err != nil {
+			return err
+		}
+		if err := rw.Flush(); err != nil {
+			return err
+		}
+
+		return expectLine(rw.Reader, "STORED")
+	})
+	if err != nil {
+		glog.V(3).Infof("failed to set %s: %s", key, err.Error())
+	}
+}
+
+func (m *MemcachedBackend) Delete(keys []string) {
+	for _, key := range keys {
+		err := m.serverOf(key).do(func(rw *bufio.ReadWriter) error {
+			if _, err := fmt.Fprintf(rw, "delete %s\r\n", key); err != nil {
+				return err
+			}
+			if err := rw.Flush(); err != nil {
+				return err
+			}
+
+			return expectLine(rw.Reader, "DELETED", "NOT_FOUND")
+		})
+		if err != nil {
+			glog.V(3).Infof("failed to delete %s: %s", key, err.Error())
+		}
+	}
+}
+
+// do runs a command on an idle connection or a new one; connections failed in commands are closed.
+func (s *memcachedServer) do(command func(*bufio.ReadWriter) error) error {
+	var conn net.Conn
+
+	select {
+	case conn = <-s.idle:
+	default:
+		c, err := net.DialTimeout("tcp", s.addr, s.timeout)
+		if err != nil {
+			return err
+		}
+		conn = c
+	}
+
+	if err := conn.SetDeadline(time.Now().Add(s.timeout)); err != nil {
+		_ = conn.Close()
+		return err
+	}
+
+	if err := command(bufio.NewReadWriter(bufio.NewReader(conn), bufio.NewWriter(conn))); err != nil {
+		_ = conn.Close()
+		return err
+	}
+
+	select {
+	case s.idle <- conn:
+	default:
+		_ = conn.Close()
+	}
+
+	return nil
+}
+
+func readLine(r *bufio.Reader) (string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+func expectLine(r *bufio.Reader, expected ...string) error {
+	line, err := readLine(r)
+	if err != nil {
+		return err
+	}
+
+	for _, e := range expected {
+		if line == e {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unexpected response: %s", line)
+}
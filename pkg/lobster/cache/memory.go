@@ -0,0 +1,73 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cache
+
+import (
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+)
+
+type memoryEntry struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+// MemoryBackend keeps values in memory of a querier and evicts the least recently used ones.
+type MemoryBackend struct {
+	entries *lru.Cache
+}
+
+func NewMemoryBackend(size int) (*MemoryBackend, error) {
+	entries, err := lru.New(size)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MemoryBackend{entries}, nil
+}
+
+func (m *MemoryBackend) Get(keys []string) map[string][]byte {
+	values := map[string][]byte{}
+	now := time.Now()
+
+	for _, key := range keys {
+		v, ok := m.entries.Get(key)
+		if !ok {
+			continue
+		}
+
+		entry := v.(memoryEntry)
+		if now.After(entry.expiresAt) {
+			m.entries.Remove(key)
+			continue
+		}
+		values[key] = entry.value
+	}
+
+	return values
+}
+
+func (m *MemoryBackend) Set(key string, value []byte, ttl time.Duration) {
+	m.entries.Add(key, memoryEntry{value, time.Now().Add(ttl)})
+}
+
+func (m *MemoryBackend) Delete(keys []string) {
+	for _, key := range keys {
+		m.entries.Remove(key)
+	}
+}
@@ -17,22 +17,15 @@
 package client
 
 import (
-	"context"
-	"encoding/json"
 	"errors"
-	"fmt"
 	"log"
-	"time"
 
-	"github.com/golang/glog"
-	v1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 )
 
 var (
-	conf    config
-	timeout = time.Second
+	conf config
 )
 
 func init() {
@@ -43,50 +36,28 @@ func init() {
 type Client struct {
 	hostName string
 	*kubernetes.Clientset
-	timeout time.Duration
-	cache   map[string]v1.Pod
+}
+
+// HostName returns the name of the node.
+func HostName() string {
+	return *conf.HostName
 }
 
 func New() (Client, error) {
 	if len(*conf.HostName) == 0 {
 		return Client{}, errors.New("`client.hostName` is required")
 	}
-	config, err := rest.InClusterConfig()
-	if err != nil {
-		return Client{}, err
-	}
-
-	clientset, err := kubernetes.NewForConfig(config)
+	clientset, err := NewClientset()
 
-	return Client{*conf.HostName, clientset, timeout, map[string]v1.Pod{}}, err
+	return Client{*conf.HostName, clientset}, err
 }
 
-func (c *Client) GetPods() map[string]v1.Pod {
-	podMap := map[string]v1.Pod{}
-	podList := v1.PodList{}
-
-	ctx, cancel := context.WithTimeout(context.TODO(), c.timeout)
-	defer cancel()
-
-	data, err := c.RESTClient().
-		Get().
-		AbsPath(fmt.Sprintf("/api/v1/nodes/%s/proxy/pods", c.hostName)).
-		DoRaw(ctx)
+// NewClientset returns a clientset of the cluster for components not running on a specific node.
+func NewClientset() (*kubernetes.Clientset, error) {
+	config, err := rest.InClusterConfig()
 	if err != nil {
-		glog.Warningf("using cached pod information: failed to make a request to the k8s API server or kubelet: %s", err.Error())
-		return c.cache
-	}
-
-	if err := json.Unmarshal(data, &podList); err != nil {
-		glog.Warningf("using cached pod information: failed to unmarshal the pod list response: %s", err.Error())
-		return c.cache
+		return nil, err
 	}
 
-	for _, pod := range podList.Items {
-		podMap[string(pod.UID)] = pod
-	}
-
-	c.cache = podMap
-
-	return podMap
+	return kubernetes.NewForConfig(config)
 }
@@ -18,16 +18,20 @@ package client
 
 import (
 	"flag"
+	"time"
 )
 
 type config struct {
-	HostName *string
+	HostName     *string
+	ResyncPeriod *time.Duration
 }
 
 func setup() config {
 	hostName := flag.String("client.hostName", "", "Host name to use in requests to k8s")
+	resyncPeriod := flag.Duration("client.resyncPeriod", 10*time.Minute, "Interval to resync pods watched on the node")
 
 	return config{
-		HostName: hostName,
+		HostName:     hostName,
+		ResyncPeriod: resyncPeriod,
 	}
 }
@@ -0,0 +1,156 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/golang/glog"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	listerv1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	PodAdded   = "added"
+	PodUpdated = "updated"
+	PodDeleted = "deleted"
+
+	podEventBufferSize = 1024
+	indexUid           = "uid"
+)
+
+type PodEvent struct {
+	Type string
+	Pod  v1.Pod
+}
+
+// PodWatcher caches pods scheduled on the node by an informer and pushes their changes as events.
+type PodWatcher struct {
+	factory  informers.SharedInformerFactory
+	informer cache.SharedIndexInformer
+	lister   listerv1.PodLister
+	events   chan PodEvent
+}
+
+func NewPodWatcher(c Client) *PodWatcher {
+	return newPodWatcher(c.Clientset, c.hostName, *conf.ResyncPeriod)
+}
+
+func newPodWatcher(clientset kubernetes.Interface, hostName string, resyncPeriod time.Duration) *PodWatcher {
+	factory := informers.NewSharedInformerFactoryWithOptions(clientset, resyncPeriod,
+		informers.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.FieldSelector = fields.OneTermEqualSelector("spec.nodeName", hostName).String()
+		}))
+	podInformer := factory.Core().V1().Pods()
+
+	w := &PodWatcher{
+		factory:  factory,
+		informer: podInformer.Informer(),
+		lister:   podInformer.Lister(),
+		events:   make(chan PodEvent, podEventBufferSize),
+	}
+
+	if err := w.informer.AddIndexers(cache.Indexers{indexUid: func(obj interface{}) ([]string, error) {
+		pod, ok := obj.(*v1.Pod)
+		if !ok {
+			return nil, nil
+		}
+		return []string{string(pod.UID)}, nil
+	}}); err != nil {
+		panic(err)
+	}
+
+	w.informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			w.push(PodAdded, obj)
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			if oldObj.(*v1.Pod).ResourceVersion == newObj.(*v1.Pod).ResourceVersion {
+				return
+			}
+			w.push(PodUpdated, newObj)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			w.push(PodDeleted, obj)
+		},
+	})
+
+	return w
+}
+
+func (w *PodWatcher) push(eventType string, obj interface{}) {
+	pod, ok := obj.(*v1.Pod)
+	if !ok {
+		return
+	}
+
+	select {
+	case w.events <- PodEvent{eventType, *pod}:
+	default:
+		// pods are reconciled by the next inspection
+		glog.Warningf("pod event for %s/%s is discarded: event buffer is full", pod.Namespace, pod.Name)
+	}
+}
+
+// Run starts watching pods and waits until the cache is synced.
+func (w *PodWatcher) Run(stopChan chan struct{}) error {
+	w.factory.Start(stopChan)
+
+	if !cache.WaitForCacheSync(stopChan, w.informer.HasSynced) {
+		return fmt.Errorf("failed to sync pods")
+	}
+
+	return nil
+}
+
+// Events returns events of pods added, updated and deleted.
+func (w *PodWatcher) Events() <-chan PodEvent {
+	return w.events
+}
+
+// HasPod returns true if the pod of the uid is on the node.
+func (w *PodWatcher) HasPod(uid string) bool {
+	objs, err := w.informer.GetIndexer().ByIndex(indexUid, uid)
+	return err == nil && len(objs) > 0
+}
+
+// GetPods returns cached pods by uid.
+func (w *PodWatcher) GetPods() map[string]v1.Pod {
+	podMap := map[string]v1.Pod{}
+
+	pods, err := w.lister.List(labels.Everything())
+	if err != nil {
+		glog.Error(err)
+		return podMap
+	}
+
+	for _, pod := range pods {
+		podMap[string(pod.UID)] = *pod
+	}
+
+	return podMap
+}
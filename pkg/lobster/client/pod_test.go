@@ -0,0 +1,84 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func waitPodEvent(t *testing.T, w *PodWatcher, eventType string) PodEvent {
+	for {
+		select {
+		case event := <-w.Events():
+			if event.Type == eventType {
+				return event
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("no %s event", eventType)
+		}
+	}
+}
+
+func TestPodWatcher(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	w := newPodWatcher(clientset, "node-a", 0)
+	stopChan := make(chan struct{})
+	defer close(stopChan)
+
+	if err := w.Run(stopChan); err != nil {
+		t.Fatal(err)
+	}
+
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "pay", UID: "uid-a", Labels: map[string]string{"app": "api"}, ResourceVersion: "1"},
+		Spec:       v1.PodSpec{NodeName: "node-a"},
+	}
+
+	ctx := context.Background()
+	if _, err := clientset.CoreV1().Pods("pay").Create(ctx, pod, metav1.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if event := waitPodEvent(t, w, PodAdded); event.Pod.Name != "api" {
+		t.Errorf("unexpected pod %s", event.Pod.Name)
+	}
+	if _, ok := w.GetPods()["uid-a"]; !ok {
+		t.Error("added pod must be cached")
+	}
+
+	pod.Labels["version"] = "v2"
+	pod.ResourceVersion = "2"
+	if _, err := clientset.CoreV1().Pods("pay").Update(ctx, pod, metav1.UpdateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if event := waitPodEvent(t, w, PodUpdated); event.Pod.Labels["version"] != "v2" {
+		t.Errorf("unexpected labels %v", event.Pod.Labels)
+	}
+
+	if err := clientset.CoreV1().Pods("pay").Delete(ctx, "api", metav1.DeleteOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	waitPodEvent(t, w, PodDeleted)
+	if len(w.GetPods()) != 0 {
+		t.Error("deleted pod must not be cached")
+	}
+}
@@ -0,0 +1,97 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"errors"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Reviewer asks the API server who owns tokens and whether users may read logs of pods.
+type Reviewer struct {
+	clientset kubernetes.Interface
+}
+
+func NewReviewer(clientset kubernetes.Interface) Reviewer {
+	return Reviewer{clientset}
+}
+
+// ReviewToken returns the user of the bearer token by a TokenReview.
+func (r Reviewer) ReviewToken(ctx context.Context, token string) (authenticationv1.UserInfo, error) {
+	review, err := r.clientset.AuthenticationV1().TokenReviews().Create(ctx, &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{Token: token},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return authenticationv1.UserInfo{}, err
+	}
+
+	if !review.Status.Authenticated {
+		if len(review.Status.Error) > 0 {
+			return authenticationv1.UserInfo{}, errors.New(review.Status.Error)
+		}
+		return authenticationv1.UserInfo{}, errors.New("token is not authenticated")
+	}
+
+	return review.Status.User, nil
+}
+
+// CanReadLogs checks whether the user may get `pods/log` in the namespace by a SubjectAccessReview.
+// An empty namespace means all namespaces.
+func (r Reviewer) CanReadLogs(ctx context.Context, user authenticationv1.UserInfo, namespace string) (bool, error) {
+	return r.review(ctx, user, &authorizationv1.ResourceAttributes{
+		Namespace:   namespace,
+		Verb:        "get",
+		Resource:    "pods",
+		Subresource: "log",
+	})
+}
+
+// CanReadNodeLogs checks whether the user may get `nodes/log` by a SubjectAccessReview.
+func (r Reviewer) CanReadNodeLogs(ctx context.Context, user authenticationv1.UserInfo) (bool, error) {
+	return r.review(ctx, user, &authorizationv1.ResourceAttributes{
+		Verb:        "get",
+		Resource:    "nodes",
+		Subresource: "log",
+	})
+}
+
+func (r Reviewer) review(ctx context.Context, user authenticationv1.UserInfo, attributes *authorizationv1.ResourceAttributes) (bool, error) {
+	extra := map[string]authorizationv1.ExtraValue{}
+	for k, v := range user.Extra {
+		extra[k] = authorizationv1.ExtraValue(v)
+	}
+
+	review, err := r.clientset.AuthorizationV1().SubjectAccessReviews().Create(ctx, &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			ResourceAttributes: attributes,
+			User:               user.Username,
+			UID:                user.UID,
+			Groups:             user.Groups,
+			Extra:              extra,
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return false, err
+	}
+
+	return review.Status.Allowed, nil
+}
@@ -0,0 +1,130 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/glog"
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/naver/lobster/pkg/lobster/model"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	maxCachedOwners = 10000
+	// failed lookups are not retried within the interval
+	ownerRetryInterval = time.Minute
+)
+
+type cachedOwner struct {
+	owner *metav1.OwnerReference
+	// zero for resolved owners
+	retryAt time.Time
+}
+
+// WorkloadResolver resolves owner chains of pods by following controller references of ReplicaSets and Jobs.
+// Owners of workloads are cached since they do not change, and failed lookups are cached for a while.
+type WorkloadResolver struct {
+	clientset kubernetes.Interface
+	timeout   time.Duration
+	owners    *lru.Cache
+}
+
+func NewWorkloadResolver(c Client) *WorkloadResolver {
+	return newWorkloadResolver(c.Clientset)
+}
+
+func newWorkloadResolver(clientset kubernetes.Interface) *WorkloadResolver {
+	owners, err := lru.New(maxCachedOwners)
+	if err != nil {
+		panic(err)
+	}
+
+	return &WorkloadResolver{clientset, time.Second, owners}
+}
+
+// Resolve returns owners of the pod from the nearest one; e.g. ReplicaSet and Deployment, or Job and CronJob.
+func (r *WorkloadResolver) Resolve(pod v1.Pod) model.Workloads {
+	workloads := model.Workloads{}
+
+	for owner := controllerOf(pod.OwnerReferences); owner != nil; owner = r.ownerOf(pod.Namespace, *owner) {
+		workloads = append(workloads, model.Workload{Kind: owner.Kind, Name: owner.Name})
+	}
+
+	return workloads
+}
+
+func (r *WorkloadResolver) ownerOf(namespace string, ref metav1.OwnerReference) *metav1.OwnerReference {
+	if ref.Kind != "ReplicaSet" && ref.Kind != "Job" {
+		return nil
+	}
+
+	key := namespace + "/" + ref.Kind + "/" + ref.Name
+	if v, ok := r.owners.Get(key); ok {
+		if cached := v.(cachedOwner); cached.retryAt.IsZero() || time.Now().Before(cached.retryAt) {
+			return cached.owner
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.TODO(), r.timeout)
+	defer cancel()
+
+	var (
+		refs []metav1.OwnerReference
+		err  error
+	)
+
+	switch ref.Kind {
+	case "ReplicaSet":
+		var rs metav1.Object
+		rs, err = r.clientset.AppsV1().ReplicaSets(namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+		if err == nil {
+			refs = rs.GetOwnerReferences()
+		}
+	case "Job":
+		var job metav1.Object
+		job, err = r.clientset.BatchV1().Jobs(namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+		if err == nil {
+			refs = job.GetOwnerReferences()
+		}
+	}
+	if err != nil {
+		// the chain is resolved again after the retry interval
+		glog.Warningf("failed to get owner of %s: %s", key, err.Error())
+		r.owners.Add(key, cachedOwner{retryAt: time.Now().Add(ownerRetryInterval)})
+		return nil
+	}
+
+	owner := controllerOf(refs)
+	r.owners.Add(key, cachedOwner{owner: owner})
+
+	return owner
+}
+
+func controllerOf(refs []metav1.OwnerReference) *metav1.OwnerReference {
+	for i := range refs {
+		if refs[i].Controller != nil && *refs[i].Controller {
+			return &refs[i]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/naver/lobster/pkg/lobster/model"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func controllerRef(kind, name string) []metav1.OwnerReference {
+	controller := true
+	return []metav1.OwnerReference{{Kind: kind, Name: name, Controller: &controller}}
+}
+
+func TestWorkloadResolver(t *testing.T) {
+	clientset := fake.NewSimpleClientset(
+		&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Namespace: "pay", Name: "api-7d9f", OwnerReferences: controllerRef("Deployment", "api")}},
+		&batchv1.Job{ObjectMeta: metav1.ObjectMeta{Namespace: "pay", Name: "settlement-2890", OwnerReferences: controllerRef("CronJob", "settlement")}},
+	)
+	resolver := newWorkloadResolver(clientset)
+
+	testData := []struct {
+		owners   []metav1.OwnerReference
+		expected model.Workloads
+	}{
+		{controllerRef("ReplicaSet", "api-7d9f"), model.Workloads{{Kind: "ReplicaSet", Name: "api-7d9f"}, {Kind: "Deployment", Name: "api"}}},
+		{controllerRef("Job", "settlement-2890"), model.Workloads{{Kind: "Job", Name: "settlement-2890"}, {Kind: "CronJob", Name: "settlement"}}},
+		{controllerRef("DaemonSet", "agent"), model.Workloads{{Kind: "DaemonSet", Name: "agent"}}},
+		{nil, model.Workloads{}},
+	}
+
+	for _, test := range testData {
+		pod := v1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "pay", OwnerReferences: test.owners}}
+		if workloads := resolver.Resolve(pod); !slices.Equal(workloads, test.expected) {
+			t.Errorf("expected %v but %v", test.expected, workloads)
+		}
+	}
+
+	// owners are cached after they are resolved
+	if err := clientset.AppsV1().ReplicaSets("pay").Delete(context.Background(), "api-7d9f", metav1.DeleteOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	pod := v1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "pay", OwnerReferences: controllerRef("ReplicaSet", "api-7d9f")}}
+	if workloads := resolver.Resolve(pod); len(workloads) != 2 {
+		t.Errorf("expected cached owner but %v", workloads)
+	}
+}
+
+func TestWorkloadResolverCachesFailures(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	resolver := newWorkloadResolver(clientset)
+	pod := v1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "pay", OwnerReferences: controllerRef("ReplicaSet", "api-7d9f")}}
+
+	for i := 0; i < 3; i++ {
+		if workloads := resolver.Resolve(pod); len(workloads) != 1 {
+			t.Fatalf("expected the pod owner only but %v", workloads)
+		}
+	}
+
+	if actions := clientset.Actions(); len(actions) != 1 {
+		t.Errorf("failed lookups should not be retried within the interval; %d requests", len(actions))
+	}
+}
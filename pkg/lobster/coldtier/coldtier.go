@@ -0,0 +1,220 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package coldtier
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/naver/lobster/pkg/lobster/model"
+)
+
+const (
+	metaExt  = ".json"
+	indexDir = "_index"
+)
+
+var conf config
+
+func init() {
+	conf = setup()
+	log.Println("cold tier configuration is loaded")
+}
+
+func Enabled() bool {
+	return len(*conf.Endpoint) > 0 && len(*conf.Bucket) > 0
+}
+
+// BlockMeta is stored next to each offloaded block to discover it without reading the block.
+type BlockMeta struct {
+	Chunk model.Chunk `json:"chunk"`
+	Block model.Block `json:"block"`
+}
+
+// Client offloads blocks to a bucket and reads them back.
+// Objects are laid out as `{root}/{pod uid}/{container}/{source}/{block file}` with metadata in `{block file}.json`.
+// Metadata are also written in `{root}/_index/{upload time}/...` to be discovered in the order of uploads.
+type Client struct {
+	storage objectStorage
+	root    string
+}
+
+func NewClient() (*Client, error) {
+	storage, err := newS3Storage()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{storage, *conf.RootPath}, nil
+}
+
+func (c *Client) chunkPrefix(podUid, container string, source model.Source) string {
+	return path.Join(c.root, podUid, container, url.PathEscape(source.String())) + "/"
+}
+
+func (c *Client) blockKey(chunk model.Chunk, block model.Block) string {
+	return c.chunkPrefix(chunk.PodUid, chunk.Container, chunk.Source) + block.FileName()
+}
+
+func (c *Client) indexPrefix() string {
+	return path.Join(c.root, indexDir) + "/"
+}
+
+// indexKey orders keys by the upload time with zero padded nanoseconds.
+func (c *Client) indexKey(uploadedAt time.Time, chunk model.Chunk, block model.Block) string {
+	return c.indexKeyAfter(uploadedAt) + "/" + strings.TrimPrefix(c.blockKey(chunk, block), c.root+"/") + metaExt
+}
+
+func (c *Client) indexKeyAfter(t time.Time) string {
+	return fmt.Sprintf("%s%020d", c.indexPrefix(), t.UnixNano())
+}
+
+// Upload puts the block before its metadata, so that discovered blocks are always readable.
+// It stops when ctx is done.
+func (c *Client) Upload(ctx context.Context, chunk model.Chunk, block model.Block, data []byte) error {
+	block.DeletionMark = false
+	key := c.blockKey(chunk, block)
+
+	if err := c.storage.put(ctx, key, data); err != nil {
+		return err
+	}
+
+	meta, err := json.Marshal(BlockMeta{Chunk: chunk, Block: block})
+	if err != nil {
+		return err
+	}
+
+	if err := c.storage.put(ctx, key+metaExt, meta); err != nil {
+		return err
+	}
+
+	return c.storage.put(ctx, c.indexKey(time.Now(), chunk, block), meta)
+}
+
+// delete removes the block with its metadata and the index entry.
+func (c *Client) delete(indexKey string, meta BlockMeta) error {
+	key := c.blockKey(meta.Chunk, meta.Block)
+	return c.storage.delete([]string{key, key + metaExt, indexKey})
+}
+
+func (c *Client) Get(chunk model.Chunk, block model.Block) ([]byte, error) {
+	return c.storage.get(c.blockKey(chunk, block))
+}
+
+// LoadChunk returns the chunk having offloaded blocks of the container; nil if there are no blocks.
+func (c *Client) LoadChunk(podUid, container string, source model.Source) (*model.Chunk, error) {
+	metas := map[string]BlockMeta{}
+	if err := c.loadMetas(c.chunkPrefix(podUid, container, source), "", metas); err != nil {
+		return nil, err
+	}
+
+	chunks := buildChunks(metas)
+	if len(chunks) == 0 {
+		return nil, nil
+	}
+
+	return &chunks[0], nil
+}
+
+// loadMetas reads metadata under the prefix after startAfter into metas; metadata already in metas are not read again since they are immutable.
+func (c *Client) loadMetas(prefix, startAfter string, metas map[string]BlockMeta) error {
+	keys, err := c.storage.list(prefix, startAfter)
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		if !strings.HasSuffix(key, metaExt) {
+			continue
+		}
+
+		if _, ok := metas[key]; ok {
+			continue
+		}
+
+		data, err := c.storage.get(key)
+		if err != nil {
+			return err
+		}
+
+		meta := BlockMeta{}
+		if err := json.Unmarshal(data, &meta); err != nil {
+			return err
+		}
+
+		metas[key] = meta
+	}
+
+	return nil
+}
+
+// buildChunks groups blocks by containers into cold chunks.
+func buildChunks(metas map[string]BlockMeta) []model.Chunk {
+	chunkMap := map[string]*model.Chunk{}
+
+	for _, meta := range metas {
+		chunk := meta.Chunk
+		chunk.Cold = true
+
+		c, ok := chunkMap[chunk.Key()]
+		if !ok {
+			c = &chunk
+			c.Blocks = []*model.Block{}
+			c.TempBlock = &model.TempBlock{}
+			c.StartedAt = meta.Block.StartedAt
+			c.UpdatedAt = meta.Block.EndedAt
+			c.Line = 0
+			c.Size = 0
+			c.StoreAddr = ""
+			chunkMap[chunk.Key()] = c
+		}
+
+		block := meta.Block
+		c.Blocks = append(c.Blocks, &block)
+		c.Line = c.Line + block.Line
+		c.Size = c.Size + block.Size
+
+		if block.StartedAt.Before(c.StartedAt) {
+			c.StartedAt = block.StartedAt
+		}
+		if block.EndedAt.After(c.UpdatedAt) {
+			c.UpdatedAt = block.EndedAt
+		}
+	}
+
+	chunks := []model.Chunk{}
+
+	for _, c := range chunkMap {
+		sort.Slice(c.Blocks, func(i, j int) bool {
+			return c.Blocks[i].StartedAt.Before(c.Blocks[j].StartedAt)
+		})
+		chunks = append(chunks, *c)
+	}
+
+	sort.Slice(chunks, func(i, j int) bool {
+		return chunks[i].Key() < chunks[j].Key()
+	})
+
+	return chunks
+}
@@ -0,0 +1,148 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package coldtier
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/naver/lobster/pkg/lobster/model"
+)
+
+type memoryStorage map[string][]byte
+
+func (m memoryStorage) put(_ context.Context, key string, data []byte) error {
+	m[key] = data
+	return nil
+}
+
+func (m memoryStorage) get(key string) ([]byte, error) {
+	data, ok := m[key]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return data, nil
+}
+
+func (m memoryStorage) list(prefix, startAfter string) ([]string, error) {
+	keys := []string{}
+	for key := range m {
+		if strings.HasPrefix(key, prefix) && key > startAfter {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+func (m memoryStorage) delete(keys []string) error {
+	for _, key := range keys {
+		delete(m, key)
+	}
+	return nil
+}
+
+func TestClientUploadAndLoadChunk(t *testing.T) {
+	client := &Client{memoryStorage{}, "lobster"}
+	now := time.Now().UTC().Truncate(time.Second)
+	chunk := model.Chunk{Namespace: "ns", Pod: "pod", PodUid: "uid", Container: "app", Source: model.Source{Type: "stdstream"}}
+	blocks := []model.Block{
+		*model.NewBlock(now.Add(-time.Hour), now.Add(-30*time.Minute), 10, 100, 50, 1),
+		*model.NewBlock(now.Add(-2*time.Hour), now.Add(-time.Hour), 20, 200, 80, 0),
+	}
+
+	for i, block := range blocks {
+		block.DeletionMark = true
+		if err := client.Upload(context.Background(), chunk, block, []byte{byte(i)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cold, err := client.LoadChunk("uid", "app", chunk.Source)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cold == nil || !cold.Cold || len(cold.Blocks) != 2 {
+		t.Fatalf("unexpected cold chunk %v", cold)
+	}
+	if !cold.StartedAt.Equal(blocks[1].StartedAt) || !cold.UpdatedAt.Equal(blocks[0].EndedAt) || cold.Line != 30 || cold.Size != 300 {
+		t.Fatalf("unexpected range or measures of cold chunk %v", cold)
+	}
+	if cold.Blocks[0].FileNum != 0 || cold.Blocks[0].DeletionMark {
+		t.Fatal("blocks should be sorted and not marked")
+	}
+
+	data, err := client.Get(*cold, *cold.Blocks[1])
+	if err != nil || !bytes.Equal(data, []byte{0}) {
+		t.Fatalf("unexpected block data %v: %v", data, err)
+	}
+
+	if other, err := client.LoadChunk("uid", "other", chunk.Source); err != nil || other != nil {
+		t.Fatalf("expected no chunk but %v: %v", other, err)
+	}
+
+	index := NewIndex(client, nil)
+	if err := index.Refresh(); err != nil {
+		t.Fatal(err)
+	}
+	if chunks := index.Chunks(); len(chunks) != 1 || chunks[0].Key() != cold.Key() {
+		t.Fatalf("unexpected indexed chunks %v", chunks)
+	}
+}
+
+func TestIndexListsIncrementallyAndExpires(t *testing.T) {
+	storage := memoryStorage{}
+	client := &Client{storage, "lobster"}
+	now := time.Now().UTC().Truncate(time.Second)
+	chunk := model.Chunk{Namespace: "ns", Pod: "pod", PodUid: "uid", Container: "app", Source: model.Source{Type: "stdstream"}}
+	expired := *model.NewBlock(now.Add(-*conf.RetentionTime-2*time.Hour), now.Add(-*conf.RetentionTime-time.Hour), 1, 1, 1, 0)
+	alive := *model.NewBlock(now.Add(-time.Hour), now, 1, 1, 1, 1)
+
+	for _, block := range []model.Block{expired, alive} {
+		if err := client.Upload(context.Background(), chunk, block, []byte{0}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the listing of other queriers drops expired blocks without deleting them
+	other := NewIndex(client, func(model.Chunk) bool { return false })
+	if err := other.Refresh(); err != nil {
+		t.Fatal(err)
+	}
+	if chunks := other.Chunks(); len(chunks) != 1 || len(chunks[0].Blocks) != 1 || len(storage) != 6 {
+		t.Fatalf("unexpected chunks %v of %d objects", chunks, len(storage))
+	}
+
+	owner := NewIndex(client, nil)
+	if err := owner.Refresh(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Get(chunk, expired); err == nil || len(storage) != 3 {
+		t.Fatalf("expected the expired block to be deleted but %d objects are left", len(storage))
+	}
+
+	// entries uploaded long before the last listing are not listed again
+	storage[client.indexKey(now.Add(-time.Hour), chunk, *model.NewBlock(now, now, 1, 1, 1, 2))] = []byte("not json")
+	if err := owner.Refresh(); err != nil {
+		t.Fatalf("expected old entries to be skipped but %v", err)
+	}
+}
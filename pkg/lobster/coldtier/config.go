@@ -0,0 +1,58 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package coldtier
+
+import (
+	"flag"
+	"time"
+)
+
+type config struct {
+	Endpoint        *string
+	Bucket          *string
+	Region          *string
+	AccessKey       *string
+	SecretKey       *string
+	RootPath        *string
+	DisableSSL      *bool
+	RefreshInterval *time.Duration
+	RetentionTime   *time.Duration
+}
+
+func setup() config {
+	endpoint := flag.String("coldTier.endpoint", "", "Endpoint of S3-compatible object storage to offload blocks before deletion; disabled if empty")
+	bucket := flag.String("coldTier.bucket", "", "Bucket to offload blocks")
+	region := flag.String("coldTier.region", "US", "Region of the bucket")
+	accessKey := flag.String("coldTier.accessKey", "", "Access key of the bucket; credentials are taken from the environment if empty")
+	secretKey := flag.String("coldTier.secretKey", "", "Secret key of the bucket")
+	rootPath := flag.String("coldTier.rootPath", "lobster", "Path in the bucket under which blocks are offloaded")
+	disableSSL := flag.Bool("coldTier.disableSSL", false, "Use http for the endpoint")
+	refreshInterval := flag.Duration("coldTier.refreshInterval", time.Minute, "Interval to discover offloaded blocks")
+	retentionTime := flag.Duration("coldTier.retentionTime", 30*24*time.Hour, "Time to keep offloaded blocks in the bucket; kept forever if 0")
+
+	return config{
+		Endpoint:        endpoint,
+		Bucket:          bucket,
+		Region:          region,
+		AccessKey:       accessKey,
+		SecretKey:       secretKey,
+		RootPath:        rootPath,
+		DisableSSL:      disableSSL,
+		RefreshInterval: refreshInterval,
+		RetentionTime:   retentionTime,
+	}
+}
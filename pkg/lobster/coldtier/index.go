@@ -0,0 +1,130 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package coldtier
+
+import (
+	"maps"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/naver/lobster/pkg/lobster/model"
+)
+
+// Entries of the index uploaded before the last listing by this duration are listed again,
+// since clocks of stores differ and listings may miss objects being uploaded.
+const indexLookback = 10 * time.Minute
+
+// Index keeps discovering offloaded blocks as cold chunks.
+// The index is listed from the last listing, and blocks beyond the retention are deleted by the querier owning them.
+type Index struct {
+	client   *Client
+	owns     func(model.Chunk) bool
+	lock     sync.RWMutex
+	metas    map[string]BlockMeta
+	chunks   []model.Chunk
+	listedAt time.Time
+}
+
+func NewIndex(client *Client, owns func(model.Chunk) bool) *Index {
+	return &Index{
+		client: client,
+		owns:   owns,
+		metas:  map[string]BlockMeta{},
+		chunks: []model.Chunk{},
+	}
+}
+
+func (i *Index) Refresh() error {
+	now := time.Now()
+
+	i.lock.RLock()
+	metas := maps.Clone(i.metas)
+	startAfter := ""
+	if !i.listedAt.IsZero() {
+		startAfter = i.client.indexKeyAfter(i.listedAt.Add(-indexLookback))
+	}
+	i.lock.RUnlock()
+
+	if err := i.client.loadMetas(i.client.indexPrefix(), startAfter, metas); err != nil {
+		return err
+	}
+	i.expire(metas, now)
+
+	chunks := buildChunks(metas)
+
+	i.lock.Lock()
+	i.metas = metas
+	i.chunks = chunks
+	i.listedAt = now
+	i.lock.Unlock()
+
+	glog.V(3).Infof("discovered %d cold chunks of %d blocks", len(chunks), len(metas))
+	return nil
+}
+
+// expire drops blocks beyond the retention; blocks failed to be deleted by the owner are kept to be deleted again.
+func (i *Index) expire(metas map[string]BlockMeta, now time.Time) {
+	if *conf.RetentionTime <= 0 {
+		return
+	}
+
+	deadline := now.Add(-*conf.RetentionTime)
+
+	for key, meta := range metas {
+		if !meta.Block.EndedAt.Before(deadline) {
+			continue
+		}
+
+		if i.owns == nil || i.owns(meta.Chunk) {
+			if err := i.client.delete(key, meta); err != nil {
+				glog.Error(err)
+				continue
+			}
+			glog.V(3).Infof("delete offloaded block : %s", i.client.blockKey(meta.Chunk, meta.Block))
+		}
+		delete(metas, key)
+	}
+}
+
+func (i *Index) Chunks() []model.Chunk {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
+	return i.chunks
+}
+
+func (i *Index) Run(stopChan chan struct{}) {
+	if err := i.Refresh(); err != nil {
+		glog.Error(err)
+	}
+
+	ticker := time.NewTicker(*conf.RefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := i.Refresh(); err != nil {
+				glog.Error(err)
+			}
+		case <-stopChan:
+			glog.Info("stop cold tier discovery")
+			return
+		}
+	}
+}
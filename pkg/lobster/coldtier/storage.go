@@ -0,0 +1,131 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package coldtier
+
+import (
+	"bytes"
+	"context"
+	"io"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+)
+
+const maxKeysPerDelete = 1000
+
+type objectStorage interface {
+	put(ctx context.Context, key string, data []byte) error
+	get(key string) ([]byte, error)
+	// list returns keys under the prefix in lexicographical order after startAfter
+	list(prefix, startAfter string) ([]string, error)
+	delete(keys []string) error
+}
+
+type s3Storage struct {
+	client   *s3.S3
+	uploader *s3manager.Uploader
+	bucket   string
+}
+
+func newS3Storage() (*s3Storage, error) {
+	awsConfig := &aws.Config{
+		Endpoint:         aws.String(*conf.Endpoint),
+		DisableSSL:       aws.Bool(*conf.DisableSSL),
+		S3ForcePathStyle: aws.Bool(true),
+		Region:           aws.String(*conf.Region),
+	}
+
+	if len(*conf.AccessKey) > 0 {
+		awsConfig.Credentials = credentials.NewStaticCredentials(*conf.AccessKey, *conf.SecretKey, "")
+	}
+
+	s3Session, err := session.NewSession(awsConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return &s3Storage{
+		client:   s3.New(s3Session),
+		uploader: s3manager.NewUploader(s3Session),
+		bucket:   *conf.Bucket,
+	}, nil
+}
+
+func (s *s3Storage) put(ctx context.Context, key string, data []byte) error {
+	_, err := s.uploader.UploadWithContext(ctx, &s3manager.UploadInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+		Body:   bytes.NewReader(data),
+	})
+
+	return err
+}
+
+func (s *s3Storage) get(key string) ([]byte, error) {
+	output, err := s.client.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = output.Body.Close() }()
+
+	return io.ReadAll(output.Body)
+}
+
+func (s *s3Storage) list(prefix, startAfter string) ([]string, error) {
+	keys := []string{}
+
+	input := &s3.ListObjectsV2Input{
+		Bucket: aws.String(s.bucket),
+		Prefix: aws.String(prefix),
+	}
+	if len(startAfter) > 0 {
+		input.StartAfter = aws.String(startAfter)
+	}
+
+	err := s.client.ListObjectsV2Pages(input, func(page *s3.ListObjectsV2Output, _ bool) bool {
+		for _, object := range page.Contents {
+			keys = append(keys, aws.StringValue(object.Key))
+		}
+		return true
+	})
+
+	return keys, err
+}
+
+func (s *s3Storage) delete(keys []string) error {
+	for start := 0; start < len(keys); start += maxKeysPerDelete {
+		objects := []*s3.ObjectIdentifier{}
+		for _, key := range keys[start:min(start+maxKeysPerDelete, len(keys))] {
+			objects = append(objects, &s3.ObjectIdentifier{Key: aws.String(key)})
+		}
+
+		if _, err := s.client.DeleteObjects(&s3.DeleteObjectsInput{
+			Bucket: aws.String(s.bucket),
+			Delete: &s3.Delete{Objects: objects, Quiet: aws.Bool(true)},
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
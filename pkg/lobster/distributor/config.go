@@ -24,32 +24,38 @@ import (
 type config struct {
 	StdstreamLogRootPath   *string
 	EmptyDirLogRootPath    *string
+	HostLogPaths           *string
 	FileInspectInterval    *time.Duration
 	FileInspectMaxStale    *time.Duration
 	TailFileMaxStale       *time.Duration
 	MatchLookbackMin       *time.Duration
 	MetricsInterval        *time.Duration
+	OffloadTimeout         *time.Duration
 	ShouldUpdateLogMatcher *bool
 }
 
 func setup() config {
 	stdstreamLogRootPath := flag.String("distributor.stdstreamLogRootPath", "/var/log/pods", "Path to find container logs")
 	emptyDirLogRootPath := flag.String("distributor.emptyDirLogRootPath", "/var/lib/kubelet/pods", "Path to find pod emptydir logs")
+	hostLogPaths := flag.String("distributor.hostLogPaths", "", "Comma separated globs of host log files of the node like kubelet, containerd and syslog; e.g. /var/log/syslog,/var/log/kubelet*.log")
 	fileInspectInterval := flag.Duration("distributor.fileInspectInterval", time.Second, "Log file inspection interval")
 	fileInspectMaxStale := flag.Duration("distributor.fileInspectMaxStale", 6*24*time.Hour, "Decide how old files to look up; This must be less than store.retentionTime")
 	tailFileMaxStale := flag.Duration("distributor.tailFileMaxStale", 5*time.Second, "Decide how old files to look up to tailing")
 	matchLookbackMin := flag.Duration("distributor.matchLookbackMin", 10*time.Second, "Determine how old the logs will be in metrics")
 	metricsInterval := flag.Duration("distributor.metricsInterval", 5*time.Second, "metrics production interval")
+	offloadTimeout := flag.Duration("distributor.offloadTimeout", 10*time.Minute, "Timeout of each offload of blocks to the cold tier, which runs apart from inspections")
 	shouldUpdateLogMatcher := flag.Bool("distributor.shouldUpdateLogMatcher", false, "When using the log sink function, set it to true for periodic log sink rule update")
 
 	return config{
 		StdstreamLogRootPath:   stdstreamLogRootPath,
 		EmptyDirLogRootPath:    emptyDirLogRootPath,
+		HostLogPaths:           hostLogPaths,
 		FileInspectInterval:    fileInspectInterval,
 		FileInspectMaxStale:    fileInspectMaxStale,
 		TailFileMaxStale:       tailFileMaxStale,
 		MatchLookbackMin:       matchLookbackMin,
 		MetricsInterval:        metricsInterval,
+		OffloadTimeout:         offloadTimeout,
 		ShouldUpdateLogMatcher: shouldUpdateLogMatcher,
 	}
 }
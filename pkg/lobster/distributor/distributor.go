@@ -17,19 +17,25 @@
 package distributor
 
 import (
+	"context"
 	"io"
 	"log"
 	"reflect"
+	"slices"
 	"sort"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/golang/glog"
 	"github.com/naver/lobster/pkg/lobster/client"
+	"github.com/naver/lobster/pkg/lobster/event"
 	"github.com/naver/lobster/pkg/lobster/loader"
 	"github.com/naver/lobster/pkg/lobster/logline"
 	"github.com/naver/lobster/pkg/lobster/metrics"
 	"github.com/naver/lobster/pkg/lobster/model"
+	"github.com/naver/lobster/pkg/lobster/multiline"
+	"github.com/naver/lobster/pkg/lobster/redaction"
 	v1 "k8s.io/api/core/v1"
 
 	"github.com/naver/lobster/pkg/lobster/sink/helper"
@@ -46,7 +52,9 @@ type Distributor struct {
 	tailerCache sync.Map
 	store       *store.Store
 	matcher     matcher.LogMatcher
-	client      client.Client
+	pods        *client.PodWatcher
+	workloads   *client.WorkloadResolver
+	events      *event.Collector
 }
 
 func init() {
@@ -55,21 +63,49 @@ func init() {
 }
 
 func NewDistributor(store *store.Store) Distributor {
-	client, err := client.New()
+	c, err := client.New()
 	if err != nil {
 		panic(err)
 	}
 
+	if err := multiline.LoadRules(); err != nil {
+		panic(err)
+	}
+
+	if err := redaction.LoadRules(); err != nil {
+		panic(err)
+	}
+
+	pods := client.NewPodWatcher(c)
+
+	var events *event.Collector
+	if event.Enabled() {
+		events = event.NewCollector(c, pods.HasPod)
+	}
+
 	return Distributor{
 		tailerCache: sync.Map{},
 		store:       store,
 		matcher:     matcher.NewLogMatcher(),
-		client:      client,
+		pods:        pods,
+		workloads:   client.NewWorkloadResolver(c),
+		events:      events,
 	}
 }
 
 func (d *Distributor) Run(stopChan chan struct{}) {
 	d.store.InitChunks()
+
+	if err := d.pods.Run(stopChan); err != nil {
+		panic(err)
+	}
+
+	if d.events != nil {
+		if err := d.events.Run(stopChan); err != nil {
+			panic(err)
+		}
+	}
+
 	go func(stopChan chan struct{}) {
 		inspectTicker := time.NewTicker(*conf.FileInspectInterval)
 
@@ -80,45 +116,21 @@ func (d *Distributor) Run(stopChan chan struct{}) {
 		for {
 			select {
 			case <-inspectTicker.C:
-				podMap := d.client.GetPods()
-
-				if len(podMap) == 0 {
-					panic("no pods found")
-				}
-
-				d.updateChunksByPods(podMap)
-
-				logfiles, err := d.loadLogFiles(podMap)
-				if err != nil {
-					glog.Error(err)
-					continue
-				}
+				d.inspect(stopChan)
 
-				if len(logfiles) == 0 {
-					panic("no log files found")
+			case event := <-d.pods.Events():
+				// logs of new pods are tailed without waiting for the next inspection
+				if added := d.handlePodEvents(event); len(added) > 0 {
+					d.inspectPods(added, stopChan)
 				}
 
-				fileMap := d.extractFileMap(logfiles, *conf.FileInspectMaxStale)
-				tailList := d.extractTailList(fileMap, *conf.TailFileMaxStale)
-
-				if *conf.ShouldUpdateLogMatcher {
-					if err := d.matcher.Update(helper.FilterChunksByExistingPods(d.store.GetChunks(), podMap)); err != nil {
-						glog.Error(err)
-					}
-				}
-
-				d.storeFiles(fileMap)
-				d.tailFiles(tailList, stopChan)
-
-				d.store.Mark()
-				d.store.Clean()
-
 			case <-stopChan:
 				glog.Info("stop distributor")
 				return
 			}
 		}
 	}(stopChan)
+	go d.runOffload(stopChan)
 	go func(stopChan chan struct{}) {
 		metricsTicker := time.NewTicker(*conf.MetricsInterval)
 
@@ -138,8 +150,129 @@ func (d *Distributor) Run(stopChan chan struct{}) {
 	}(stopChan)
 }
 
+func (d *Distributor) inspect(stopChan chan struct{}) {
+	podMap := d.pods.GetPods()
+
+	if len(podMap) == 0 {
+		glog.Warning("no pods found")
+		return
+	}
+
+	d.updateChunksByPods(podMap)
+
+	logfiles, err := d.loadLogFiles(podMap)
+	if err != nil {
+		glog.Error(err)
+		return
+	}
+
+	if len(logfiles) == 0 {
+		glog.Warning("no log files found")
+		return
+	}
+
+	fileMap := d.extractFileMap(logfiles, *conf.FileInspectMaxStale)
+	tailList := d.extractTailList(fileMap, *conf.TailFileMaxStale)
+
+	if *conf.ShouldUpdateLogMatcher {
+		if err := d.matcher.Update(helper.FilterChunksByExistingPods(d.store.GetChunks(), podMap)); err != nil {
+			glog.Error(err)
+		}
+	}
+
+	d.storeFiles(fileMap)
+	d.tailFiles(tailList, stopChan)
+
+	d.store.Mark()
+	d.store.Clean()
+}
+
+// runOffload uploads blocks marked by inspections apart from them, so that slow uploads don't delay tailing new files.
+// Blocks are cleaned by inspections once they are uploaded.
+func (d *Distributor) runOffload(stopChan chan struct{}) {
+	offloadTicker := time.NewTicker(*conf.FileInspectInterval)
+	defer offloadTicker.Stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-stopChan
+		cancel()
+	}()
+
+	for {
+		select {
+		case <-offloadTicker.C:
+			offloadCtx, cancelOffload := context.WithTimeout(ctx, *conf.OffloadTimeout)
+			d.store.Offload(offloadCtx)
+			cancelOffload()
+		case <-ctx.Done():
+			glog.Info("stop offload")
+			return
+		}
+	}
+}
+
+// inspectPods stores and tails log files of the pods only; retention and offload are left to the next inspection.
+func (d *Distributor) inspectPods(podMap map[string]v1.Pod, stopChan chan struct{}) {
+	logfiles, err := d.loadPodLogFiles(podMap)
+	if err != nil {
+		glog.Error(err)
+		return
+	}
+
+	logfiles = slices.DeleteFunc(logfiles, func(file model.LogFile) bool {
+		_, ok := podMap[file.PodUid]
+		return !ok
+	})
+
+	fileMap := d.extractFileMap(logfiles, *conf.FileInspectMaxStale)
+
+	d.storeFiles(fileMap)
+	d.tailFiles(d.extractTailList(fileMap, *conf.TailFileMaxStale), stopChan)
+}
+
+// handlePodEvents applies the event and the buffered ones; it returns pods added by them.
+func (d *Distributor) handlePodEvents(event client.PodEvent) map[string]v1.Pod {
+	added := map[string]v1.Pod{}
+
+	for {
+		d.updateChunksByPodEvent(event)
+		if event.Type == client.PodAdded {
+			added[string(event.Pod.UID)] = event.Pod
+		}
+
+		select {
+		case event = <-d.pods.Events():
+		default:
+			return added
+		}
+	}
+}
+
+// updateChunksByPodEvent applies changes of a pod to its chunks as soon as they are watched.
+func (d *Distributor) updateChunksByPodEvent(event client.PodEvent) {
+	d.store.UpdateChunks(func(chunk *model.Chunk) {
+		if chunk.PodUid != string(event.Pod.UID) {
+			return
+		}
+
+		if event.Type == client.PodDeleted {
+			chunk.PodDeleted = true
+			return
+		}
+
+		d.updateChunkByPod(chunk, event.Pod)
+	})
+}
+
 func (d *Distributor) updateChunksByPods(podMap map[string]v1.Pod) {
 	d.store.UpdateChunks(func(chunk *model.Chunk) {
+		// chunks of host log files belong to the node
+		if chunk.Namespace == model.HostNamespace {
+			return
+		}
+
 		pod, ok := podMap[chunk.PodUid]
 
 		chunk.PodDeleted = !ok
@@ -148,16 +281,57 @@ func (d *Distributor) updateChunksByPods(podMap map[string]v1.Pod) {
 			return
 		}
 
-		if reflect.DeepEqual(map[string]string(chunk.Labels), pod.Labels) {
-			return
-		}
-
-		chunk.Labels = pod.Labels
-		d.store.WriteLabelsFile(chunk)
+		d.updateChunkByPod(chunk, pod)
 	})
 }
 
+func (d *Distributor) updateChunkByPod(chunk *model.Chunk, pod v1.Pod) {
+	chunk.PodDeleted = false
+	chunk.Annotations = lobsterAnnotations(pod.Annotations)
+
+	if workloads := d.workloads.Resolve(pod); !slices.Equal(chunk.Workloads, workloads) {
+		chunk.Workloads = workloads
+		d.store.WriteWorkloadsFile(chunk)
+	}
+
+	if reflect.DeepEqual(map[string]string(chunk.Labels), pod.Labels) {
+		return
+	}
+
+	chunk.Labels = pod.Labels
+	d.store.WriteLabelsFile(chunk)
+}
+
+// lobsterAnnotations keeps annotations of pods prefixed by lobster in chunks.
+func lobsterAnnotations(annotations map[string]string) model.Labels {
+	selected := model.Labels{}
+	for k, v := range annotations {
+		if strings.HasPrefix(k, store.AnnotationPrefix) {
+			selected[k] = v
+		}
+	}
+	return selected
+}
+
 func (d *Distributor) loadLogFiles(podMap map[string]v1.Pod) ([]model.LogFile, error) {
+	logFiles, err := d.loadPodLogFiles(podMap)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(*conf.HostLogPaths) > 0 {
+		logFiles = append(logFiles, loader.LoadHostLogFiles(strings.Split(*conf.HostLogPaths, ","), client.HostName())...)
+	}
+
+	if d.events != nil {
+		d.events.Collect(podMap)
+		logFiles = append(logFiles, loader.LoadEventLogFiles(event.LogRootPath(), podMap)...)
+	}
+
+	return logFiles, nil
+}
+
+func (d *Distributor) loadPodLogFiles(podMap map[string]v1.Pod) ([]model.LogFile, error) {
 	logFiles, err := loader.LoadLogfiles(*conf.StdstreamLogRootPath, func(podDirName string) (model.Labels, error) {
 		return model.NewLabelsFromDirectoryName(podDirName, podMap)
 	}, loader.ParseKubeLogFile)
@@ -0,0 +1,34 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package distributor
+
+import (
+	"testing"
+
+	"github.com/naver/lobster/pkg/lobster/store"
+)
+
+func TestLobsterAnnotations(t *testing.T) {
+	annotations := lobsterAnnotations(map[string]string{
+		store.AnnotationRetention:           "720h",
+		"kubectl.kubernetes.io/restartedAt": "2024-01-01T00:00:00Z",
+	})
+
+	if len(annotations) != 1 || annotations[store.AnnotationRetention] != "720h" {
+		t.Fatalf("only lobster annotations should be kept: %v", annotations)
+	}
+}
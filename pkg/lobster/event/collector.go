@@ -0,0 +1,327 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package event
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/naver/lobster/pkg/lobster/client"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+)
+
+const indexPodUid = "podUid"
+
+var conf config
+
+func init() {
+	conf = setup()
+	log.Println("event configuration is loaded")
+}
+
+func Enabled() bool {
+	return len(*conf.LogRootPath) > 0
+}
+
+func LogRootPath() string {
+	return *conf.LogRootPath
+}
+
+// Collector watches events of pods and appends events of pods on the node to a log file of each pod.
+// Only events of pods on the node are cached; events before pods are scheduled on the node are listed once for each pod.
+type Collector struct {
+	clientset kubernetes.Interface
+	informer  cache.SharedIndexInformer
+	root      string
+	// events written since the collector started by pod uid
+	written map[string]map[string]bool
+	// timestamp of the last event in files written before the collector started by pod uid
+	lastTs map[string]time.Time
+}
+
+// NewCollector watches events of pods for which isLocal returns true.
+func NewCollector(c client.Client, isLocal func(podUid string) bool) *Collector {
+	return newCollector(c.Clientset, *conf.LogRootPath, isLocal)
+}
+
+func newCollector(clientset kubernetes.Interface, root string, isLocal func(podUid string) bool) *Collector {
+	selector := fields.OneTermEqualSelector("involvedObject.kind", "Pod").String()
+	isLocalEvent := func(event *v1.Event) bool {
+		return isLocal(string(event.InvolvedObject.UID))
+	}
+
+	listWatch := &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			options.FieldSelector = selector
+			list, err := clientset.CoreV1().Events(metav1.NamespaceAll).List(context.TODO(), options)
+			if err != nil {
+				return nil, err
+			}
+			list.Items = slices.DeleteFunc(list.Items, func(event v1.Event) bool { return !isLocalEvent(&event) })
+			return list, nil
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			options.FieldSelector = selector
+			w, err := clientset.CoreV1().Events(metav1.NamespaceAll).Watch(context.TODO(), options)
+			if err != nil {
+				return nil, err
+			}
+			return watch.Filter(w, func(in watch.Event) (watch.Event, bool) {
+				event, ok := in.Object.(*v1.Event)
+				return in, !ok || isLocalEvent(event)
+			}), nil
+		},
+	}
+
+	informer := cache.NewSharedIndexInformer(listWatch, &v1.Event{}, 0, cache.Indexers{indexPodUid: func(obj interface{}) ([]string, error) {
+		event, ok := obj.(*v1.Event)
+		if !ok {
+			return nil, nil
+		}
+		return []string{string(event.InvolvedObject.UID)}, nil
+	}})
+
+	return &Collector{
+		clientset: clientset,
+		informer:  informer,
+		root:      root,
+		written:   map[string]map[string]bool{},
+		lastTs:    map[string]time.Time{},
+	}
+}
+
+// Run starts watching events and waits until the cache is synced.
+func (c *Collector) Run(stopChan chan struct{}) error {
+	if err := os.MkdirAll(c.root, 0755); err != nil {
+		return err
+	}
+
+	go c.informer.Run(stopChan)
+
+	if !cache.WaitForCacheSync(stopChan, c.informer.HasSynced) {
+		return fmt.Errorf("failed to sync events")
+	}
+
+	return nil
+}
+
+// Collect writes new events of the pods and removes files of pods deleted before event.removeAfter.
+func (c *Collector) Collect(podMap map[string]v1.Pod) {
+	for uid := range podMap {
+		if err := c.collect(uid); err != nil {
+			glog.Error(err)
+		}
+	}
+
+	c.removeStaleFiles(podMap)
+}
+
+func (c *Collector) collect(uid string) error {
+	objs, err := c.informer.GetIndexer().ByIndex(indexPodUid, uid)
+	if err != nil {
+		return err
+	}
+
+	events := []*v1.Event{}
+	for _, obj := range objs {
+		if event, ok := obj.(*v1.Event); ok {
+			events = append(events, event)
+		}
+	}
+
+	// events watched before the pod is on the node are not cached
+	if _, ok := c.written[uid]; !ok {
+		listed, err := c.listEvents(uid)
+		if err != nil {
+			return err
+		}
+		events = append(events, listed...)
+	}
+
+	// recent events are written at the next collection, so events arriving late in the delay are written in order
+	settled := time.Now().Add(-*conf.WriteDelay)
+	events = slices.DeleteFunc(events, func(event *v1.Event) bool {
+		return eventTime(event).After(settled)
+	})
+	if len(events) == 0 {
+		return nil
+	}
+
+	sort.SliceStable(events, func(i, k int) bool {
+		return eventTime(events[i]).Before(eventTime(events[k]))
+	})
+
+	path := LogFilePath(c.root, uid)
+
+	lastTs, ok := c.lastTs[uid]
+	if !ok {
+		lastTs = lastTimestamp(path)
+		c.lastTs[uid] = lastTs
+	}
+
+	written, ok := c.written[uid]
+	if !ok {
+		written = map[string]bool{}
+		c.written[uid] = written
+	}
+
+	lines := []string{}
+	for _, event := range events {
+		key := fmt.Sprintf("%s/%d", event.UID, event.Count)
+		if written[key] || !eventTime(event).After(lastTs) {
+			continue
+		}
+		written[key] = true
+		lines = append(lines, FormatEvent(event))
+	}
+	if len(lines) == 0 {
+		return nil
+	}
+	c.lastTs[uid] = eventTime(events[len(events)-1])
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			glog.Error(err)
+		}
+	}()
+
+	_, err = f.WriteString(strings.Join(lines, "\n") + "\n")
+	return err
+}
+
+func (c *Collector) listEvents(uid string) ([]*v1.Event, error) {
+	list, err := c.clientset.CoreV1().Events(metav1.NamespaceAll).List(context.TODO(), metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("involvedObject.uid", uid).String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	events := []*v1.Event{}
+	for i := range list.Items {
+		if string(list.Items[i].InvolvedObject.UID) == uid {
+			events = append(events, &list.Items[i])
+		}
+	}
+
+	return events, nil
+}
+
+func (c *Collector) removeStaleFiles(podMap map[string]v1.Pod) {
+	paths, err := filepath.Glob(LogFilePath(c.root, "*"))
+	if err != nil {
+		glog.Error(err)
+		return
+	}
+
+	for _, path := range paths {
+		uid := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		if _, ok := podMap[uid]; ok {
+			continue
+		}
+
+		info, err := os.Stat(path)
+		if err != nil || time.Since(info.ModTime()) < *conf.RemoveAfter {
+			continue
+		}
+
+		if err := os.Remove(path); err != nil {
+			glog.Error(err)
+			continue
+		}
+		delete(c.written, uid)
+		delete(c.lastTs, uid)
+	}
+}
+
+// LogFilePath returns the path of the event log file of the pod.
+func LogFilePath(root, podUid string) string {
+	return fmt.Sprintf("%s/%s.log", root, podUid)
+}
+
+// FormatEvent returns a log line of the event; `{timestamp} {type} {reason} {object}: {message}`.
+func FormatEvent(event *v1.Event) string {
+	object := strings.ToLower(event.InvolvedObject.Kind) + "/" + event.InvolvedObject.Name
+	if len(event.InvolvedObject.FieldPath) > 0 {
+		object = object + " " + event.InvolvedObject.FieldPath
+	}
+
+	line := fmt.Sprintf("%s %s %s %s: %s", eventTime(event).Format(time.RFC3339Nano), event.Type, event.Reason, object, strings.ReplaceAll(event.Message, "\n", " "))
+	if event.Count > 1 {
+		line = fmt.Sprintf("%s (x%d)", line, event.Count)
+	}
+
+	return line
+}
+
+func eventTime(event *v1.Event) time.Time {
+	switch {
+	case event.Series != nil && !event.Series.LastObservedTime.IsZero():
+		return event.Series.LastObservedTime.Time
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	case !event.FirstTimestamp.IsZero():
+		return event.FirstTimestamp.Time
+	default:
+		return event.CreationTimestamp.Time
+	}
+}
+
+func lastTimestamp(path string) time.Time {
+	f, err := os.Open(path)
+	if err != nil {
+		return time.Time{}
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			glog.Error(err)
+		}
+	}()
+
+	last := time.Time{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		// lines of event log files always begin with timestamps regardless of logline.format
+		token, _, _ := strings.Cut(scanner.Text(), " ")
+		if ts, err := time.Parse(time.RFC3339Nano, token); err == nil {
+			last = ts
+		}
+	}
+
+	return last
+}
@@ -0,0 +1,173 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package event
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newEvent(name, podUid, reason string, count int32, ts time.Time) *v1.Event {
+	return &v1.Event{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "pay", UID: "event-" + types.UID(name)},
+		InvolvedObject: v1.ObjectReference{
+			Kind: "Pod",
+			Name: "api",
+			UID:  types.UID(podUid),
+		},
+		Type:          v1.EventTypeWarning,
+		Reason:        reason,
+		Message:       reason + " message",
+		Count:         count,
+		LastTimestamp: metav1.NewTime(ts),
+	}
+}
+
+func TestFormatEvent(t *testing.T) {
+	ts := time.Date(2024, 7, 17, 10, 58, 51, 0, time.UTC)
+	event := newEvent("a", "uid-a", "BackOff", 3, ts)
+	event.InvolvedObject.FieldPath = "spec.containers{api}"
+
+	expected := "2024-07-17T10:58:51Z Warning BackOff pod/api spec.containers{api}: BackOff message (x3)"
+	if line := FormatEvent(event); line != expected {
+		t.Errorf("expected %q but got %q", expected, line)
+	}
+}
+
+func TestCollect(t *testing.T) {
+	ts := time.Now().Add(-time.Minute).Truncate(time.Second)
+	clientset := fake.NewSimpleClientset(
+		newEvent("b", "uid-a", "Started", 1, ts.Add(time.Second)),
+		newEvent("a", "uid-a", "FailedScheduling", 1, ts),
+		newEvent("c", "uid-b", "Pulled", 1, ts),
+	)
+	root := t.TempDir()
+	isLocal := func(podUid string) bool { return podUid == "uid-a" }
+	c := newCollector(clientset, root, isLocal)
+	stopChan := make(chan struct{})
+	defer close(stopChan)
+
+	if err := c.Run(stopChan); err != nil {
+		t.Fatal(err)
+	}
+
+	podMap := map[string]v1.Pod{"uid-a": {}}
+	c.Collect(podMap)
+	c.Collect(podMap)
+
+	data, err := os.ReadFile(LogFilePath(root, "uid-a"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines but got %v", lines)
+	}
+	if !strings.Contains(lines[0], "FailedScheduling") || !strings.Contains(lines[1], "Started") {
+		t.Errorf("events must be written in time order: %v", lines)
+	}
+
+	if _, err := os.Stat(LogFilePath(root, "uid-b")); !os.IsNotExist(err) {
+		t.Error("events of pods not on the node must not be written")
+	}
+
+	// events already in files are not written again after restart
+	restarted := newCollector(clientset, root, isLocal)
+	if err := restarted.Run(stopChan); err != nil {
+		t.Fatal(err)
+	}
+	restarted.Collect(podMap)
+
+	if after, _ := os.ReadFile(LogFilePath(root, "uid-a")); string(after) != string(data) {
+		t.Errorf("unexpected lines after restart: %s", after)
+	}
+}
+
+func TestCollectEventsBeforePodsOnNode(t *testing.T) {
+	ts := time.Now().Add(-time.Minute).Truncate(time.Second)
+	clientset := fake.NewSimpleClientset(newEvent("a", "uid-a", "FailedScheduling", 1, ts))
+	root := t.TempDir()
+	local := map[string]bool{}
+	c := newCollector(clientset, root, func(podUid string) bool { return local[podUid] })
+	stopChan := make(chan struct{})
+	defer close(stopChan)
+
+	if err := c.Run(stopChan); err != nil {
+		t.Fatal(err)
+	}
+	if objs := c.informer.GetStore().List(); len(objs) != 0 {
+		t.Fatalf("events of pods not on the node must not be cached: %v", objs)
+	}
+
+	local["uid-a"] = true
+	c.Collect(map[string]v1.Pod{"uid-a": {}})
+
+	data, err := os.ReadFile(LogFilePath(root, "uid-a"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "FailedScheduling") {
+		t.Errorf("events before the pod is on the node must be written: %s", data)
+	}
+}
+
+func TestCollectHoldsRecentEvents(t *testing.T) {
+	ts := time.Now().Truncate(time.Second)
+	clientset := fake.NewSimpleClientset(
+		newEvent("a", "uid-a", "FailedScheduling", 1, ts.Add(-time.Minute)),
+		newEvent("b", "uid-a", "Started", 1, ts),
+	)
+	root := t.TempDir()
+	c := newCollector(clientset, root, func(string) bool { return true })
+	stopChan := make(chan struct{})
+	defer close(stopChan)
+
+	if err := c.Run(stopChan); err != nil {
+		t.Fatal(err)
+	}
+
+	podMap := map[string]v1.Pod{"uid-a": {}}
+	c.Collect(podMap)
+
+	data, err := os.ReadFile(LogFilePath(root, "uid-a"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "Started") {
+		t.Fatalf("recent events must be held until the write delay passes: %s", data)
+	}
+
+	delay := *conf.WriteDelay
+	*conf.WriteDelay = 0
+	defer func() { *conf.WriteDelay = delay }()
+	c.Collect(podMap)
+
+	data, err = os.ReadFile(LogFilePath(root, "uid-a"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n"); len(lines) != 2 || !strings.Contains(lines[1], "Started") {
+		t.Errorf("unexpected lines: %v", lines)
+	}
+}
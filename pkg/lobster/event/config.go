@@ -0,0 +1,40 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package event
+
+import (
+	"flag"
+	"time"
+)
+
+type config struct {
+	LogRootPath *string
+	RemoveAfter *time.Duration
+	WriteDelay  *time.Duration
+}
+
+func setup() config {
+	logRootPath := flag.String("event.logRootPath", "", "Path to write events of pods on the node as log files; events are not collected if it is empty")
+	removeAfter := flag.Duration("event.removeAfter", time.Hour, "Time to remove event log files after pods are deleted")
+	writeDelay := flag.Duration("event.writeDelay", 5*time.Second, "Time to wait for events arriving late before events are written in time order")
+
+	return config{
+		LogRootPath: logRootPath,
+		RemoveAfter: removeAfter,
+		WriteDelay:  writeDelay,
+	}
+}
@@ -43,6 +43,8 @@ type config struct {
 	ContentsLimit              *uint64
 	FetchTimeout               *time.Duration
 	FetchResponseHeaderTimeout *time.Duration
+	StoreGrpcPort              *string
+	StoreGrpcMaxRecvMsgSize    *int
 }
 
 func setup() config {
@@ -53,6 +55,8 @@ func setup() config {
 	contentsLimit := flag.Uint64("global.contentsLimit", 1000*1000*30, "Limit the amount of responsive content per page")
 	fetchTimeout := flag.Duration("global.fetchTimeout", 10*time.Second, "Response timeout for log requests")
 	fetchResponseHeaderTimeout := flag.Duration("global.fetchResponseHeaderTimeout", 10*time.Second, "Header response timeout for log requests; delays may occur during file reading")
+	storeGrpcPort := flag.String("global.storeGrpcPort", "11130", "grpc port of stores to stream series and logs; http is used only if empty")
+	storeGrpcMaxRecvMsgSize := flag.Int("global.storeGrpcMaxRecvMsgSize", 64*1024*1024, "The maximum size (in bytes) of a message that can be received from stores")
 
 	return config{
 		LobsterQueries:             lobsterQueries,
@@ -61,5 +65,7 @@ func setup() config {
 		ContentsLimit:              contentsLimit,
 		FetchTimeout:               fetchTimeout,
 		FetchResponseHeaderTimeout: fetchResponseHeaderTimeout,
+		StoreGrpcPort:              storeGrpcPort,
+		StoreGrpcMaxRecvMsgSize:    storeGrpcMaxRecvMsgSize,
 	}
 }
@@ -17,6 +17,7 @@
 package global
 
 import (
+	"context"
 	"errors"
 	"log"
 	"net"
@@ -24,6 +25,7 @@ import (
 	"time"
 
 	"github.com/golang/glog"
+	"github.com/naver/lobster/pkg/lobster/cache"
 	"github.com/naver/lobster/pkg/lobster/metrics"
 	"github.com/naver/lobster/pkg/lobster/model"
 	"github.com/naver/lobster/pkg/lobster/querier"
@@ -65,10 +67,19 @@ func NewQuerier() *Querier {
 		clusters = append(clusters, r.Cluster)
 	}
 
+	var resultCache *cache.Cache
+	if cache.Enabled() {
+		c, err := cache.NewCache()
+		if err != nil {
+			panic(err)
+		}
+		resultCache = c
+	}
+
 	glog.Infof("actual clusters after host lookup: %s", strings.Join(clusters, ","))
 	return &Querier{
 		Broker:  broker.NewBroker(remoteAddrs),
-		Fetcher: querier.NewFetcher(*conf.FetchTimeout, *conf.FetchResponseHeaderTimeout),
+		Fetcher: querier.NewFetcher(*conf.FetchTimeout, *conf.FetchResponseHeaderTimeout, *conf.StoreGrpcPort, *conf.StoreGrpcMaxRecvMsgSize, resultCache),
 		remotes: remotes,
 	}
 }
@@ -100,8 +111,8 @@ func parseRemotes() []remote {
 	return remotes
 }
 
-func (q *Querier) GetChunksWithinRange(req query.Request) (chunks []model.Chunk, err error) {
-	chunks, err = q.RequestChunksWithinRange(req, true)
+func (q *Querier) GetChunksWithinRange(ctx context.Context, req query.Request) (chunks []model.Chunk, err error) {
+	chunks, err = q.RequestChunksWithinRange(ctx, req, true)
 	if err != nil {
 		return
 	}
@@ -110,18 +121,18 @@ func (q *Querier) GetChunksWithinRange(req query.Request) (chunks []model.Chunk,
 	return
 }
 
-func (q *Querier) GetSeriesInBlocksWithinRange(req query.Request) (numOfChunk int, series model.SeriesData, err error) {
+func (q *Querier) GetSeriesInBlocksWithinRange(ctx context.Context, req query.Request) (numOfChunk int, series model.SeriesData, err error) {
 	var (
 		chunks  []model.Chunk
 		results []querier.FetchResult
 	)
 
-	chunks, err = q.RequestChunksWithinRange(req, true)
+	chunks, err = q.RequestChunksWithinRange(ctx, req, true)
 	if err != nil {
 		return
 	}
 
-	results, err = q.Fetch(req, chunks, logHandler.PathLogSeries)
+	results, err = q.Fetch(ctx, req, chunks, logHandler.PathLogSeries)
 	if err != nil {
 		return
 	}
@@ -136,10 +147,40 @@ func (q *Querier) GetSeriesInBlocksWithinRange(req query.Request) (numOfChunk in
 	return
 }
 
-func (q *Querier) GetBlocksWithinRange(req query.Request) (data []byte, _, _ time.Time, numOfChunk int, pageInfo model.PageInfo, err error) {
+func (q *Querier) GetAggregationWithinRange(ctx context.Context, req query.Request) (numOfChunk int, data model.AggregationData, err error) {
+	var (
+		chunks  []model.Chunk
+		results []querier.FetchResult
+	)
+
+	chunks, err = q.RequestChunksWithinRange(ctx, req, true)
+	if err != nil {
+		return
+	}
+
+	results, err = q.Fetch(ctx, req, chunks, logHandler.PathLogAggregate)
+	if err != nil {
+		return
+	}
+
+	data, err = querier.NewAggregationBuilder(results).
+		Merge().
+		Build(*req.Aggregation)
+	if err != nil {
+		return
+	}
+
+	numOfChunk = len(chunks)
+
+	glog.V(3).Infof("chunks %d | fetched %d | groups %d | %s", numOfChunk, len(results), len(data), req.String())
+	return
+}
+
+func (q *Querier) GetBlocksWithinRange(ctx context.Context, req query.Request) (data []byte, _, _ time.Time, numOfChunk int, pageInfo model.PageInfo, err error) {
 	var (
 		chunks           []model.Chunk
 		results          []querier.FetchResult
+		subReq           query.Request
 		isPartialEntries bool
 		limit            = *conf.ContentsLimit
 	)
@@ -148,20 +189,24 @@ func (q *Querier) GetBlocksWithinRange(req query.Request) (data []byte, _, _ tim
 		limit = req.ContentsLimit
 	}
 
-	chunks, err = q.RequestChunksWithinRange(req, true)
+	chunks, err = q.RequestChunksWithinRange(ctx, req, true)
 	if err != nil {
 		return
 	}
 
-	results, pageInfo, err = q.GetLogEntries(req, chunks, limit)
+	results, subReq, pageInfo, err = q.GetLogEntries(ctx, req, chunks, limit)
 	if err != nil {
 		return
 	}
 
-	data, isPartialEntries = querier.NewEntryBuilder(results, limit).
-		Merge(querier.ParseEntryRaw).
-		SortAscending().
-		BuildRawLogs()
+	builder := querier.NewEntryBuilder(results, limit).
+		Paginate(subReq, pageInfo.HasNext).
+		Merge(query.ParseEntryRaw).
+		SortByDirection()
+
+	data, isPartialEntries = builder.BuildRawLogs()
+	pageInfo.Cursor = builder.NextCursor()
+	pageInfo.HasNext = len(pageInfo.Cursor) > 0
 
 	if isPartialEntries || pageInfo.IsPartialContents {
 		pageInfo.IsPartialContents = true
@@ -174,10 +219,11 @@ func (q *Querier) GetBlocksWithinRange(req query.Request) (data []byte, _, _ tim
 	return
 }
 
-func (q *Querier) GetEntriesWithinRange(req query.Request) (data []model.Entry, numOfChunk int, pageInfo model.PageInfo, err error) {
+func (q *Querier) GetEntriesWithinRange(ctx context.Context, req query.Request) (data []model.Entry, numOfChunk int, pageInfo model.PageInfo, err error) {
 	var (
 		chunks           []model.Chunk
 		results          []querier.FetchResult
+		subReq           query.Request
 		isPartialEntries bool
 		limit            = *conf.ContentsLimit
 	)
@@ -186,20 +232,24 @@ func (q *Querier) GetEntriesWithinRange(req query.Request) (data []model.Entry,
 		limit = req.ContentsLimit
 	}
 
-	chunks, err = q.RequestChunksWithinRange(req, true)
+	chunks, err = q.RequestChunksWithinRange(ctx, req, true)
 	if err != nil {
 		return
 	}
 
-	results, pageInfo, err = q.GetLogEntries(req, chunks, limit)
+	results, subReq, pageInfo, err = q.GetLogEntries(ctx, req, chunks, limit)
 	if err != nil {
 		return
 	}
 
-	data, isPartialEntries = querier.NewEntryBuilder(results, limit).
-		Merge(querier.ParseEntry).
-		SortAscending().
-		Build()
+	builder := querier.NewEntryBuilder(results, limit).
+		Paginate(subReq, pageInfo.HasNext).
+		Merge(query.ParseEntry).
+		SortByDirection()
+
+	data, isPartialEntries = builder.Build()
+	pageInfo.Cursor = builder.NextCursor()
+	pageInfo.HasNext = len(pageInfo.Cursor) > 0
 
 	if isPartialEntries || pageInfo.IsPartialContents {
 		pageInfo.IsPartialContents = true
@@ -24,6 +24,7 @@ import (
 	"time"
 
 	"github.com/golang/glog"
+	"github.com/naver/lobster/pkg/lobster/mtls"
 )
 
 const (
@@ -35,7 +36,7 @@ const (
 
 // probeClient is separate from the clients that carry log requests; a status
 // probe must not wait as long as a log query.
-var probeClient = &http.Client{Timeout: probeTimeout}
+var probeClient = &http.Client{Timeout: probeTimeout, Transport: &http.Transport{TLSClientConfig: mtls.ClientConfig()}}
 
 // ClusterStatus is one configured lobsterQuery entry and whether it answers.
 type ClusterStatus struct {
@@ -88,7 +89,7 @@ func probe(r remote) ClusterStatus {
 		return status
 	}
 
-	resp, err := probeClient.Get(fmt.Sprintf("http://%s/health", r.Address))
+	resp, err := probeClient.Get(fmt.Sprintf("%s://%s/health", mtls.Scheme(), r.Address))
 	if err != nil {
 		status.Error = err.Error()
 		return status
@@ -34,6 +34,8 @@ import (
 const (
 	LogExt              = ".log"
 	EmptyDirDescription = "__emptydir__"
+	HostDescription     = "__host__"
+	EventDescription    = "__event__"
 )
 
 type LabelFunc func(string) (model.Labels, error)
@@ -172,6 +174,77 @@ func LoadPodEmptyDir(root string, podMap map[string]v1.Pod) []model.LogFile {
 	return logfiles
 }
 
+// LoadHostLogFiles returns files matched by globs as logs of the node.
+func LoadHostLogFiles(globs []string, nodeName string) []model.LogFile {
+	logfiles := []model.LogFile{}
+
+	for _, glob := range globs {
+		paths, err := filepath.Glob(glob)
+		if err != nil {
+			glog.Error(err)
+			continue
+		}
+
+		for _, path := range paths {
+			info, err := os.Stat(path)
+			if err != nil || !info.Mode().IsRegular() || info.Size() == 0 {
+				continue
+			}
+
+			logfiles = append(logfiles, model.LogFile{
+				Namespace: model.HostNamespace,
+				Labels:    map[string]string{"node": nodeName},
+				Pod:       nodeName,
+				PodUid:    nodeName,
+				Container: HostDescription,
+				FileName:  info.Name(),
+				Path:      path,
+				Source: model.Source{
+					Type: model.LogTypeHostFile,
+					Path: sanitizePath(path, ""),
+				},
+				Number:        0,
+				ModTime:       info.ModTime(),
+				InspectedSize: info.Size(),
+			})
+		}
+	}
+
+	return logfiles
+}
+
+// LoadEventLogFiles returns event log files of pods, which are named by uids of pods.
+func LoadEventLogFiles(root string, podMap map[string]v1.Pod) []model.LogFile {
+	logfiles := []model.LogFile{}
+
+	for uid, pod := range podMap {
+		path := fmt.Sprintf("%s/%s%s", root, uid, LogExt)
+		info, err := os.Stat(path)
+		if err != nil || !info.Mode().IsRegular() || info.Size() == 0 {
+			continue
+		}
+
+		logfiles = append(logfiles, model.LogFile{
+			Namespace: pod.Namespace,
+			Labels:    pod.Labels,
+			Pod:       pod.Name,
+			PodUid:    uid,
+			Container: EventDescription,
+			FileName:  info.Name(),
+			Path:      path,
+			Source: model.Source{
+				Type: model.LogTypeEvent,
+				Path: model.EventSourcePath,
+			},
+			Number:        0,
+			ModTime:       info.ModTime(),
+			InspectedSize: info.Size(),
+		})
+	}
+
+	return logfiles
+}
+
 func findLogFiles(root string) map[string]os.FileInfo {
 	var files = map[string]os.FileInfo{}
 
@@ -214,5 +287,13 @@ func getLogType(dir string) string {
 		return model.LogTypeEmptyDirFile
 	}
 
+	if strings.HasPrefix(dir, model.LogTypeHostFile+model.LogTypeDelimiter) {
+		return model.LogTypeHostFile
+	}
+
+	if strings.HasPrefix(dir, model.LogTypeEvent+model.LogTypeDelimiter) {
+		return model.LogTypeEvent
+	}
+
 	return model.LogTypeStdStream
 }
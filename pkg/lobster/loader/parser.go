@@ -77,12 +77,18 @@ func ParseStoredLogFile(logType, path string, mTime time.Time, size int64, appea
 	)
 
 	switch logType {
-	case model.LogTypeEmptyDirFile:
+	case model.LogTypeEmptyDirFile, model.LogTypeHostFile, model.LogTypeEvent:
 		subTokens := strings.Split(logDir, model.LogTypeDelimiter)
 		if len(subTokens) != 2 {
 			return nil, errors.New("failed to parse log type : " + logDir)
 		}
 		container = EmptyDirDescription
+		switch logType {
+		case model.LogTypeHostFile:
+			container = HostDescription
+		case model.LogTypeEvent:
+			container = EventDescription
+		}
 		pathInContainer = subTokens[1]
 	default:
 		container = logDir
@@ -27,14 +27,17 @@ const (
 )
 
 type Config struct {
-	LogFormat *string
+	LogFormat         *string
+	MaxJoinedLineSize *int
 }
 
 func Setup() {
 	logFormat := flag.String("logline.format", LogFormatText, "log line format(text or json)")
+	maxJoinedLineSize := flag.Int("logline.maxJoinedLineSize", 1<<20, "Max size of a message joined from CRI partial lines; the rest is truncated")
 
 	conf = Config{
-		LogFormat: logFormat,
+		LogFormat:         logFormat,
+		MaxJoinedLineSize: maxJoinedLineSize,
 	}
 
 	log.Println("logline configuration is loaded")
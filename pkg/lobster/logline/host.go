@@ -0,0 +1,82 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package logline
+
+import (
+	"time"
+)
+
+const (
+	syslogTimestampLayout = "Jan _2 15:04:05"
+	klogTimestampLayout   = "0102 15:04:05.000000"
+)
+
+// StampHostLogLine prefixes a line of host log files with its timestamp, so it is stored like lines of containers.
+// Timestamps of RFC3339, syslog(`Jan _2 15:04:05`) and klog(`I0102 15:04:05.000000`) are recognized at the start of the line;
+// the fallback is used for the others.
+func StampHostLogLine(line string, fallback time.Time) string {
+	if _, err := getTimestampInTextLogLine(line); err == nil {
+		return line
+	}
+
+	ts, ok := parseHostTimestamp(line, fallback)
+	if !ok {
+		ts = fallback
+	}
+
+	return ts.Format(time.RFC3339Nano) + " " + line
+}
+
+// ParseHostTimestamp returns the timestamp at the start of a line of host log files; years are guessed by now if omitted.
+func ParseHostTimestamp(line string, now time.Time) (time.Time, bool) {
+	if ts, err := getTimestampInTextLogLine(line); err == nil {
+		return ts, true
+	}
+
+	return parseHostTimestamp(line, now)
+}
+
+func parseHostTimestamp(line string, now time.Time) (time.Time, bool) {
+	var (
+		ts  time.Time
+		err error
+	)
+
+	switch {
+	case len(line) >= len(syslogTimestampLayout) && line[3] == ' ':
+		ts, err = time.ParseInLocation(syslogTimestampLayout, line[:len(syslogTimestampLayout)], time.Local)
+	case len(line) > len(klogTimestampLayout) && isKlogSeverity(line[0]):
+		ts, err = time.ParseInLocation(klogTimestampLayout, line[1:len(klogTimestampLayout)+1], time.Local)
+	default:
+		return time.Time{}, false
+	}
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	// years are omitted, so logs of the last year are written around new year
+	ts = ts.AddDate(now.Year(), 0, 0)
+	if ts.After(now.Add(24 * time.Hour)) {
+		ts = ts.AddDate(-1, 0, 0)
+	}
+
+	return ts, true
+}
+
+func isKlogSeverity(c byte) bool {
+	return c == 'I' || c == 'W' || c == 'E' || c == 'F'
+}
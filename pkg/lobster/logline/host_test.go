@@ -0,0 +1,48 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package logline
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStampHostLogLine(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.Local)
+
+	testData := map[string]time.Time{
+		"2024-03-10T11:00:00Z containerd started":                   time.Date(2024, 3, 10, 11, 0, 0, 0, time.UTC),
+		"Mar  9 23:59:58 node-a systemd[1]: Started kubelet":        time.Date(2024, 3, 9, 23, 59, 58, 0, time.Local),
+		"Dec 31 23:59:59 node-a kernel: eth0: link down":            time.Date(2023, 12, 31, 23, 59, 59, 0, time.Local),
+		"I0310 11:30:00.123456    1234 kubelet.go:1000] pod synced": time.Date(2024, 3, 10, 11, 30, 0, 123456000, time.Local),
+		"level=info msg=\"no timestamp\"":                           now,
+		"E0310 bad klog":                                            now,
+	}
+
+	for question, expected := range testData {
+		stamped := StampHostLogLine(question, now)
+
+		ts, err := getTimestampInTextLogLine(stamped)
+		if err != nil {
+			t.Errorf("%q: %s", stamped, err)
+			continue
+		}
+		if !ts.Equal(expected) {
+			t.Errorf("%q: expected %v but %v", question, expected, ts)
+		}
+	}
+}
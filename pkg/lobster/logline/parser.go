@@ -126,6 +126,10 @@ func ParseTag(str string) (string, error) {
 		return "", errors.New("unsupported logline.format")
 	}
 
+	if _, tag, _, ok := splitTextLogLine(str); ok {
+		return tag, nil
+	}
+
 	tagLen := 1
 	idx := strings.Index(str, "F")
 	if idx <= 0 {
@@ -138,7 +142,65 @@ func ParseTag(str string) (string, error) {
 	return str[idx : idx+tagLen], nil
 }
 
+// splitTextLogLine splits `{timestamp} {stream} {tag} {message}` of the CRI format.
+// The tag may have additional tags after the delimiter such as `F:T`.
+func splitTextLogLine(str string) (stream, tag, msg string, ok bool) {
+	fields := strings.SplitN(str, " ", 4)
+	if len(fields) < 3 {
+		return "", "", "", false
+	}
+
+	if fields[1] != "stdout" && fields[1] != "stderr" {
+		return "", "", "", false
+	}
+
+	switch tag, _, _ := strings.Cut(fields[2], tagDelimiter); tag {
+	case TagFull, TagPartial:
+	default:
+		return "", "", "", false
+	}
+
+	if len(fields) == 4 {
+		msg = fields[3]
+	}
+
+	return fields[1], fields[2], msg, true
+}
+
+// CutStoredPrefix returns the message of a stored line without the prefix written with it;
+// a RFC3339 timestamp followed by the stream and the tag of the CRI format or the mark of an unreliable timestamp.
+// The line is returned as is if it doesn't start with the prefix.
+func CutStoredPrefix(line string) (string, bool) {
+	if *conf.LogFormat == LogFormatJson {
+		if msg, err := getLogMessageInJsonLogLine(line); err == nil {
+			return msg, true
+		}
+		return line, false
+	}
+
+	ts, rest, found := strings.Cut(line, " ")
+	if !found {
+		return line, false
+	}
+	if _, err := time.Parse(time.RFC3339Nano, ts); err != nil {
+		return line, false
+	}
+
+	if _, _, msg, ok := splitTextLogLine(line); ok {
+		return msg, true
+	}
+	if msg, ok := strings.CutPrefix(rest, unreliableTimestamp+" "); ok {
+		return msg, true
+	}
+
+	return rest, true
+}
+
 func getLogMessageInTextLogLine(str string) (string, error) {
+	if _, _, msg, ok := splitTextLogLine(str); ok {
+		return msg, nil
+	}
+
 	idx := strings.Index(str, "F")
 	if idx <= 0 {
 		idx = strings.Index(str, "P")
@@ -0,0 +1,124 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package logline
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	TagFull    = "F"
+	TagPartial = "P"
+	// TagTruncated is added to the tag of a line joined from partial lines, e.g. `F:T`, if its message is truncated
+	TagTruncated = "T"
+
+	tagDelimiter = ":"
+)
+
+type partialLine struct {
+	ts        time.Time
+	prefix    string
+	msg       strings.Builder
+	truncated bool
+}
+
+// PartialJoiner joins CRI partial lines(`P`) of a stream with the following full line(`F`)
+// into a line having the timestamp of the first partial line.
+// Joined messages exceeding logline.maxJoinedLineSize are truncated, tagged with TagTruncated and reported to onTruncated.
+type PartialJoiner struct {
+	format      string
+	maxSize     int
+	partials    map[string]*partialLine
+	onTruncated func()
+}
+
+func NewPartialJoiner(onTruncated func()) *PartialJoiner {
+	return newPartialJoiner(*conf.LogFormat, *conf.MaxJoinedLineSize, onTruncated)
+}
+
+func newPartialJoiner(format string, maxSize int, onTruncated func()) *PartialJoiner {
+	return &PartialJoiner{format, maxSize, map[string]*partialLine{}, onTruncated}
+}
+
+// Join returns the full line or false if the line is partial; line must not have the newline.
+func (j *PartialJoiner) Join(ts time.Time, line string) (time.Time, string, bool) {
+	if j.format != LogFormatText {
+		return ts, line, true
+	}
+
+	stream, tag, msg, ok := splitTextLogLine(line)
+	if !ok {
+		return ts, line, true
+	}
+
+	partial, pending := j.partials[stream]
+	if !pending && tag != TagPartial {
+		return ts, line, true
+	}
+
+	if !pending {
+		// keep `{timestamp} {stream} ` of the first partial line
+		partial = &partialLine{ts: ts, prefix: line[:strings.Index(line, " ")+len(stream)+2]}
+		j.partials[stream] = partial
+	}
+
+	if remains := j.maxSize - partial.msg.Len(); len(msg) > remains {
+		msg = msg[:max(remains, 0)]
+		partial.truncated = true
+	}
+	partial.msg.WriteString(msg)
+
+	if tag == TagPartial {
+		return ts, "", false
+	}
+
+	delete(j.partials, stream)
+	j.reportTruncated(partial)
+
+	return partial.ts, partial.prefix + partial.tag(TagFull) + " " + partial.msg.String(), true
+}
+
+// Flush returns lines joined from partial lines left without the full line in timestamp order.
+func (j *PartialJoiner) Flush() []LogLine {
+	lines := []LogLine{}
+
+	for stream, partial := range j.partials {
+		j.reportTruncated(partial)
+		lines = append(lines, LogLine{Timestamp: partial.ts, Line: partial.prefix + partial.tag(TagPartial) + " " + partial.msg.String()})
+		delete(j.partials, stream)
+	}
+
+	sort.Slice(lines, func(i, k int) bool { return lines[i].Timestamp.Before(lines[k].Timestamp) })
+
+	return lines
+}
+
+func (p *partialLine) tag(tag string) string {
+	if p.truncated {
+		return tag + tagDelimiter + TagTruncated
+	}
+
+	return tag
+}
+
+func (j *PartialJoiner) reportTruncated(partial *partialLine) {
+	if partial.truncated && j.onTruncated != nil {
+		j.onTruncated()
+	}
+}
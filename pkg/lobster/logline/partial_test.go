@@ -0,0 +1,93 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package logline
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPartialJoiner(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	joiner := newPartialJoiner(LogFormatText, 1024, nil)
+
+	inputs := []string{
+		"2024-01-01T00:00:00Z stdout P hello ",
+		"2024-01-01T00:00:01Z stderr F error",
+		"2024-01-01T00:00:02Z stdout P wor",
+		"2024-01-01T00:00:03Z stdout F ld",
+	}
+	expected := []string{
+		"2024-01-01T00:00:01Z stderr F error",
+		"2024-01-01T00:00:00Z stdout F hello world",
+	}
+
+	joined := []string{}
+	for i, input := range inputs {
+		ts, line, ok := joiner.Join(base.Add(time.Duration(i)*time.Second), input)
+		if !ok {
+			continue
+		}
+		if line == expected[1] && !ts.Equal(base) {
+			t.Errorf("expected timestamp of the first partial line but %v", ts)
+		}
+		joined = append(joined, line)
+	}
+
+	if len(joined) != len(expected) {
+		t.Fatalf("expected %v but %v", expected, joined)
+	}
+	for i := range expected {
+		if joined[i] != expected[i] {
+			t.Errorf("expected %q but %q", expected[i], joined[i])
+		}
+	}
+}
+
+func TestPartialJoinerTruncated(t *testing.T) {
+	truncated := 0
+	joiner := newPartialJoiner(LogFormatText, 8, func() { truncated++ })
+
+	if _, _, ok := joiner.Join(time.Now(), "2024-01-01T00:00:00Z stdout P 123456"); ok {
+		t.Fatal("partial line must not be returned")
+	}
+	_, line, ok := joiner.Join(time.Now(), "2024-01-01T00:00:01Z stdout F 7890")
+	if !ok {
+		t.Fatal("full line must be returned")
+	}
+
+	if expected := "2024-01-01T00:00:00Z stdout F:T 12345678"; line != expected {
+		t.Errorf("expected %q but %q", expected, line)
+	}
+	if truncated != 1 {
+		t.Errorf("expected a truncated line but %d", truncated)
+	}
+}
+
+func TestPartialJoinerFlush(t *testing.T) {
+	joiner := newPartialJoiner(LogFormatText, 1024, nil)
+
+	joiner.Join(time.Now(), "2024-01-01T00:00:00Z stdout P incomplete")
+
+	lines := joiner.Flush()
+	if len(lines) != 1 || lines[0].Line != "2024-01-01T00:00:00Z stdout P incomplete" {
+		t.Errorf("unexpected flushed lines: %v", lines)
+	}
+	if len(joiner.Flush()) != 0 {
+		t.Error("flushed lines must be removed")
+	}
+}
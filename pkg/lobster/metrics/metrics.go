@@ -46,6 +46,8 @@ const (
 	labelHandler    = "handler"
 	labelStatusCode = "code"
 	labelLimit      = "limit"
+	labelRule       = "rule"
+	labelAction     = "action"
 
 	metricPath = "/metrics"
 )
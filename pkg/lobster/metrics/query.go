@@ -30,11 +30,17 @@ var (
 		Name: "lobster_querier_partial_response_total",
 		Help: "A Number of chunks in querier.",
 	}, []string{})
+
+	cacheRequestTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "lobster_querier_cache_requests_total",
+		Help: "A Number of results requested to the cache.",
+	}, []string{"result"})
 )
 
 func RegisterQuerierMetrics() {
 	prometheus.MustRegister(storedChunks)
 	prometheus.MustRegister(partialResponseTotal)
+	prometheus.MustRegister(cacheRequestTotal)
 }
 
 func SetStoredChunks(chunks float64) {
@@ -44,3 +50,8 @@ func SetStoredChunks(chunks float64) {
 func IncreasePartialResponseCount() {
 	partialResponseTotal.WithLabelValues().Inc()
 }
+
+func AddCacheRequests(hits, misses int) {
+	cacheRequestTotal.WithLabelValues("hit").Add(float64(hits))
+	cacheRequestTotal.WithLabelValues("miss").Add(float64(misses))
+}
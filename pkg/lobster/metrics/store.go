@@ -23,6 +23,7 @@ import (
 var (
 	chunkKeys          = promLabelsKeys(emptyChunkLabelValues())
 	chunkKeysWithLimit = append(promLabelsKeys(emptyChunkLabelValues()), labelLimit)
+	chunkKeysWithRule  = append(promLabelsKeys(emptyChunkLabelValues()), labelRule, labelAction)
 
 	blockTotal = prometheus.NewGaugeVec(prometheus.GaugeOpts{
 		Name: "lobster_blocks",
@@ -49,6 +50,21 @@ var (
 		Help: "A Number of stoping due to overloaded logs.",
 	}, chunkKeysWithLimit)
 
+	redacted = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "lobster_redacted_lines_total",
+		Help: "A number of lines masked or dropped by redaction rules.",
+	}, chunkKeysWithRule)
+
+	truncated = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "lobster_truncated_lines_total",
+		Help: "A number of lines truncated while joining partial lines.",
+	}, chunkKeys)
+
+	notOffloaded = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "lobster_blocks_not_offloaded_total",
+		Help: "A number of blocks deleted to free the disk before they are offloaded to the cold tier.",
+	}, []string{})
+
 	pushError = prometheus.NewCounterVec(prometheus.CounterOpts{
 		Name: "lobster_push_errors_total",
 		Help: "An error occurred during pushing",
@@ -86,6 +102,9 @@ func RegisterStoreMetrics() {
 	prometheus.MustRegister(tailedBytes)
 	prometheus.MustRegister(tailedLines)
 	prometheus.MustRegister(overloaded)
+	prometheus.MustRegister(redacted)
+	prometheus.MustRegister(truncated)
+	prometheus.MustRegister(notOffloaded)
 	prometheus.MustRegister(pushError)
 	prometheus.MustRegister(capOflimit)
 	prometheus.MustRegister(usageOflimit)
@@ -132,6 +151,22 @@ func AddOverloadedCount(namespace, pod, container, sourceType, sourcePath, limit
 	overloaded.With(labels).Add(1)
 }
 
+func AddRedactedCount(namespace, pod, container, sourceType, sourcePath, rule, action string) {
+	labels := chunkLabelValues(namespace, pod, container, sourceType, sourcePath)
+	labels[labelRule] = rule
+	labels[labelAction] = action
+
+	redacted.With(labels).Add(1)
+}
+
+func AddTruncatedCount(namespace, pod, container, sourceType, sourcePath string) {
+	truncated.With(chunkLabelValues(namespace, pod, container, sourceType, sourcePath)).Add(1)
+}
+
+func AddNotOffloadedBlocks(count int) {
+	notOffloaded.WithLabelValues().Add(float64(count))
+}
+
 func AddPushError() {
 	pushError.WithLabelValues().Inc()
 }
@@ -168,5 +203,7 @@ func Delete(namespace, pod, container, sourceType, sourcePath string) {
 	tailedBytes.DeletePartialMatch(labelChunk)
 	tailedLines.DeletePartialMatch(labelChunk)
 	overloaded.DeletePartialMatch(labelChunk)
+	redacted.DeletePartialMatch(labelChunk)
+	truncated.DeletePartialMatch(labelChunk)
 	flushSeconds.DeletePartialMatch(labelChunk)
 }
@@ -0,0 +1,89 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+type AggregatedValue struct {
+	Timestamp time.Time `json:"timestamp"`
+	Value     float64   `json:"value"`
+}
+
+// AggregatedSeries
+// @Description Samples are partial lines and bytes per step from stores and values are results of the function.
+type AggregatedSeries struct {
+	Labels  Labels            `json:"labels"`
+	Samples []Sample          `json:"samples,omitempty"`
+	Values  []AggregatedValue `json:"values,omitempty"`
+	Total   float64           `json:"total"`
+}
+
+// AggregationData
+// @Description Array contains AggregatedSeries.
+type AggregationData []*AggregatedSeries
+
+// Merge sums samples of series having the same labels at the same timestamps.
+func (d AggregationData) Merge(others ...AggregationData) AggregationData {
+	merged := AggregationData{}
+	groups := map[string]map[int64]*Sample{}
+	labels := map[string]Labels{}
+
+	for _, data := range append([]AggregationData{d}, others...) {
+		for _, series := range data {
+			key := labelsKey(series.Labels)
+			if _, ok := groups[key]; !ok {
+				groups[key] = map[int64]*Sample{}
+				labels[key] = series.Labels
+			}
+
+			for _, s := range series.Samples {
+				v, ok := groups[key][s.Timestamp.UnixNano()]
+				if !ok {
+					groups[key][s.Timestamp.UnixNano()] = &Sample{Timestamp: s.Timestamp, Lines: s.Lines, Size: s.Size}
+					continue
+				}
+				v.Lines = v.Lines + s.Lines
+				v.Size = v.Size + s.Size
+			}
+		}
+	}
+
+	for key, sampleMap := range groups {
+		series := &AggregatedSeries{Labels: labels[key]}
+		for _, sample := range sampleMap {
+			series.Samples = append(series.Samples, *sample)
+		}
+
+		sort.Slice(series.Samples, func(i, j int) bool {
+			return series.Samples[i].Timestamp.Before(series.Samples[j].Timestamp)
+		})
+
+		merged = append(merged, series)
+	}
+
+	return merged
+}
+
+func labelsKey(labels Labels) string {
+	pairs := labels.Pairs()
+	sort.Strings(pairs)
+	return strings.Join(pairs, LabelsDelimiter)
+}
@@ -0,0 +1,61 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAggregationDataMerge(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(min int) time.Time { return base.Add(time.Duration(min) * time.Minute) }
+
+	stores := []AggregationData{
+		{
+			{Labels: Labels{"namespace": "a"}, Samples: []Sample{{Timestamp: at(1), Lines: 1, Size: 10}}},
+			{Labels: Labels{"namespace": "b"}, Samples: []Sample{{Timestamp: at(0), Lines: 2, Size: 20}}},
+		},
+		{
+			{Labels: Labels{"namespace": "a"}, Samples: []Sample{
+				{Timestamp: at(1).In(time.Local), Lines: 4, Size: 40},
+				{Timestamp: at(0), Lines: 8, Size: 80},
+			}},
+		},
+	}
+
+	merged := AggregationData{}.Merge(stores...)
+	if len(merged) != 2 {
+		t.Fatalf("groups = %d, want 2", len(merged))
+	}
+
+	for _, series := range merged {
+		if series.Labels["namespace"] != "a" {
+			continue
+		}
+
+		want := []Sample{{Timestamp: at(0), Lines: 8, Size: 80}, {Timestamp: at(1), Lines: 5, Size: 50}}
+		if len(series.Samples) != len(want) {
+			t.Fatalf("samples = %v, want %v", series.Samples, want)
+		}
+		for i, s := range series.Samples {
+			if !s.Timestamp.Equal(want[i].Timestamp) || s.Lines != want[i].Lines || s.Size != want[i].Size {
+				t.Errorf("sample %d = %v, want %v", i, s, want[i])
+			}
+		}
+	}
+}
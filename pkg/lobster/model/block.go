@@ -18,11 +18,14 @@ package model
 
 import (
 	"fmt"
+	"strings"
 	"time"
 )
 
 const (
 	TempBlockFileName  = "temp.log"
+	BlockIndexExt      = ".idx"
+	BlockBloomExt      = ".bloom"
 	blockNameDelimiter = "_"
 )
 
@@ -46,27 +49,30 @@ type Block struct {
 	StartedAt    time.Time
 	EndedAt      time.Time
 	Line         int64
-	Size         int64
+	Size         int64 // logical size of logs
+	DiskSize     int64 // size of the block file which may be compressed
 	FileNum      int64
 	DeletionMark bool
 }
 
-func NewBlock(start, end time.Time, line, size, fileNum int64) *Block {
+func NewBlock(start, end time.Time, line, size, diskSize, fileNum int64) *Block {
 	return &Block{
 		StartedAt: start,
 		EndedAt:   end,
 		Line:      line,
 		Size:      size,
+		DiskSize:  diskSize,
 		FileNum:   fileNum,
 	}
 }
 
-func NewBlockFromTempBlock(tempBlock TempBlock, fileNum int64) *Block {
+func NewBlockFromTempBlock(tempBlock TempBlock, diskSize, fileNum int64) *Block {
 	return &Block{
 		StartedAt: tempBlock.StartedAt,
 		EndedAt:   tempBlock.EndedAt,
 		Line:      tempBlock.Line,
 		Size:      tempBlock.Size,
+		DiskSize:  diskSize,
 		FileNum:   fileNum,
 	}
 }
@@ -90,10 +96,29 @@ func (b Block) FileName() string {
 		b.FileNum)
 }
 
+func (b Block) IndexFileName() string {
+	return strings.TrimSuffix(b.FileName(), ".log") + BlockIndexExt
+}
+
+func (b Block) BloomFileName() string {
+	return strings.TrimSuffix(b.FileName(), ".log") + BlockBloomExt
+}
+
+func (b Block) SidecarFileNames() []string {
+	return []string{b.IndexFileName(), b.BloomFileName()}
+}
+
 func (b Block) FileNumber() int64 {
 	return b.FileNum
 }
 
+func (b Block) StoredSize() int64 {
+	if b.DiskSize > 0 {
+		return b.DiskSize
+	}
+	return b.Size
+}
+
 func (b TempBlock) FileNumber() int64 {
 	return b.FileNum
 }
@@ -36,6 +36,7 @@ type Chunk struct {
 	Namespace           string      `json:"namespace"`
 	Labels              Labels      `json:"labels"`
 	SetName             string      `json:"setName"`
+	Workloads           Workloads   `json:"workloads,omitempty"`
 	Pod                 string      `json:"pod"`
 	PodUid              string      `json:"podUid"`
 	Container           string      `json:"container"`
@@ -48,18 +49,24 @@ type Chunk struct {
 	DeletionMarkInBlock bool        `json:"-"`
 	PodDeleted          bool        `json:"-"`
 	MetricsCleared      bool        `json:"-"`
+	Annotations         Labels      `json:"-"` // annotations of the pod for lobster
 	Line                int64       `json:"line" format:"int64"`
 	Size                int64       `json:"size" format:"int64"`
 	CheckPoint          *CheckPoint `json:"-"`
 	StoreAddr           string      `json:"storeAddr"`
+	Cold                bool        `json:"cold,omitempty"` // blocks are offloaded to the cold tier
 	RelativePodDir      string      `json:"-"`
 	RelativeBlockDir    string      `json:"-"`
 }
 
 func NewChunk(file LogFile, checkPoint *CheckPoint) (*Chunk, error) {
-	name, err := util.FindSetName(file.Pod)
-	if err != nil {
-		return nil, err
+	name := file.Pod
+	if file.Source.Type != LogTypeHostFile {
+		found, err := util.FindSetName(file.Pod)
+		if err != nil {
+			return nil, err
+		}
+		name = found
 	}
 
 	return &Chunk{
@@ -179,6 +186,12 @@ func (c *Chunk) DeleteBlockAt(i int, rootPath string) error {
 		c.Blocks = append(c.Blocks[:i], c.Blocks[i+1:]...)
 	}()
 
+	for _, fileName := range c.Blocks[i].SidecarFileNames() {
+		if err := os.Remove(fmt.Sprintf("%s/%s/%s", rootPath, c.RelativeBlockDir, fileName)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
 	return os.Remove(fmt.Sprintf("%s/%s/%s", rootPath, c.RelativeBlockDir, c.Blocks[i].FileName()))
 }
 
@@ -187,6 +200,14 @@ func (c Chunk) IsOutdated(retentionTime time.Duration) bool {
 }
 
 func (c Chunk) Key() string {
+	if c.Cold {
+		return c.HotKey() + "_cold"
+	}
+	return c.HotKey()
+}
+
+// HotKey returns the key shared by the hot chunk and the cold chunk of a container.
+func (c Chunk) HotKey() string {
 	return fmt.Sprintf("%s_%s_%s_%s_%s_%s", c.Namespace, c.SetName, c.Pod, c.PodUid, c.Container, c.Source.String())
 }
 
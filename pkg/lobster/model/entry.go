@@ -17,9 +17,25 @@
 package model
 
 import (
+	"strings"
 	"time"
 )
 
+const (
+	// ContextLinePrefix marks lines returned around matched logs in raw contents like `grep -C`.
+	ContextLinePrefix = "-"
+	// MultilineDelimiter joins lines of a multiline log like a stack trace stored as a line.
+	MultilineDelimiter = "\x1e"
+)
+
+// ExpandMultiline restores newlines of a multiline log.
+func ExpandMultiline(s string) string {
+	if !strings.Contains(s, MultilineDelimiter) {
+		return s
+	}
+	return strings.ReplaceAll(s, MultilineDelimiter, "\n")
+}
+
 type Entry struct {
 	Timestamp  time.Time         `json:"time"`
 	SourceType string            `json:"sourceType"`
@@ -33,6 +49,7 @@ type Entry struct {
 	PodUid     string            `json:"podUid,omitempty"`
 	Container  string            `json:"container"`
 	Message    string            `json:"message"`
+	Context    bool              `json:"context,omitempty"`
 }
 
 func NewEntryFromChunk(c Chunk) Entry {
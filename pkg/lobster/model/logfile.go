@@ -41,7 +41,7 @@ func (f LogFile) RelativePodDir() string {
 
 func (f LogFile) RelativeBlockDir() string {
 	switch f.Source.Type {
-	case LogTypeEmptyDirFile:
+	case LogTypeEmptyDirFile, LogTypeHostFile, LogTypeEvent:
 		return fmt.Sprintf("%s/%s%s%s", f.RelativePodDir(), f.Source.Type, LogTypeDelimiter, f.Source.Path)
 	default:
 		return fmt.Sprintf("%s/%s", f.RelativePodDir(), f.Container)
@@ -21,10 +21,11 @@ import "math"
 // Page struct
 // @Description Page inforamtion.
 type PageInfo struct {
-	HasNext           bool `json:"hasNext"`
-	Total             int  `json:"total"`
-	Current           int  `json:"current"`
-	IsPartialContents bool `json:"isPartialContents"` // partial logs are returned
+	HasNext           bool   `json:"hasNext"`
+	Total             int    `json:"total"`
+	Current           int    `json:"current"`
+	IsPartialContents bool   `json:"isPartialContents"` // partial logs are returned
+	Cursor            string `json:"cursor,omitempty"`  // opaque cursor to continue from the last returned log
 }
 
 func NewPageInfo(currentPage int, lines, pageBurst int64, isPartialContents bool) PageInfo {
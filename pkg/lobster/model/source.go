@@ -21,6 +21,14 @@ import "fmt"
 const (
 	LogTypeStdStream    = "stdstream"
 	LogTypeEmptyDirFile = "emptydir"
+	LogTypeHostFile     = "host"
+	LogTypeEvent        = "event"
+
+	// HostNamespace is the namespace of chunks for host log files of nodes, which is not a valid name of namespaces
+	HostNamespace = "@host"
+
+	// EventSourcePath is the source path of chunks for events of pods
+	EventSourcePath = "kubernetes"
 
 	LogTypeDelimiter = "@"
 )
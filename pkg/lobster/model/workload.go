@@ -0,0 +1,86 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/golang/glog"
+)
+
+const (
+	WorkloadsFileName = "workloads"
+	WorkloadDelimiter = "/"
+)
+
+// Workload is an owner of pods such as Deployment, ReplicaSet, CronJob, Job, DaemonSet and StatefulSet.
+type Workload struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+}
+
+func ParseWorkload(str string) (Workload, error) {
+	kind, name, ok := strings.Cut(str, WorkloadDelimiter)
+	if !ok || len(kind) == 0 || len(name) == 0 {
+		return Workload{}, fmt.Errorf("invalid workload %q", str)
+	}
+
+	return Workload{Kind: kind, Name: name}, nil
+}
+
+func (w Workload) String() string {
+	return w.Kind + WorkloadDelimiter + w.Name
+}
+
+// Workloads is an owner chain of a pod from the nearest owner; e.g. ReplicaSet and then Deployment.
+type Workloads []Workload
+
+func (w Workloads) KeyMap() map[string]bool {
+	keyMap := map[string]bool{}
+
+	for _, workload := range w {
+		keyMap[workload.String()] = true
+	}
+
+	return keyMap
+}
+
+func (w Workloads) ToBytes() []byte {
+	data, err := json.Marshal(w)
+	if err != nil {
+		glog.Error(err)
+	}
+	return data
+}
+
+func NewWorkloadsFromFile(filePath string) (Workloads, error) {
+	workloads := Workloads{}
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return workloads, err
+	}
+
+	if err := json.Unmarshal(data, &workloads); err != nil {
+		return workloads, err
+	}
+
+	return workloads, nil
+}
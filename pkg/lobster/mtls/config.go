@@ -0,0 +1,46 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mtls
+
+import (
+	"flag"
+	"time"
+)
+
+type config struct {
+	CertFile       *string
+	KeyFile        *string
+	CAFile         *string
+	ServerName     *string
+	ReloadInterval *time.Duration
+}
+
+func setup() config {
+	certFile := flag.String("mtls.certFile", "", "Certificate file of the component used as both a server and a client; mTLS is enabled if it is set")
+	keyFile := flag.String("mtls.keyFile", "", "Private key file of mtls.certFile")
+	caFile := flag.String("mtls.caFile", "", "CA certificate file to verify certificates of other components")
+	serverName := flag.String("mtls.serverName", "", "Name to verify certificates of servers instead of their addresses; e.g. a name shared by certificates of all components")
+	reloadInterval := flag.Duration("mtls.reloadInterval", 10*time.Second, "Interval to check whether certificate files are rotated")
+
+	return config{
+		CertFile:       certFile,
+		KeyFile:        keyFile,
+		CAFile:         caFile,
+		ServerName:     serverName,
+		ReloadInterval: reloadInterval,
+	}
+}
@@ -0,0 +1,233 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mtls
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+const (
+	SchemeHttp  = "http"
+	SchemeHttps = "https"
+)
+
+var (
+	conf  config
+	files = &reloader{}
+)
+
+func init() {
+	conf = setup()
+	log.Println("mtls configuration is loaded")
+}
+
+func Enabled() bool {
+	return len(*conf.CertFile) > 0
+}
+
+// Scheme returns the scheme of requests between components.
+func Scheme() string {
+	if Enabled() {
+		return SchemeHttps
+	}
+
+	return SchemeHttp
+}
+
+// Check refuses configurations mixing plaintext and mTLS, which components could not talk with.
+func Check() error {
+	set := 0
+	for _, file := range []string{*conf.CertFile, *conf.KeyFile, *conf.CAFile} {
+		if len(file) > 0 {
+			set++
+		}
+	}
+
+	switch set {
+	case 0:
+		return nil
+	case 3:
+		_, _, err := files.load()
+		return err
+	default:
+		return errors.New("`mtls.certFile`, `mtls.keyFile` and `mtls.caFile` must be set together")
+	}
+}
+
+// ServerConfig returns a TLS configuration of servers which verifies certificates of clients if they are given.
+// Certificates are always required if requireClientCert is true.
+func ServerConfig(requireClientCert bool) *tls.Config {
+	clientAuth := tls.RequestClientCert
+	if requireClientCert {
+		clientAuth = tls.RequireAnyClientCert
+	}
+
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ClientAuth: clientAuth,
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			cert, _, err := files.load()
+			return cert, err
+		},
+		// clients are verified here instead of ClientCAs to use the reloaded CA
+		VerifyConnection: func(state tls.ConnectionState) error {
+			if len(state.PeerCertificates) == 0 {
+				return nil
+			}
+			return verify(state.PeerCertificates, "", x509.ExtKeyUsageClientAuth)
+		},
+	}
+}
+
+// ClientConfig returns a TLS configuration of clients presenting the certificate of the component.
+// It is used only for https requests, so clients can take it before flags are parsed.
+func ClientConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		// servers are verified in VerifyConnection instead of RootCAs to use the reloaded CA
+		InsecureSkipVerify: true,
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			cert, _, err := files.load()
+			return cert, err
+		},
+		VerifyConnection: func(state tls.ConnectionState) error {
+			name := state.ServerName
+			if len(*conf.ServerName) > 0 {
+				name = *conf.ServerName
+			}
+			return verify(state.PeerCertificates, name, x509.ExtKeyUsageServerAuth)
+		},
+	}
+}
+
+// GrpcCredentials returns credentials of gRPC clients, which are insecure if mTLS is disabled.
+func GrpcCredentials() credentials.TransportCredentials {
+	if !Enabled() {
+		return insecure.NewCredentials()
+	}
+
+	return credentials.NewTLS(ClientConfig())
+}
+
+// HasClientCert returns true if the client of the request presented a verified certificate.
+// Every request has it if mTLS is disabled.
+func HasClientCert(r *http.Request) bool {
+	if !Enabled() {
+		return true
+	}
+
+	return r.TLS != nil && len(r.TLS.PeerCertificates) > 0
+}
+
+func verify(certs []*x509.Certificate, name string, usage x509.ExtKeyUsage) error {
+	if len(certs) == 0 {
+		return errors.New("no certificates")
+	}
+
+	_, pool, err := files.load()
+	if err != nil {
+		return err
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+
+	_, err = certs[0].Verify(x509.VerifyOptions{
+		DNSName:       name,
+		Roots:         pool,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{usage},
+	})
+
+	return err
+}
+
+// reloader keeps the certificate and the CA and reads them again when their files are modified.
+type reloader struct {
+	lock     sync.Mutex
+	checked  time.Time
+	modTimes []time.Time
+	cert     *tls.Certificate
+	pool     *x509.CertPool
+}
+
+func (r *reloader) load() (*tls.Certificate, *x509.CertPool, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.cert != nil && time.Since(r.checked) < *conf.ReloadInterval {
+		return r.cert, r.pool, nil
+	}
+	r.checked = time.Now()
+
+	modTimes := []time.Time{}
+	for _, file := range []string{*conf.CertFile, *conf.KeyFile, *conf.CAFile} {
+		info, err := os.Stat(file)
+		if err != nil {
+			return r.fallback(err)
+		}
+		modTimes = append(modTimes, info.ModTime())
+	}
+
+	if r.cert != nil && slices.EqualFunc(r.modTimes, modTimes, time.Time.Equal) {
+		return r.cert, r.pool, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(*conf.CertFile, *conf.KeyFile)
+	if err != nil {
+		return r.fallback(err)
+	}
+
+	data, err := os.ReadFile(*conf.CAFile)
+	if err != nil {
+		return r.fallback(err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return r.fallback(fmt.Errorf("no certificates in %s", *conf.CAFile))
+	}
+
+	r.cert, r.pool, r.modTimes = &cert, pool, modTimes
+	glog.Info("mtls certificates are loaded")
+
+	return r.cert, r.pool, nil
+}
+
+// fallback keeps the loaded certificate while files are being rotated.
+func (r *reloader) fallback(err error) (*tls.Certificate, *x509.CertPool, error) {
+	if r.cert != nil {
+		glog.Warningf("keep mtls certificates: %s", err.Error())
+		return r.cert, r.pool, nil
+	}
+
+	return nil, nil, err
+}
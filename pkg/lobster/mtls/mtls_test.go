@@ -0,0 +1,160 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mtls
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCA(t *testing.T) testCA {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "lobster-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+
+	return testCA{cert, key, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// writeFiles writes a certificate for lobster.internal signed by the CA and returns paths of the certificate, the key and the CA.
+func (ca testCA) writeFiles(t *testing.T, dir string) (string, string, string) {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "lobster"},
+		DNSNames:     []string{"lobster.internal"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDer, _ := x509.MarshalECPrivateKey(key)
+
+	certFile, keyFile, caFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key"), filepath.Join(dir, "ca.crt")
+	for path, data := range map[string][]byte{
+		certFile: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		keyFile:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}),
+		caFile:   ca.pem,
+	} {
+		if err := os.WriteFile(path, data, 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return certFile, keyFile, caFile
+}
+
+func setFiles(certFile, keyFile, caFile string) {
+	serverName := "lobster.internal"
+	reloadInterval := time.Duration(0)
+	conf = config{&certFile, &keyFile, &caFile, &serverName, &reloadInterval}
+	files = &reloader{}
+}
+
+func TestCheck(t *testing.T) {
+	certFile, keyFile, caFile := newTestCA(t).writeFiles(t, t.TempDir())
+
+	setFiles("", "", "")
+	if err := Check(); err != nil || Enabled() || Scheme() != SchemeHttp {
+		t.Errorf("mtls must be disabled without files: %v", err)
+	}
+
+	setFiles(certFile, keyFile, "")
+	if err := Check(); err == nil {
+		t.Error("mixed configurations must be refused")
+	}
+
+	setFiles(certFile, keyFile, caFile)
+	if err := Check(); err != nil || Scheme() != SchemeHttps {
+		t.Errorf("mtls must be enabled with files: %v", err)
+	}
+}
+
+func TestMutualTLS(t *testing.T) {
+	ca := newTestCA(t)
+	certFile, keyFile, caFile := ca.writeFiles(t, t.TempDir())
+	setFiles(certFile, keyFile, caFile)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !HasClientCert(r) {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	// httptest serves its own certificate with StartTLS
+	server.Listener = tls.NewListener(server.Listener, ServerConfig(true))
+	server.Start()
+	defer server.Close()
+	url := "https://" + server.Listener.Addr().String()
+
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: ClientConfig()}}
+	resp, err := client.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	// certificates of another CA are refused
+	otherCert, otherKey, _ := newTestCA(t).writeFiles(t, t.TempDir())
+	pair, err := tls.LoadX509KeyPair(otherCert, otherKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	untrusted := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{
+		InsecureSkipVerify: true,
+		Certificates:       []tls.Certificate{pair},
+	}}}
+	if resp, err := untrusted.Get(url); err == nil {
+		_ = resp.Body.Close()
+		t.Error("clients of other CAs must be refused")
+	}
+}
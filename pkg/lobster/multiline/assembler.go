@@ -0,0 +1,94 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package multiline
+
+import (
+	"strings"
+	"time"
+
+	"github.com/naver/lobster/pkg/lobster/model"
+)
+
+// Event is a log line assembled from physical lines.
+type Event struct {
+	Timestamp time.Time
+	// The first line followed by messages of the other lines joined with model.MultilineDelimiter
+	Line string
+	// Offset of the file after the last line
+	Offset int64
+}
+
+// Assembler groups lines of a source into events by the rule.
+type Assembler struct {
+	rule    *Rule
+	pending *Event
+	// lines of the pending event, which are joined once when it is flushed
+	buf   strings.Builder
+	lines int
+	last  time.Time
+}
+
+func NewAssembler(rule *Rule) *Assembler {
+	return &Assembler{rule: rule}
+}
+
+// Add returns the event completed by the line if any; msg is the message part of the line matched with the rule.
+func (a *Assembler) Add(ts time.Time, line, msg string, offset int64, now time.Time) (Event, bool) {
+	msg = strings.TrimSuffix(msg, "\n")
+
+	if a.pending != nil && a.rule.follows(msg) && a.lines < a.rule.MaxLines && a.buf.Len()+len(msg) < a.rule.MaxBytes {
+		a.buf.WriteString(model.MultilineDelimiter)
+		a.buf.WriteString(msg)
+		a.pending.Offset = offset
+		a.lines = a.lines + 1
+		a.last = now
+		return Event{}, false
+	}
+
+	completed, ok := a.Flush()
+
+	a.pending = &Event{Timestamp: ts, Offset: offset}
+	a.buf.WriteString(strings.TrimSuffix(line, "\n"))
+	a.lines = 1
+	a.last = now
+
+	return completed, ok
+}
+
+// Flush returns the pending event.
+func (a *Assembler) Flush() (Event, bool) {
+	if a.pending == nil {
+		return Event{}, false
+	}
+
+	event := *a.pending
+	event.Line = a.buf.String()
+	a.pending = nil
+	a.buf.Reset()
+	a.lines = 0
+
+	return event, true
+}
+
+// Expired returns true if no line has followed the pending event within the timeout of the rule.
+func (a *Assembler) Expired(now time.Time) bool {
+	return a.pending != nil && now.Sub(a.last) >= a.rule.timeout
+}
+
+func (a *Assembler) MaxWait() time.Duration {
+	return a.rule.MaxWait()
+}
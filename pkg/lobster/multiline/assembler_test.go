@@ -0,0 +1,112 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package multiline
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/naver/lobster/pkg/lobster/model"
+)
+
+func assemble(t *testing.T, rule *Rule, msgs []string) []string {
+	rules, err := NewRules([]*Rule{rule})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		assembler = NewAssembler(rules[0])
+		now       = time.Now()
+		events    = []string{}
+	)
+
+	for i, msg := range msgs {
+		if event, ok := assembler.Add(now, msg, msg, int64(i+1), now); ok {
+			events = append(events, strings.ReplaceAll(event.Line, model.MultilineDelimiter, "|"))
+		}
+	}
+
+	if assembler.Expired(now) || !assembler.Expired(now.Add(rules[0].MaxWait())) {
+		t.Fatal("pending event should expire after the timeout")
+	}
+
+	if event, ok := assembler.Flush(); ok {
+		if event.Offset != int64(len(msgs)) {
+			t.Fatalf("expected offset %d but %d", len(msgs), event.Offset)
+		}
+		events = append(events, strings.ReplaceAll(event.Line, model.MultilineDelimiter, "|"))
+	}
+
+	return events
+}
+
+func TestAssembler(t *testing.T) {
+	trace := []string{
+		"Exception in thread \"main\" java.lang.IllegalStateException: boom",
+		"\tat com.example.App.run(App.java:10)",
+		"\tat com.example.App.main(App.java:5)",
+		"Caused by: java.io.IOException: closed",
+		"\tat com.example.Io.read(Io.java:3)",
+		"2024-01-24 01:01:09 INFO next\n",
+	}
+
+	tests := []struct {
+		rule     *Rule
+		expected []string
+	}{
+		{
+			&Rule{Name: "indent", Indent: true},
+			[]string{trace[0] + "|" + trace[1] + "|" + trace[2], trace[3] + "|" + trace[4], "2024-01-24 01:01:09 INFO next"},
+		},
+		{
+			&Rule{Name: "start", Start: `^(Exception|\d{4}-\d{2}-\d{2})`},
+			[]string{strings.Join(trace[:5], "|"), "2024-01-24 01:01:09 INFO next"},
+		},
+		{
+			&Rule{Name: "continue", Continue: `^(\s|Caused by:)`},
+			[]string{strings.Join(trace[:5], "|"), "2024-01-24 01:01:09 INFO next"},
+		},
+		{
+			&Rule{Name: "maxLines", Indent: true, MaxLines: 2},
+			[]string{trace[0] + "|" + trace[1], trace[2], trace[3] + "|" + trace[4], "2024-01-24 01:01:09 INFO next"},
+		},
+	}
+
+	for _, test := range tests {
+		if events := assemble(t, test.rule, trace); !reflect.DeepEqual(events, test.expected) {
+			t.Fatalf("%s: expected %q but %q", test.rule.Name, test.expected, events)
+		}
+	}
+
+	if _, err := NewRules([]*Rule{{Name: "invalid", Indent: true, Start: "^a"}}); err == nil {
+		t.Fatal("a rule having both start and indent should fail")
+	}
+}
+
+func TestRuleSelects(t *testing.T) {
+	rule := Rule{Namespaces: []string{"pay"}, SourceTypes: []string{model.LogTypeStdStream}}
+
+	if !rule.selects("pay", "api", model.Source{Type: model.LogTypeStdStream}) {
+		t.Fatal("rule should select stdstream of pay")
+	}
+	if rule.selects("pay", "api", model.Source{Type: model.LogTypeEmptyDirFile}) || rule.selects("ci", "api", model.Source{Type: model.LogTypeStdStream}) {
+		t.Fatal("rule should not select other sources")
+	}
+}
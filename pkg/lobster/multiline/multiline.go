@@ -0,0 +1,188 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package multiline
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/naver/lobster/pkg/lobster/model"
+	"github.com/naver/lobster/pkg/lobster/policy"
+)
+
+const (
+	defaultMaxLines = 500
+	defaultMaxBytes = 256 * 1024
+	defaultTimeout  = time.Second
+)
+
+var (
+	conf  config
+	rules []*Rule
+	once  sync.Once
+)
+
+func init() {
+	conf = setup()
+	log.Println("multiline configuration is loaded")
+}
+
+// Rule assembles lines of matched sources into events by one of start, continue and indent.
+type Rule struct {
+	Name string `json:"name"`
+	// Empty selectors select all sources
+	Namespaces  []string `json:"namespaces,omitempty"`
+	Containers  []string `json:"containers,omitempty"`
+	SourceTypes []string `json:"sourceTypes,omitempty"`
+	// Regular expression matched with the first line of an event
+	Start string `json:"start,omitempty"`
+	// Regular expression matched with following lines of an event
+	Continue string `json:"continue,omitempty"`
+	// Lines starting with spaces or tabs follow the previous line
+	Indent bool `json:"indent,omitempty"`
+	// An event is completed when it reaches max lines or bytes, or no line follows within timeout
+	MaxLines int    `json:"maxLines,omitempty"`
+	MaxBytes int    `json:"maxBytes,omitempty"`
+	Timeout  string `json:"timeout,omitempty"`
+
+	startRegexp    *regexp.Regexp
+	continueRegexp *regexp.Regexp
+	timeout        time.Duration
+}
+
+func (r *Rule) init() error {
+	modes := 0
+
+	if len(r.Start) > 0 {
+		compiled, err := regexp.Compile(r.Start)
+		if err != nil {
+			return fmt.Errorf("invalid start of multiline rule %q: %w", r.Name, err)
+		}
+		r.startRegexp = compiled
+		modes = modes + 1
+	}
+
+	if len(r.Continue) > 0 {
+		compiled, err := regexp.Compile(r.Continue)
+		if err != nil {
+			return fmt.Errorf("invalid continue of multiline rule %q: %w", r.Name, err)
+		}
+		r.continueRegexp = compiled
+		modes = modes + 1
+	}
+
+	if r.Indent {
+		modes = modes + 1
+	}
+
+	if modes != 1 {
+		return fmt.Errorf("multiline rule %q requires one of start, continue and indent", r.Name)
+	}
+
+	if r.MaxLines <= 0 {
+		r.MaxLines = defaultMaxLines
+	}
+	if r.MaxBytes <= 0 {
+		r.MaxBytes = defaultMaxBytes
+	}
+
+	r.timeout = defaultTimeout
+	if len(r.Timeout) > 0 {
+		timeout, err := time.ParseDuration(r.Timeout)
+		if err != nil || timeout <= 0 {
+			return fmt.Errorf("invalid timeout of multiline rule %q", r.Name)
+		}
+		r.timeout = timeout
+	}
+
+	return nil
+}
+
+func (r Rule) selects(namespace, container string, source model.Source) bool {
+	return contains(r.Namespaces, namespace) && contains(r.Containers, container) && contains(r.SourceTypes, source.Type)
+}
+
+// follows returns true if msg continues the event.
+func (r Rule) follows(msg string) bool {
+	switch {
+	case r.startRegexp != nil:
+		return !r.startRegexp.MatchString(msg)
+	case r.continueRegexp != nil:
+		return r.continueRegexp.MatchString(msg)
+	default:
+		return strings.HasPrefix(msg, " ") || strings.HasPrefix(msg, "\t")
+	}
+}
+
+// MaxWait returns how long an event waits for following lines.
+func (r Rule) MaxWait() time.Duration {
+	return r.timeout
+}
+
+func contains(values []string, value string) bool {
+	if len(values) == 0 {
+		return true
+	}
+
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func NewRules(loaded []*Rule) ([]*Rule, error) {
+	for _, rule := range loaded {
+		if err := rule.init(); err != nil {
+			return nil, err
+		}
+	}
+
+	return loaded, nil
+}
+
+// LoadRules reads rules from multiline.ruleFile once; multiline logs are not assembled if it is empty.
+func LoadRules() (err error) {
+	once.Do(func() {
+		var loaded []*Rule
+
+		if loaded, err = policy.Load[*Rule](*conf.RuleFile); err != nil {
+			return
+		}
+
+		rules, err = NewRules(loaded)
+	})
+
+	return
+}
+
+// RuleOf returns the first rule selecting the source; nil if there is none.
+func RuleOf(namespace, container string, source model.Source) *Rule {
+	for _, rule := range rules {
+		if rule.selects(namespace, container, source) {
+			return rule
+		}
+	}
+
+	return nil
+}
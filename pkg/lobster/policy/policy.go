@@ -0,0 +1,65 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package policy
+
+import (
+	"os"
+	"slices"
+
+	"github.com/naver/lobster/pkg/lobster/model"
+	"sigs.k8s.io/yaml"
+)
+
+// Selector selects chunks by namespaces and labels of pods; empty selectors select all chunks.
+type Selector struct {
+	Namespaces []string          `json:"namespaces,omitempty"`
+	Labels     map[string]string `json:"labels,omitempty"`
+}
+
+func (s Selector) Selects(chunk model.Chunk) bool {
+	if len(s.Namespaces) > 0 && !slices.Contains(s.Namespaces, chunk.Namespace) {
+		return false
+	}
+
+	for k, v := range s.Labels {
+		if chunk.Labels[k] != v {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Load reads a list of policies from a yaml or json file; nothing is loaded if path is empty.
+func Load[T any](path string) ([]T, error) {
+	policies := []T{}
+
+	if len(path) == 0 {
+		return policies, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := yaml.Unmarshal(data, &policies); err != nil {
+		return nil, err
+	}
+
+	return policies, nil
+}
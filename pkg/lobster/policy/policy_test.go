@@ -0,0 +1,57 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package policy
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/naver/lobster/pkg/lobster/model"
+)
+
+type testPolicy struct {
+	Name string `json:"name"`
+	Selector
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policies.yaml")
+	data := "- name: production\n  namespaces: [pay]\n  labels:\n    app: web\n"
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	policies, err := Load[testPolicy](path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(policies) != 1 || policies[0].Name != "production" {
+		t.Fatalf("unexpected policies %v", policies)
+	}
+	if !policies[0].Selects(model.Chunk{Namespace: "pay", Labels: model.Labels{"app": "web", "tier": "1"}}) {
+		t.Error("the policy should select the chunk")
+	}
+	if policies[0].Selects(model.Chunk{Namespace: "ci", Labels: model.Labels{"app": "web"}}) {
+		t.Error("the policy should not select a chunk of another namespace")
+	}
+
+	if policies, err := Load[testPolicy](""); err != nil || len(policies) != 0 {
+		t.Errorf("nothing should be loaded without a path: %v %v", policies, err)
+	}
+}
@@ -36,6 +36,8 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	ChunkService_GetChunksWithinRange_FullMethodName = "/proto.ChunkService/GetChunksWithinRange"
 	ChunkService_GetChunk_FullMethodName             = "/proto.ChunkService/GetChunk"
+	ChunkService_FetchSeries_FullMethodName          = "/proto.ChunkService/FetchSeries"
+	ChunkService_FetchLogs_FullMethodName            = "/proto.ChunkService/FetchLogs"
 )
 
 // ChunkServiceClient is the client API for ChunkService service.
@@ -44,6 +46,8 @@ const (
 type ChunkServiceClient interface {
 	GetChunksWithinRange(ctx context.Context, in *Request, opts ...grpc.CallOption) (*Response, error)
 	GetChunk(ctx context.Context, in *Request, opts ...grpc.CallOption) (*Response, error)
+	FetchSeries(ctx context.Context, in *FetchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[FetchResponse], error)
+	FetchLogs(ctx context.Context, in *FetchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[FetchResponse], error)
 }
 
 type chunkServiceClient struct {
@@ -74,12 +78,52 @@ func (c *chunkServiceClient) GetChunk(ctx context.Context, in *Request, opts ...
 	return out, nil
 }
 
+func (c *chunkServiceClient) FetchSeries(ctx context.Context, in *FetchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[FetchResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ChunkService_ServiceDesc.Streams[0], ChunkService_FetchSeries_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[FetchRequest, FetchResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ChunkService_FetchSeriesClient = grpc.ServerStreamingClient[FetchResponse]
+
+func (c *chunkServiceClient) FetchLogs(ctx context.Context, in *FetchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[FetchResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ChunkService_ServiceDesc.Streams[1], ChunkService_FetchLogs_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[FetchRequest, FetchResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ChunkService_FetchLogsClient = grpc.ServerStreamingClient[FetchResponse]
+
 // ChunkServiceServer is the server API for ChunkService service.
 // All implementations must embed UnimplementedChunkServiceServer
 // for forward compatibility.
 type ChunkServiceServer interface {
 	GetChunksWithinRange(context.Context, *Request) (*Response, error)
 	GetChunk(context.Context, *Request) (*Response, error)
+	FetchSeries(*FetchRequest, grpc.ServerStreamingServer[FetchResponse]) error
+	FetchLogs(*FetchRequest, grpc.ServerStreamingServer[FetchResponse]) error
 	mustEmbedUnimplementedChunkServiceServer()
 }
 
@@ -96,6 +140,12 @@ func (UnimplementedChunkServiceServer) GetChunksWithinRange(context.Context, *Re
 func (UnimplementedChunkServiceServer) GetChunk(context.Context, *Request) (*Response, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetChunk not implemented")
 }
+func (UnimplementedChunkServiceServer) FetchSeries(*FetchRequest, grpc.ServerStreamingServer[FetchResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method FetchSeries not implemented")
+}
+func (UnimplementedChunkServiceServer) FetchLogs(*FetchRequest, grpc.ServerStreamingServer[FetchResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method FetchLogs not implemented")
+}
 func (UnimplementedChunkServiceServer) mustEmbedUnimplementedChunkServiceServer() {}
 func (UnimplementedChunkServiceServer) testEmbeddedByValue()                      {}
 
@@ -153,6 +203,28 @@ func _ChunkService_GetChunk_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ChunkService_FetchSeries_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FetchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ChunkServiceServer).FetchSeries(m, &grpc.GenericServerStream[FetchRequest, FetchResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ChunkService_FetchSeriesServer = grpc.ServerStreamingServer[FetchResponse]
+
+func _ChunkService_FetchLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FetchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ChunkServiceServer).FetchLogs(m, &grpc.GenericServerStream[FetchRequest, FetchResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ChunkService_FetchLogsServer = grpc.ServerStreamingServer[FetchResponse]
+
 // ChunkService_ServiceDesc is the grpc.ServiceDesc for ChunkService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -169,6 +241,17 @@ var ChunkService_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _ChunkService_GetChunk_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "FetchSeries",
+			Handler:       _ChunkService_FetchSeries_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "FetchLogs",
+			Handler:       _ChunkService_FetchLogs_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "pkg/lobster/proto/chunk.proto",
 }
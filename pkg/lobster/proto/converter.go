@@ -52,6 +52,7 @@ func (c Converter) FromChunks(chunks []model.Chunk) (protoChunks []*ProtoChunk)
 			Line:             chunk.Line,
 			Size:             chunk.Size,
 			RelativeBlockDir: chunk.RelativeBlockDir,
+			Workloads:        c.fromWorkloads(chunk.Workloads),
 		})
 	}
 
@@ -87,6 +88,29 @@ func (c Converter) ToChunks(protoChunks []*ProtoChunk) (chunks []model.Chunk) {
 			Line:             chunk.Line,
 			Size:             chunk.Size,
 			RelativeBlockDir: chunk.RelativeBlockDir,
+			Workloads:        c.toWorkloads(chunk.Workloads),
+		})
+	}
+
+	return
+}
+
+func (c Converter) fromWorkloads(workloads model.Workloads) (protoWorkloads []*ProtoWorkload) {
+	for _, workload := range workloads {
+		protoWorkloads = append(protoWorkloads, &ProtoWorkload{
+			Kind: workload.Kind,
+			Name: workload.Name,
+		})
+	}
+
+	return
+}
+
+func (c Converter) toWorkloads(protoWorkloads []*ProtoWorkload) (workloads model.Workloads) {
+	for _, workload := range protoWorkloads {
+		workloads = append(workloads, model.Workload{
+			Kind: workload.Kind,
+			Name: workload.Name,
 		})
 	}
 
@@ -120,3 +144,81 @@ func (c Converter) toBlocks(protoBlocks []*ProtoBlock) (blocks []*model.Block) {
 
 	return
 }
+
+func (c Converter) FromSeriesData(seriesData model.SeriesData) (protoSeries []*ProtoSeries) {
+	for _, series := range seriesData {
+		protoSeries = append(protoSeries, &ProtoSeries{
+			ChunkKey: series.ChunkKey,
+			Name:     series.Name,
+			Lines:    series.Lines,
+			Size:     series.Size,
+			Samples:  c.fromSamples(series.Samples),
+		})
+	}
+
+	return
+}
+
+func (c Converter) ToSeriesData(protoSeries []*ProtoSeries) model.SeriesData {
+	seriesData := model.SeriesData{}
+
+	for _, series := range protoSeries {
+		seriesData = append(seriesData, &model.Series{
+			ChunkKey: series.ChunkKey,
+			Name:     series.Name,
+			Lines:    series.Lines,
+			Size:     series.Size,
+			Samples:  c.toSamples(series.Samples),
+		})
+	}
+
+	return seriesData
+}
+
+func (c Converter) fromSamples(samples []model.Sample) (protoSamples []*ProtoSample) {
+	for _, sample := range samples {
+		protoSamples = append(protoSamples, &ProtoSample{
+			Timestamp: timestamppb.New(sample.Timestamp),
+			Lines:     sample.Lines,
+			Size:      sample.Size,
+		})
+	}
+
+	return
+}
+
+func (c Converter) toSamples(protoSamples []*ProtoSample) (samples []model.Sample) {
+	for _, sample := range protoSamples {
+		samples = append(samples, model.Sample{
+			Timestamp: sample.Timestamp.AsTime(),
+			Lines:     sample.Lines,
+			Size:      sample.Size,
+		})
+	}
+
+	return
+}
+
+func (c Converter) FromPageInfo(pageInfo model.PageInfo) *ProtoPageInfo {
+	return &ProtoPageInfo{
+		HasNext:           pageInfo.HasNext,
+		Total:             int32(pageInfo.Total),
+		Current:           int32(pageInfo.Current),
+		IsPartialContents: pageInfo.IsPartialContents,
+		Cursor:            pageInfo.Cursor,
+	}
+}
+
+func (c Converter) ToPageInfo(protoPageInfo *ProtoPageInfo) *model.PageInfo {
+	if protoPageInfo == nil {
+		return nil
+	}
+
+	return &model.PageInfo{
+		HasNext:           protoPageInfo.HasNext,
+		Total:             int(protoPageInfo.Total),
+		Current:           int(protoPageInfo.Current),
+		IsPartialContents: protoPageInfo.IsPartialContents,
+		Cursor:            protoPageInfo.Cursor,
+	}
+}
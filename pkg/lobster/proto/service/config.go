@@ -14,7 +14,7 @@
  * limitations under the License.
  */
 
-package proto
+package service
 
 import (
 	"flag"
@@ -23,14 +23,17 @@ import (
 type config struct {
 	ServerAddr         *string
 	GrpcMaxRecvMsgSize *int
+	FetchConcurrency   *int
 }
 
 func setup() config {
 	serverAddr := flag.String("grpc.server.addr", ":11130", "server address")
 	grpcMaxRecvMsgSize := flag.Int("sink.exporter.grpcMaxRecvMsgSize", 10*1024*1024, "The maximum size (in bytes) of a message that can be received")
+	fetchConcurrency := flag.Int("grpc.server.fetchConcurrency", 10, "The number of requests of a fetch stream handled concurrently")
 
 	return config{
 		ServerAddr:         serverAddr,
 		GrpcMaxRecvMsgSize: grpcMaxRecvMsgSize,
+		FetchConcurrency:   fetchConcurrency,
 	}
 }
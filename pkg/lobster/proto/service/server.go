@@ -14,14 +14,17 @@
  * limitations under the License.
  */
 
-package proto
+package service
 
 import (
 	"log"
 	"net"
 
 	"github.com/golang/glog"
+	"github.com/naver/lobster/pkg/lobster/mtls"
+	"github.com/naver/lobster/pkg/lobster/proto"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 var conf config
@@ -41,8 +44,13 @@ func (p *ProtoServer) Run(stopChan chan struct{}) {
 		glog.Fatal(err)
 	}
 
-	grpcServer := grpc.NewServer(grpc.MaxRecvMsgSize(*conf.GrpcMaxRecvMsgSize))
-	RegisterChunkServiceServer(grpcServer, &p.Service)
+	options := []grpc.ServerOption{grpc.MaxRecvMsgSize(*conf.GrpcMaxRecvMsgSize)}
+	if mtls.Enabled() {
+		options = append(options, grpc.Creds(credentials.NewTLS(mtls.ServerConfig(true))))
+	}
+
+	grpcServer := grpc.NewServer(options...)
+	proto.RegisterChunkServiceServer(grpcServer, &p.Service)
 
 	go func() {
 		glog.Info("Start grpc server")
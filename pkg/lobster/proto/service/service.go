@@ -0,0 +1,198 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	context "context"
+	"errors"
+	"net/http"
+	"sync"
+
+	"github.com/golang/glog"
+	"github.com/naver/lobster/pkg/lobster/model"
+	"github.com/naver/lobster/pkg/lobster/proto"
+	"github.com/naver/lobster/pkg/lobster/query"
+	serverErrors "github.com/naver/lobster/pkg/lobster/server/errors"
+	logHandler "github.com/naver/lobster/pkg/lobster/server/handler/log"
+	"github.com/naver/lobster/pkg/lobster/server/middleware"
+	"github.com/naver/lobster/pkg/lobster/store"
+	"github.com/naver/lobster/pkg/lobster/util"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type ChunkService struct {
+	Store     *store.Store
+	Limiter   *middleware.Limiter
+	converter proto.Converter
+	proto.UnimplementedChunkServiceServer
+}
+
+func (c *ChunkService) GetChunksWithinRange(ctx context.Context, req *proto.Request) (*proto.Response, error) {
+	chunks, _ := c.Store.GetChunksWithinRange(ctx, query.Request{
+		Start: util.Timestamp{Time: req.Start.AsTime()},
+		End:   util.Timestamp{Time: req.End.AsTime()},
+	})
+
+	return &proto.Response{
+		ProtoChunk: c.converter.FromChunks(chunks),
+	}, nil
+}
+
+func (c *ChunkService) GetChunk(ctx context.Context, req *proto.Request) (*proto.Response, error) {
+	chunk := c.Store.LoadChunk(model.Source{
+		Type: req.Source.Type,
+		Path: req.Source.Path,
+	}, req.PodUid, req.Container)
+
+	if chunk == nil {
+		return nil, errors.New("failed to load chunk")
+	}
+
+	return &proto.Response{
+		ProtoChunk: c.converter.FromChunks([]model.Chunk{*chunk}),
+	}, nil
+}
+
+// FetchSeries streams series of chunks in the same manner as the series API.
+func (c *ChunkService) FetchSeries(req *proto.FetchRequest, stream grpc.ServerStreamingServer[proto.FetchResponse]) error {
+	return c.fetch(req, stream, c.fetchSeries)
+}
+
+// FetchLogs streams logs of chunks in the same manner as the range API.
+func (c *ChunkService) FetchLogs(req *proto.FetchRequest, stream grpc.ServerStreamingServer[proto.FetchResponse]) error {
+	return c.fetch(req, stream, c.fetchLogs)
+}
+
+func (c *ChunkService) fetch(req *proto.FetchRequest, stream grpc.ServerStreamingServer[proto.FetchResponse], handle func(context.Context, query.Request) *proto.FetchResponse) error {
+	if !logHandler.Versions.IsValid(req.Version) {
+		return status.Error(codes.InvalidArgument, "invalid version")
+	}
+
+	var (
+		wg      sync.WaitGroup
+		lock    sync.Mutex
+		sendErr error
+		sem     = make(chan struct{}, *conf.FetchConcurrency)
+	)
+
+	for i, body := range req.Requests {
+		if err := stream.Context().Err(); err != nil {
+			break
+		}
+
+		sem <- struct{}{}
+		wg.Add(1)
+
+		go func(index int, body []byte) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			resp := &proto.FetchResponse{Status: http.StatusBadRequest}
+
+			r, err := query.ParseRequestWithBody(body)
+			if err != nil {
+				glog.Info(err.Error())
+			} else {
+				r.Version = req.Version
+				resp = handle(stream.Context(), r)
+			}
+			resp.Index = int32(index)
+
+			lock.Lock()
+			defer lock.Unlock()
+
+			if sendErr != nil {
+				return
+			}
+			sendErr = stream.Send(resp)
+		}(i, body)
+	}
+
+	wg.Wait()
+
+	if sendErr != nil {
+		return sendErr
+	}
+
+	return stream.Context().Err()
+}
+
+func (c *ChunkService) fetchSeries(ctx context.Context, req query.Request) *proto.FetchResponse {
+	return serve(func() error { return c.Store.Validate(req) }, nil, func() (*proto.FetchResponse, error) {
+		numOfChunk, seriesData, err := c.Store.GetSeriesInBlocksWithinRange(ctx, req)
+		if err != nil || numOfChunk == 0 {
+			return nil, err
+		}
+
+		return &proto.FetchResponse{
+			Status: http.StatusOK,
+			Series: c.converter.FromSeriesData(seriesData),
+		}, nil
+	})
+}
+
+func (c *ChunkService) fetchLogs(ctx context.Context, req query.Request) *proto.FetchResponse {
+	if req.Version == logHandler.ApiV1 {
+		req.Source.Type = model.LogTypeStdStream
+	}
+
+	return serve(func() error { return logHandler.ValidateRange(c.Store, req) }, c.Limiter, func() (*proto.FetchResponse, error) {
+		contents, _, _, numOfChunk, pageInfo, err := c.Store.GetBlocksWithinRange(ctx, req)
+		if err != nil || numOfChunk == 0 {
+			return nil, err
+		}
+
+		return &proto.FetchResponse{
+			Status:   http.StatusOK,
+			Contents: contents,
+			PageInfo: c.converter.FromPageInfo(pageInfo),
+		}, nil
+	})
+}
+
+// serve answers a request in the same manner as http handlers of stores;
+// fetch runs holding a slot of limiter unless it is nil and returns no response if there are no chunks.
+func serve(validate func() error, limiter *middleware.Limiter, fetch func() (*proto.FetchResponse, error)) *proto.FetchResponse {
+	if err := validate(); err != nil {
+		glog.Info(err.Error())
+		return &proto.FetchResponse{Status: http.StatusBadRequest}
+	}
+
+	if limiter != nil {
+		release, ok := limiter.Acquire()
+		if !ok {
+			return &proto.FetchResponse{Status: http.StatusTooManyRequests}
+		}
+		defer release()
+	}
+
+	resp, err := fetch()
+	if err != nil {
+		glog.Error(err)
+		return &proto.FetchResponse{Status: int32(serverErrors.StatusCode(err))}
+	}
+
+	if resp == nil {
+		return &proto.FetchResponse{Status: http.StatusNoContent}
+	}
+
+	return resp
+}
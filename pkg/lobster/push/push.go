@@ -30,12 +30,12 @@ import (
 	"github.com/golang/glog"
 	"github.com/naver/lobster/pkg/lobster/metrics"
 	"github.com/naver/lobster/pkg/lobster/model"
+	"github.com/naver/lobster/pkg/lobster/mtls"
 	"github.com/naver/lobster/pkg/lobster/store"
 	"github.com/naver/lobster/pkg/lobster/util"
 )
 
 const (
-	Scheme   = "http"
 	PathPush = "/push"
 )
 
@@ -55,6 +55,7 @@ var (
 				KeepAlive: 60 * time.Second,
 			}).Dial,
 			TLSHandshakeTimeout: 60 * time.Second,
+			TLSClientConfig:     mtls.ClientConfig(),
 			WriteBufferSize:     (1 << 20),
 		},
 	}
@@ -127,7 +128,7 @@ func push(localAddr string, endpoints []string, data []byte) error {
 		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 		defer cancel()
 
-		req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("http://%s%s", endpoint, PathPush), io.NopCloser(bytes.NewBuffer(data)))
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s://%s%s", mtls.Scheme(), endpoint, PathPush), io.NopCloser(bytes.NewBuffer(data)))
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,96 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package querier
+
+import (
+	"sort"
+
+	"github.com/naver/lobster/pkg/lobster/model"
+	"github.com/naver/lobster/pkg/lobster/query"
+)
+
+type AggregationBuilder struct {
+	FetchResults []FetchResult
+	data         model.AggregationData
+}
+
+func NewAggregationBuilder(fetchResults []FetchResult) *AggregationBuilder {
+	return &AggregationBuilder{
+		FetchResults: fetchResults,
+		data:         model.AggregationData{},
+	}
+}
+
+// Merge sums partial aggregates of stores by groups.
+func (b *AggregationBuilder) Merge() *AggregationBuilder {
+	partials := []model.AggregationData{}
+
+	for _, r := range b.FetchResults {
+		if r.response.Aggregation == nil {
+			continue
+		}
+
+		partials = append(partials, *r.response.Aggregation)
+	}
+
+	b.data = b.data.Merge(partials...)
+
+	return b
+}
+
+// Build applies the function to merged samples and keeps top k groups.
+func (b *AggregationBuilder) Build(aggregation query.Aggregation) (model.AggregationData, error) {
+	step, err := aggregation.StepDuration()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, series := range b.data {
+		series.Values = []model.AggregatedValue{}
+		series.Total = 0
+
+		for _, sample := range series.Samples {
+			var value float64
+
+			switch aggregation.Function {
+			case query.FunctionCountOverTime:
+				value = float64(sample.Lines)
+			case query.FunctionRate:
+				value = float64(sample.Lines) / step.Seconds()
+			case query.FunctionBytesOverTime:
+				value = float64(sample.Size)
+			case query.FunctionBytesRate:
+				value = float64(sample.Size) / step.Seconds()
+			}
+
+			series.Values = append(series.Values, model.AggregatedValue{Timestamp: sample.Timestamp, Value: value})
+			series.Total = series.Total + value
+		}
+
+		series.Samples = nil
+	}
+
+	sort.SliceStable(b.data, func(i, j int) bool {
+		return b.data[i].Total > b.data[j].Total
+	})
+
+	if aggregation.TopK > 0 && len(b.data) > aggregation.TopK {
+		b.data = b.data[:aggregation.TopK]
+	}
+
+	return b.data, nil
+}
@@ -18,6 +18,7 @@ package broker
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -28,7 +29,9 @@ import (
 
 	"github.com/golang/glog"
 
+	"github.com/naver/lobster/pkg/lobster/auth"
 	"github.com/naver/lobster/pkg/lobster/model"
+	"github.com/naver/lobster/pkg/lobster/mtls"
 	"github.com/naver/lobster/pkg/lobster/query"
 	"github.com/naver/lobster/pkg/lobster/server/handler/log"
 )
@@ -46,6 +49,7 @@ var (
 				KeepAlive: 3 * time.Second,
 			}).Dial,
 			TLSHandshakeTimeout: 3 * time.Second,
+			TLSClientConfig:     mtls.ClientConfig(),
 			WriteBufferSize:     (1 << 20),
 			ReadBufferSize:      (1 << 20),
 		},
@@ -65,7 +69,17 @@ func NewBroker(remoteAddrs []RemoteAddr) Broker {
 	return Broker{remoteAddrs}
 }
 
-func (b *Broker) RequestChunksWithinRange(req query.Request, isGlobal bool) ([]model.Chunk, error) {
+// RemoteAddresses returns addresses of remote queriers.
+func (b *Broker) RemoteAddresses() []string {
+	addrs := []string{}
+	for _, addr := range b.remoteAddrs {
+		addrs = append(addrs, addr.Address)
+	}
+
+	return addrs
+}
+
+func (b *Broker) RequestChunksWithinRange(ctx context.Context, req query.Request, isGlobal bool) ([]model.Chunk, error) {
 	results := []model.Chunk{}
 	channel := make(chan []model.Chunk)
 	expectedChannelLength := len(b.remoteAddrs)
@@ -98,15 +112,18 @@ func (b *Broker) RequestChunksWithinRange(req query.Request, isGlobal bool) ([]m
 				return
 			}
 
-			resp, err := httpClient.Do(&http.Request{
+			r := (&http.Request{
 				Method: http.MethodPost,
 				URL: &url.URL{
-					Scheme: log.Scheme,
+					Scheme: mtls.Scheme(),
 					Host:   addr,
 					Path:   fmt.Sprintf("/api/%s%s", req.Version, log.PathLogs),
 				},
 				Body: io.NopCloser(bytes.NewBuffer(body)),
-			})
+			}).WithContext(ctx)
+			auth.Forward(ctx, r)
+
+			resp, err := httpClient.Do(r)
 			if err != nil {
 				glog.Error(err)
 				return
@@ -0,0 +1,127 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package querier
+
+import (
+	"sort"
+	"time"
+
+	"github.com/naver/lobster/pkg/lobster/hash"
+	"github.com/naver/lobster/pkg/lobster/model"
+	"github.com/naver/lobster/pkg/lobster/query"
+)
+
+// getColdChunksWithinRange returns chunks of blocks offloaded to the cold tier that this querier is responsible for.
+// They are read through the store having the hot chunk of the same container, or any live store if there is none.
+func (q *Querier) getColdChunksWithinRange(req query.Request, hotChunks []model.Chunk) []model.Chunk {
+	chunks := []model.Chunk{}
+
+	if q.cold == nil {
+		return chunks
+	}
+
+	storeAddrs := q.liveStoreAddrs()
+	if len(storeAddrs) == 0 {
+		return chunks
+	}
+
+	hotStoreAddrs := map[string]string{}
+	for _, chunk := range hotChunks {
+		hotStoreAddrs[chunk.HotKey()] = chunk.StoreAddr
+	}
+
+	namespaces := map[string]bool{}
+	for _, ns := range append(req.Namespaces, req.Namespace) {
+		if len(ns) > 0 {
+			namespaces[ns] = true
+		}
+	}
+
+	chunkMatcher := query.NewChunkMatcher(req)
+	operator := hash.HashOperator{Modulus: q.Modulus}
+
+	for _, chunk := range q.cold.Chunks() {
+		if !chunk.UpdatedAt.After(req.Start.Time) || !chunk.StartedAt.Before(req.End.Time) {
+			continue
+		}
+		if len(namespaces) > 0 && !namespaces[chunk.Namespace] {
+			continue
+		}
+		if !chunkMatcher.IsRequestedChunk(chunk) {
+			continue
+		}
+		if q.Modulus > 0 && operator.Modulo(chunk.HotKey()) != q.Id {
+			continue
+		}
+
+		if addr, ok := hotStoreAddrs[chunk.HotKey()]; ok {
+			chunk.StoreAddr = addr
+		} else {
+			chunk.StoreAddr = storeAddrs[hash.HashOperator{Modulus: uint64(len(storeAddrs))}.Modulo(chunk.Key())]
+		}
+
+		chunks = append(chunks, chunk)
+	}
+
+	return chunks
+}
+
+// routeColdChunks reads cold chunks through stores having hot chunks of the same containers,
+// which may be found by other queriers, so that blocks still on the nodes are not read twice.
+func routeColdChunks(chunks []model.Chunk) []model.Chunk {
+	hotStoreAddrs := map[string]string{}
+	for _, chunk := range chunks {
+		if !chunk.Cold {
+			hotStoreAddrs[chunk.HotKey()] = chunk.StoreAddr
+		}
+	}
+
+	routed := []model.Chunk{}
+	seen := map[string]bool{}
+
+	for _, chunk := range chunks {
+		if chunk.Cold {
+			if seen[chunk.Key()] {
+				continue
+			}
+			seen[chunk.Key()] = true
+
+			if addr, ok := hotStoreAddrs[chunk.HotKey()]; ok {
+				chunk.StoreAddr = addr
+			}
+		}
+		routed = append(routed, chunk)
+	}
+
+	return routed
+}
+
+func (q *Querier) liveStoreAddrs() []string {
+	addrs := []string{}
+	now := time.Now()
+
+	q.storeMap.Range(func(key, value interface{}) bool {
+		if now.Sub(value.(time.Time)) <= *conf.StoreRetentionTime {
+			addrs = append(addrs, key.(string))
+		}
+		return true
+	})
+
+	sort.Strings(addrs)
+
+	return addrs
+}
@@ -0,0 +1,39 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package querier
+
+import (
+	"testing"
+
+	"github.com/naver/lobster/pkg/lobster/model"
+)
+
+func TestRouteColdChunks(t *testing.T) {
+	hot := model.Chunk{PodUid: "uid", Container: "a", StoreAddr: "store-a"}
+	cold := model.Chunk{PodUid: "uid", Container: "a", StoreAddr: "store-b", Cold: true}
+	other := model.Chunk{PodUid: "uid", Container: "b", StoreAddr: "store-c", Cold: true}
+
+	// the cold chunk is found by another querier than the one having the hot chunk
+	chunks := routeColdChunks([]model.Chunk{cold, other, hot, cold})
+
+	if len(chunks) != 3 {
+		t.Fatalf("expected 3 chunks but got %v", chunks)
+	}
+	if chunks[0].StoreAddr != "store-a" || chunks[1].StoreAddr != "store-c" {
+		t.Errorf("unexpected stores of cold chunks %v", chunks)
+	}
+}
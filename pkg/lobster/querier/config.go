@@ -32,6 +32,11 @@ type config struct {
 	ContentsLimit              *uint64
 	FetchTimeout               *time.Duration
 	FetchResponseHeaderTimeout *time.Duration
+	StoreGrpcPort              *string
+	StoreGrpcMaxRecvMsgSize    *int
+	TailMergeDelay             *time.Duration
+	TailRefreshInterval        *time.Duration
+	TailLookback               *time.Duration
 }
 
 func setup() config {
@@ -45,6 +50,11 @@ func setup() config {
 	contentsLimit := flag.Uint64("querier.contentsLimit", 1000*1000*30, "Limit the amount of responsive content per page")
 	fetchTimeout := flag.Duration("querier.fetchTimeout", 10*time.Second, "Response timeout for log requests")
 	fetchResponseHeaderTimeout := flag.Duration("querier.fetchResponseHeaderTimeout", 10*time.Second, "Header response timeout for log requests; delays may occur during file reading")
+	storeGrpcPort := flag.String("querier.storeGrpcPort", "11130", "grpc port of stores to stream series and logs; http is used only if empty")
+	storeGrpcMaxRecvMsgSize := flag.Int("querier.storeGrpcMaxRecvMsgSize", 64*1024*1024, "The maximum size (in bytes) of a message that can be received from stores")
+	tailMergeDelay := flag.Duration("querier.tailMergeDelay", time.Second, "Delay to hold live logs from stores to merge them in time order")
+	tailRefreshInterval := flag.Duration("querier.tailRefreshInterval", 30*time.Second, "Interval to find stores newly having chunks for live logs")
+	tailLookback := flag.Duration("querier.tailLookback", 10*time.Minute, "Time range to find chunks recently updated for live logs")
 
 	return config{
 		StatusCheckInteval:         statusCheckInteval,
@@ -57,5 +67,10 @@ func setup() config {
 		ContentsLimit:              contentsLimit,
 		FetchTimeout:               fetchTimeout,
 		FetchResponseHeaderTimeout: fetchResponseHeaderTimeout,
+		StoreGrpcPort:              storeGrpcPort,
+		StoreGrpcMaxRecvMsgSize:    storeGrpcMaxRecvMsgSize,
+		TailMergeDelay:             tailMergeDelay,
+		TailRefreshInterval:        tailRefreshInterval,
+		TailLookback:               tailLookback,
 	}
 }
@@ -17,6 +17,7 @@
 package querier
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/hashicorp/go-memdb"
@@ -28,6 +29,7 @@ const (
 	indexID             = "id"
 	indexChunkNamespace = "chunk_namespace"
 	indexStoreAddr      = "store_addr"
+	indexChunkWorkload  = "chunk_workload"
 )
 
 type Database struct {
@@ -54,6 +56,12 @@ func NewDatabase() (Database, error) {
 							},
 						},
 					},
+					indexChunkWorkload: {
+						Name:         indexChunkWorkload,
+						Unique:       false,
+						AllowMissing: true,
+						Indexer:      workloadIndexer{},
+					},
 					indexStoreAddr: {
 						Name:    indexStoreAddr,
 						Unique:  false,
@@ -110,6 +118,19 @@ func (d Database) getChunksForNamespaceWithinRange(namespace string, start, end
 	return getChunksWithinRange(it, start, end), nil
 }
 
+// getChunksForWorkloadWithinRange returns chunks of pods owned by the workload in the namespace.
+func (d Database) getChunksForWorkloadWithinRange(namespace string, workload model.Workload, start, end time.Time) ([]model.Chunk, error) {
+	txn := d.db.Txn(false)
+	defer txn.Abort()
+
+	it, err := txn.Get(tableName, indexChunkWorkload, namespace, workload.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return getChunksWithinRange(it, start, end), nil
+}
+
 func getChunksWithinRange(it memdb.ResultIterator, start, end time.Time) []model.Chunk {
 	var chunks []model.Chunk
 
@@ -147,11 +168,64 @@ func (d Database) delete(chunk model.Chunk) error {
 	return nil
 }
 
-func (d Database) deleteByAddr(addr string) error {
+// deleteByAddr deletes chunks of the store and returns them.
+func (d Database) deleteByAddr(addr string) ([]model.Chunk, error) {
+	chunks := []model.Chunk{}
 	txn := d.db.Txn(true)
+
+	it, err := txn.Get(tableName, indexStoreAddr, addr)
+	if err != nil {
+		return chunks, err
+	}
+	for obj := it.Next(); obj != nil; obj = it.Next() {
+		chunks = append(chunks, obj.(model.Chunk))
+	}
+
 	if _, err := txn.DeleteAll(tableName, indexStoreAddr, addr); err != nil {
-		return err
+		return chunks, err
 	}
 	txn.Commit()
-	return nil
+	return chunks, nil
+}
+
+// workloadIndexer indexes chunks by each of `{namespace}\x00{kind}/{name}` of their workloads.
+type workloadIndexer struct{}
+
+func (workloadIndexer) FromObject(obj interface{}) (bool, [][]byte, error) {
+	chunk, ok := obj.(model.Chunk)
+	if !ok {
+		return false, nil, fmt.Errorf("unexpected object %T", obj)
+	}
+
+	if len(chunk.Workloads) == 0 {
+		return false, nil, nil
+	}
+
+	keys := make([][]byte, 0, len(chunk.Workloads))
+	for _, workload := range chunk.Workloads {
+		keys = append(keys, workloadIndexKey(chunk.Namespace, workload.String()))
+	}
+
+	return true, keys, nil
+}
+
+func (workloadIndexer) FromArgs(args ...interface{}) ([]byte, error) {
+	if len(args) != 2 {
+		return nil, fmt.Errorf("namespace and workload are required")
+	}
+
+	namespace, ok := args[0].(string)
+	if !ok {
+		return nil, fmt.Errorf("unexpected namespace %v", args[0])
+	}
+	workload, ok := args[1].(string)
+	if !ok {
+		return nil, fmt.Errorf("unexpected workload %v", args[1])
+	}
+
+	return workloadIndexKey(namespace, workload), nil
+}
+
+func workloadIndexKey(namespace, workload string) []byte {
+	return []byte(namespace + "\x00" + workload + "\x00")
 }
@@ -0,0 +1,62 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package querier
+
+import (
+	"testing"
+	"time"
+
+	"github.com/naver/lobster/pkg/lobster/model"
+)
+
+func TestGetChunksForWorkloadWithinRange(t *testing.T) {
+	db, err := NewDatabase()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	deployment := model.Workload{Kind: "Deployment", Name: "api"}
+	newChunk := func(id, namespace string, workloads model.Workloads) model.Chunk {
+		return model.Chunk{Id: id, Namespace: namespace, Workloads: workloads, StoreAddr: "store-a", StartedAt: now.Add(-time.Hour), UpdatedAt: now}
+	}
+
+	if err := db.insert([]model.Chunk{
+		newChunk("a", "pay", model.Workloads{{Kind: "ReplicaSet", Name: "api-1"}, deployment}),
+		newChunk("b", "pay", model.Workloads{{Kind: "ReplicaSet", Name: "api-2"}, deployment}),
+		newChunk("c", "shop", model.Workloads{{Kind: "ReplicaSet", Name: "api-1"}, deployment}),
+		newChunk("d", "pay", nil),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	chunks, err := db.getChunksForWorkloadWithinRange("pay", deployment, now.Add(-time.Minute), now.Add(time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(chunks) != 2 {
+		t.Errorf("expected 2 chunks but %d", len(chunks))
+	}
+
+	chunks, err = db.getChunksForWorkloadWithinRange("pay", deployment, now.Add(-3*time.Hour), now.Add(-2*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(chunks) != 0 {
+		t.Errorf("expected no chunk out of range but %d", len(chunks))
+	}
+}
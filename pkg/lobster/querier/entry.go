@@ -23,74 +23,14 @@ import (
 	"sort"
 	"strings"
 	"sync/atomic"
+	"time"
 
 	"github.com/golang/glog"
 
-	"github.com/naver/lobster/pkg/lobster/logline"
 	"github.com/naver/lobster/pkg/lobster/model"
+	"github.com/naver/lobster/pkg/lobster/query"
 )
 
-type ParseFunc func(string, model.Chunk) (model.Entry, error)
-
-func ParseEntry(line string, chunk model.Chunk) (model.Entry, error) {
-	var (
-		e   = model.NewEntryFromChunk(chunk)
-		err error
-	)
-
-	e.Timestamp, err = logline.ParseTimestamp(line)
-	if err != nil {
-		return e, err
-	}
-
-	if chunk.Source.Type == model.LogTypeStdStream {
-		e.Stream, err = logline.ParseStream(line)
-		if err != nil {
-			return e, err
-		}
-
-		e.Tag, err = logline.ParseTag(line)
-		if err != nil {
-			return e, err
-		}
-
-		e.Message, err = logline.ParseLogMessage(line)
-		if err != nil {
-			return e, err
-		}
-	} else {
-		e.Message = line
-	}
-
-	e.Message = stripNewline(e.Message)
-
-	return e, nil
-}
-
-func ParseEntryRaw(line string, chunk model.Chunk) (model.Entry, error) {
-	var (
-		e   = model.NewEntryFromChunk(chunk)
-		err error
-	)
-
-	e.Timestamp, err = logline.ParseTimestamp(line)
-	if err != nil {
-		return e, err
-	}
-
-	e.Message = line
-
-	return e, nil
-}
-
-func stripNewline(msg string) string {
-	if len(msg) == 0 {
-		return msg
-	}
-
-	return msg[:len(msg)-1]
-}
-
 func (b *SeriesBuilder) Build() model.SeriesData {
 	return b.seriesData
 }
@@ -101,6 +41,9 @@ type EntryBuilder struct {
 	seriesData   model.SeriesData
 	total        atomic.Uint64
 	limit        uint64
+	subReq       query.Request
+	hasNextPage  bool
+	nextCursor   string
 }
 
 func NewEntryBuilder(FetchResults []FetchResult, limit uint64) *EntryBuilder {
@@ -112,15 +55,29 @@ func NewEntryBuilder(FetchResults []FetchResult, limit uint64) *EntryBuilder {
 	}
 }
 
-func (b *EntryBuilder) Merge(fn ParseFunc) *EntryBuilder {
-	channel := make(chan []model.Entry)
+// Paginate makes the builder keep only logs that can be continued by a cursor
+// with the request sent to stores and whether more pages remain after it.
+func (b *EntryBuilder) Paginate(subReq query.Request, hasNextPage bool) *EntryBuilder {
+	b.subReq = subReq
+	b.hasNextPage = hasNextPage
+	return b
+}
+
+type fetchedEntries struct {
+	chunkKey string
+	entries  []model.Entry
+	cut      time.Time
+}
+
+func (b *EntryBuilder) Merge(fn query.ParseFunc) *EntryBuilder {
+	channel := make(chan fetchedEntries)
 
 	for _, r := range b.FetchResults {
 		go func(r FetchResult) {
 			reader := bufio.NewReader(strings.NewReader(r.response.Contents))
-			entries := []model.Entry{}
+			fetched := fetchedEntries{r.Chunk.Key(), []model.Entry{}, r.cut}
 
-			for b.total.Load() < b.limit {
+			for {
 				line, err := reader.ReadString('\n')
 				if err != nil {
 					if err != io.EOF {
@@ -135,22 +92,124 @@ func (b *EntryBuilder) Merge(fn ParseFunc) *EntryBuilder {
 					continue
 				}
 
-				entries = append(entries, e)
+				// the rest of logs are cut by the limit
+				if b.total.Load() >= b.limit {
+					fetched.cut = closer(b.subReq, fetched.cut, e.Timestamp)
+					break
+				}
+
+				fetched.entries = append(fetched.entries, e)
 				b.total.Add(uint64(len(e.Message)))
 			}
 
-			channel <- entries
+			// stores return at most burst logs of a chunk for cursors
+			if b.subReq.HasCursor() && len(fetched.entries) > 0 && strings.Count(r.response.Contents, "\n") >= b.subReq.Burst {
+				fetched.cut = closer(b.subReq, fetched.cut, fetched.entries[len(fetched.entries)-1].Timestamp)
+			}
+
+			channel <- fetched
 		}(r)
 	}
 
+	results := []fetchedEntries{}
 	for i := 0; i < len(b.FetchResults); i++ {
-		entries := <-channel
-		b.entries = append(b.entries, entries...)
+		results = append(results, <-channel)
 	}
 
+	if b.subReq.Start.Time.IsZero() {
+		for _, r := range results {
+			b.entries = append(b.entries, r.entries...)
+		}
+		return b
+	}
+
+	b.paginate(results)
+
 	return b
 }
 
+// paginate keeps logs until the first timestamp where any chunk is cut in the direction, so that all logs before the cursor are returned.
+func (b *EntryBuilder) paginate(results []fetchedEntries) {
+	var (
+		cut        time.Time
+		timestamps = []time.Time{}
+	)
+
+	for _, r := range results {
+		if !r.cut.IsZero() {
+			cut = closer(b.subReq, cut, r.cut)
+		}
+		for _, e := range r.entries {
+			timestamps = append(timestamps, e.Timestamp)
+		}
+	}
+
+	if b.subReq.HasCursor() && len(timestamps) > b.subReq.Burst {
+		sort.Slice(timestamps, func(i, j int) bool { return isCloser(b.subReq, timestamps[i], timestamps[j]) })
+		cut = closer(b.subReq, cut, timestamps[b.subReq.Burst-1])
+	}
+
+	if cut.IsZero() {
+		if !b.hasNextPage {
+			for _, r := range results {
+				b.entries = append(b.entries, r.entries...)
+			}
+			return
+		}
+		cut = b.subReq.End.Time
+		if b.subReq.IsBackward() {
+			cut = b.subReq.Start.Time
+		}
+	}
+
+	cursor := query.Cursor{Timestamp: cut, Skips: map[string]int{}}
+	previous, _ := query.ParseCursor(b.subReq.Cursor)
+
+	for _, r := range results {
+		skip := 0
+		if previous.Timestamp.Equal(cut) {
+			skip = previous.Skip(r.chunkKey)
+		}
+
+		for _, e := range r.entries {
+			if isCloser(b.subReq, cut, e.Timestamp) {
+				continue
+			}
+			if e.Timestamp.Equal(cut) {
+				skip = skip + 1
+			}
+			b.entries = append(b.entries, e)
+		}
+
+		if skip > 0 {
+			cursor.Skips[r.chunkKey] = skip
+		}
+	}
+
+	b.nextCursor = cursor.String()
+}
+
+// NextCursor returns the cursor to continue from the last returned log; empty if no logs remain.
+func (b *EntryBuilder) NextCursor() string {
+	return b.nextCursor
+}
+
+// isCloser returns true if a is read before b in the direction of the request.
+func isCloser(req query.Request, a, b time.Time) bool {
+	if req.IsBackward() {
+		return a.After(b)
+	}
+	return a.Before(b)
+}
+
+// closer returns the timestamp read first in the direction of the request; a zero timestamp is ignored.
+func closer(req query.Request, a, b time.Time) time.Time {
+	if a.IsZero() || isCloser(req, b, a) {
+		return b
+	}
+	return a
+}
+
 func (b *EntryBuilder) SortAscending() *EntryBuilder {
 	sort.Slice(b.entries, func(i, j int) bool {
 		return b.entries[i].Timestamp.Before(b.entries[j].Timestamp)
@@ -158,6 +217,21 @@ func (b *EntryBuilder) SortAscending() *EntryBuilder {
 	return b
 }
 
+func (b *EntryBuilder) SortDescending() *EntryBuilder {
+	sort.Slice(b.entries, func(i, j int) bool {
+		return b.entries[i].Timestamp.After(b.entries[j].Timestamp)
+	})
+	return b
+}
+
+// SortByDirection sorts logs newest first for backward requests given by Paginate.
+func (b *EntryBuilder) SortByDirection() *EntryBuilder {
+	if b.subReq.IsBackward() {
+		return b.SortDescending()
+	}
+	return b.SortAscending()
+}
+
 func (b *EntryBuilder) Build() ([]model.Entry, bool) {
 	return b.entries, b.isPartialContents()
 }
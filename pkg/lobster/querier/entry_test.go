@@ -0,0 +1,175 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package querier
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/naver/lobster/pkg/lobster/logline"
+	"github.com/naver/lobster/pkg/lobster/model"
+	"github.com/naver/lobster/pkg/lobster/query"
+	"github.com/naver/lobster/pkg/lobster/util"
+)
+
+func TestMain(m *testing.M) {
+	logline.Setup()
+	os.Exit(m.Run())
+}
+
+type testLog struct {
+	ts  time.Time
+	msg string
+}
+
+// fetchFromCursor returns at most burst logs of the chunk after the cursor as stores do.
+func fetchFromCursor(chunk model.Chunk, logs []testLog, req query.Request) FetchResult {
+	cursor, _ := query.ParseCursor(req.Cursor)
+	skip := cursor.Skip(chunk.Key())
+	contents := strings.Builder{}
+	lines := 0
+
+	if req.IsBackward() {
+		logs = slices.Clone(logs)
+		slices.Reverse(logs)
+	}
+
+	for _, l := range logs {
+		if l.ts.Before(req.Start.Time) || l.ts.After(req.End.Time) || lines == req.Burst {
+			continue
+		}
+		if skip > 0 && l.ts.Equal(cursor.Timestamp) {
+			skip--
+			continue
+		}
+		contents.WriteString(fmt.Sprintf("%s stdout F %s\n", l.ts.Format(time.RFC3339Nano), l.msg))
+		lines++
+	}
+
+	return FetchResult{chunk, query.Response{Contents: contents.String()}, nil, time.Time{}}
+}
+
+func TestEntryBuilderPaginateWithCursor(t *testing.T) {
+	for _, direction := range []string{query.DirectionForward, query.DirectionBackward} {
+		t.Run(direction, func(t *testing.T) {
+			testEntryBuilderPaginateWithCursor(t, direction)
+		})
+	}
+}
+
+func testEntryBuilderPaginateWithCursor(t *testing.T, direction string) {
+	start := time.Date(2024, 1, 24, 1, 0, 0, 0, time.UTC)
+	at := func(sec int) time.Time { return start.Add(time.Duration(sec) * time.Second) }
+	source := model.Source{Type: model.LogTypeStdStream}
+
+	chunks := map[string]model.Chunk{
+		"a": {Namespace: "ns", Pod: "a", Container: "app", Source: source},
+		"b": {Namespace: "ns", Pod: "b", Container: "app", Source: source},
+	}
+	logs := map[string][]testLog{
+		"a": {{at(0), "a0"}, {at(1), "a1"}, {at(1), "a2"}, {at(1), "a3"}, {at(2), "a4"}},
+		"b": {{at(1), "b0"}, {at(3), "b1"}, {at(3), "b2"}},
+	}
+
+	req := query.Request{
+		Start:     util.Timestamp{Time: start},
+		End:       util.Timestamp{Time: at(10)},
+		Burst:     2,
+		Direction: direction,
+	}
+
+	got := []string{}
+	cursor := query.Cursor{Timestamp: start}.String()
+	if req.IsBackward() {
+		cursor = query.Cursor{Timestamp: req.End.Time}.String()
+	}
+
+	for i := 0; len(cursor) > 0; i++ {
+		if i > 10 {
+			t.Fatal("cursor does not progress")
+		}
+
+		subReq := req
+		subReq.Cursor = cursor
+		if err := subReq.InitCursor(); err != nil {
+			t.Fatal(err)
+		}
+
+		results := []FetchResult{}
+		for name, chunk := range chunks {
+			results = append(results, fetchFromCursor(chunk, logs[name], subReq))
+		}
+
+		builder := NewEntryBuilder(results, 1<<20).
+			Paginate(subReq, false).
+			Merge(query.ParseEntry).
+			SortByDirection()
+
+		entries, _ := builder.Build()
+		for _, e := range entries {
+			got = append(got, e.Message)
+		}
+		cursor = builder.NextCursor()
+	}
+
+	if len(got) != 8 {
+		t.Fatalf("expected 8 logs without duplicates or gaps but %v", got)
+	}
+
+	// logs at the same timestamp may be in any order
+	first := []string{"a0"}
+	if req.IsBackward() {
+		first = []string{"b1", "b2"}
+	}
+	if !slices.Contains(first, got[0]) {
+		t.Fatalf("expected one of %v first but %v", first, got)
+	}
+
+	seen := map[string]bool{}
+	for _, msg := range got {
+		if seen[msg] {
+			t.Fatalf("duplicated %s in %v", msg, got)
+		}
+		seen[msg] = true
+	}
+}
+
+func TestEntryBuilderKeepsTruncatedTag(t *testing.T) {
+	chunk := model.Chunk{Namespace: "ns", Pod: "a", Container: "app", Source: model.Source{Type: model.LogTypeStdStream}}
+	contents := "2024-01-24T01:00:00Z stdout F:T joined but truncated\n" +
+		"2024-01-24T01:00:01Z stdout F complete\n"
+	results := []FetchResult{{chunk, query.Response{Contents: contents}, nil, time.Time{}}}
+
+	entries, _ := NewEntryBuilder(results, 1<<20).Merge(query.ParseEntry).SortAscending().Build()
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries but %v", entries)
+	}
+
+	expected := []string{logline.TagFull + ":" + logline.TagTruncated, logline.TagFull}
+	for i, e := range entries {
+		if e.Tag != expected[i] {
+			t.Errorf("expected tag %q but %q", expected[i], e.Tag)
+		}
+	}
+	if entries[0].Message != "joined but truncated" {
+		t.Errorf("expected the message without the tag but %q", entries[0].Message)
+	}
+}
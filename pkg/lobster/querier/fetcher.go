@@ -18,6 +18,7 @@ package querier
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -27,7 +28,9 @@ import (
 	"time"
 
 	"github.com/golang/glog"
+	"github.com/naver/lobster/pkg/lobster/cache"
 	"github.com/naver/lobster/pkg/lobster/model"
+	"github.com/naver/lobster/pkg/lobster/mtls"
 	"github.com/naver/lobster/pkg/lobster/query"
 	"github.com/naver/lobster/pkg/lobster/server/errors"
 	logHandler "github.com/naver/lobster/pkg/lobster/server/handler/log"
@@ -37,13 +40,24 @@ type FetchResult struct {
 	model.Chunk
 	response query.Response
 	err      error
+	// timestamp of the first log that is not fetched; zero if all logs within range are fetched
+	cut time.Time
 }
 
 type Fetcher struct {
-	client *http.Client
+	client  *http.Client
+	streams *streamClient
+	cache   *cache.Cache
 }
 
-func NewFetcher(timeout, responseHeaderTimeout time.Duration) Fetcher {
+// NewFetcher returns a fetcher requesting stores over http;
+// series and logs are fetched through grpc streams as well if grpcPort of stores is given, and kept in resultCache if it is not nil.
+func NewFetcher(timeout, responseHeaderTimeout time.Duration, grpcPort string, grpcMaxRecvMsgSize int, resultCache *cache.Cache) Fetcher {
+	var streams *streamClient
+	if len(grpcPort) > 0 {
+		streams = newStreamClient(grpcPort, grpcMaxRecvMsgSize, timeout)
+	}
+
 	return Fetcher{
 		&http.Client{
 			Timeout: timeout,
@@ -58,17 +72,25 @@ func NewFetcher(timeout, responseHeaderTimeout time.Duration) Fetcher {
 				}).Dial,
 				ResponseHeaderTimeout: responseHeaderTimeout,
 				TLSHandshakeTimeout:   10 * time.Second,
+				TLSClientConfig:       mtls.ClientConfig(),
 				WriteBufferSize:       (1 << 20),
 				ReadBufferSize:        (1 << 20),
 			},
 		},
+		streams,
+		resultCache,
 	}
 }
 
-func (f Fetcher) GetLogEntries(req query.Request, chunks []model.Chunk, limit uint64) ([]FetchResult, model.PageInfo, error) {
-	results, err := f.Fetch(req, chunks, logHandler.PathLogSeries)
+// GetLogEntries fetches logs of the page or from the cursor and returns the request sent to stores.
+func (f Fetcher) GetLogEntries(ctx context.Context, req query.Request, chunks []model.Chunk, limit uint64) ([]FetchResult, query.Request, model.PageInfo, error) {
+	if req.HasCursor() {
+		return f.getLogEntriesFromCursor(ctx, req, chunks)
+	}
+
+	results, err := f.Fetch(ctx, req, chunks, logHandler.PathLogSeries)
 	if err != nil {
-		return results, model.PageInfo{}, err
+		return results, req, model.PageInfo{}, err
 	}
 
 	series := NewSeriesBuilder(results).
@@ -82,7 +104,7 @@ func (f Fetcher) GetLogEntries(req query.Request, chunks []model.Chunk, limit ui
 
 	subReq, pageInfo, size, err := query.MakeSubQuery(req, series, int64(burst))
 	if err != nil {
-		return results, model.PageInfo{}, err
+		return results, req, model.PageInfo{}, err
 	}
 
 	chunksToFetch := chunks
@@ -90,71 +112,166 @@ func (f Fetcher) GetLogEntries(req query.Request, chunks []model.Chunk, limit ui
 		chunksToFetch, pageInfo.IsPartialContents = limitChunksBySize(req, chunks, series, limit)
 	}
 
-	results, err = f.Fetch(subReq, chunksToFetch, logHandler.PathLogRange)
+	results, err = f.Fetch(ctx, subReq, chunksToFetch, logHandler.PathLogRange)
+	if err != nil {
+		return results, subReq, model.PageInfo{}, err
+	}
+
+	return append(results, cutChunks(subReq, chunks, chunksToFetch, series)...), subReq, pageInfo, nil
+}
+
+// getLogEntriesFromCursor fetches at most burst logs of each chunk after the cursor.
+func (f Fetcher) getLogEntriesFromCursor(ctx context.Context, req query.Request, chunks []model.Chunk) ([]FetchResult, query.Request, model.PageInfo, error) {
+	subReq := req
+	if subReq.Burst == 0 {
+		subReq.Burst = *conf.PageBurst
+	}
+
+	results, err := f.Fetch(ctx, subReq, chunks, logHandler.PathLogRange)
 	if err != nil {
-		return results, model.PageInfo{}, err
+		return results, subReq, model.PageInfo{}, err
 	}
 
-	return results, pageInfo, nil
+	return results, subReq, model.PageInfo{}, nil
 }
 
-func (f Fetcher) Fetch(req query.Request, chunks []model.Chunk, urlPath string) ([]FetchResult, error) {
+// cutChunks returns results for chunks not fetched by size limit with the timestamp of their first logs to be read within range.
+func cutChunks(req query.Request, chunks, fetchedChunks []model.Chunk, seriesData model.SeriesData) []FetchResult {
 	results := []FetchResult{}
-	channel := make(chan FetchResult)
+	fetched := map[string]bool{}
 
+	for _, chunk := range fetchedChunks {
+		fetched[chunk.Key()] = true
+	}
+
+	chunkMap := map[string]model.Chunk{}
 	for _, chunk := range chunks {
-		go func(req query.Request, c model.Chunk, channel chan FetchResult) {
-			r := req
-			r.PodUid = c.PodUid
-			r.Container = c.Container
-			r.Source = c.Source
-			result := FetchResult{c, query.Response{}, nil}
-
-			body, err := json.Marshal(r)
-			if err != nil {
-				glog.Error(err)
-				return
+		if !fetched[chunk.Key()] {
+			chunkMap[chunk.Key()] = chunk
+		}
+	}
+
+	cuts := map[string]time.Time{}
+
+	for _, series := range seriesData {
+		if _, ok := chunkMap[series.ChunkKey]; !ok {
+			continue
+		}
+
+		for _, sample := range series.Samples {
+			if sample.Lines == 0 || sample.Timestamp.After(req.End.Time) || sample.Timestamp.Add(model.BucketPrecision).Before(req.Start.Time) {
+				continue
 			}
 
-			defer func(channel chan FetchResult, result *FetchResult) {
-				channel <- *result
-			}(channel, &result)
-
-			resp, err := f.client.Do(&http.Request{
-				Method: http.MethodPost,
-				URL: &url.URL{
-					Scheme: logHandler.Scheme,
-					Host:   c.StoreAddr,
-					Path:   fmt.Sprintf("/api/%s%s", req.Version, urlPath),
-				},
-				Body: io.NopCloser(bytes.NewBuffer(body)),
-			})
-			if err != nil {
-				result.err = err
-				return
+			// samples are aligned to the precision, so logs of the sample may be after the start
+			cut := sample.Timestamp
+			if cut.Before(req.Start.Time) {
+				cut = req.Start.Time
 			}
-			defer func() { _ = resp.Body.Close() }()
 
-			b, err := io.ReadAll(resp.Body)
+			if req.IsBackward() {
+				cut = sample.Timestamp.Add(model.BucketPrecision)
+				if cut.After(req.End.Time) {
+					cut = req.End.Time
+				}
+			}
+
+			if prev, ok := cuts[series.ChunkKey]; !ok || isCloser(req, cut, prev) {
+				cuts[series.ChunkKey] = cut
+			}
+		}
+	}
+
+	for key, cut := range cuts {
+		results = append(results, FetchResult{chunkMap[key], query.Response{}, nil, cut})
+	}
+
+	return results
+}
+
+func (f Fetcher) Fetch(ctx context.Context, req query.Request, chunks []model.Chunk, urlPath string) ([]FetchResult, error) {
+	if f.cache != nil && (urlPath == logHandler.PathLogSeries || urlPath == logHandler.PathLogRange) {
+		return f.fetchWithCache(ctx, req, chunks, urlPath)
+	}
+
+	return f.fetch(ctx, req, chunks, urlPath)
+}
+
+func (f Fetcher) fetch(ctx context.Context, req query.Request, chunks []model.Chunk, urlPath string) ([]FetchResult, error) {
+	if f.streams != nil && f.streams.supports(urlPath) {
+		return f.fetchByStreams(ctx, req, chunks, urlPath)
+	}
+
+	return f.fetchByHttp(ctx, req, chunks, urlPath)
+}
+
+// fetchByStreams opens a stream per store and falls back to http for chunks not answered through it.
+// Chunks of stores not serving streams are fetched over http from the start.
+// Chunks left after the request is done are returned with the error of the context instead of falling back.
+func (f Fetcher) fetchByStreams(ctx context.Context, req query.Request, chunks []model.Chunk, urlPath string) ([]FetchResult, error) {
+	chunksByStore := map[string][]model.Chunk{}
+	for _, chunk := range chunks {
+		chunksByStore[chunk.StoreAddr] = append(chunksByStore[chunk.StoreAddr], chunk)
+	}
+
+	channel := make(chan FetchResult)
+
+	for storeAddr, storeChunks := range chunksByStore {
+		if !f.streams.implemented(storeAddr) {
+			f.fetchChunks(ctx, req, storeChunks, urlPath, channel)
+			continue
+		}
+
+		go func(storeAddr string, storeChunks []model.Chunk) {
+			streamed, err := f.streams.fetch(ctx, req, storeAddr, storeChunks, urlPath)
 			if err != nil {
-				result.err = err
-				return
+				glog.Errorf("failed to fetch from %s through stream, fall back to http: %s", storeAddr, err.Error())
 			}
 
-			if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
-				result.err = errors.ErrorByStatusCode(resp.StatusCode)
-				return
+			missed := []model.Chunk{}
+			for i, result := range streamed {
+				if result == nil {
+					missed = append(missed, storeChunks[i])
+					continue
+				}
+				channel <- *result
 			}
 
-			if err := json.Unmarshal(b, &result.response); err != nil {
-				glog.Errorf("%s | %s", err.Error(), string(b))
+			if err := ctx.Err(); err != nil {
+				for _, chunk := range missed {
+					channel <- FetchResult{chunk, query.Response{}, err, time.Time{}}
+				}
+				return
 			}
-		}(req, chunk, channel)
+			f.fetchChunks(ctx, req, missed, urlPath, channel)
+		}(storeAddr, storeChunks)
 	}
 
+	return collectResults(channel, len(chunks))
+}
+
+func (f Fetcher) fetchByHttp(ctx context.Context, req query.Request, chunks []model.Chunk, urlPath string) ([]FetchResult, error) {
+	channel := make(chan FetchResult)
+	f.fetchChunks(ctx, req, chunks, urlPath, channel)
+
+	return collectResults(channel, len(chunks))
+}
+
+// fetchChunks requests chunks concurrently over http and sends their results to channel.
+func (f Fetcher) fetchChunks(ctx context.Context, req query.Request, chunks []model.Chunk, urlPath string, channel chan FetchResult) {
+	for _, chunk := range chunks {
+		go func(c model.Chunk) {
+			channel <- f.fetchChunk(ctx, req, c, urlPath)
+		}(chunk)
+	}
+}
+
+func collectResults(channel chan FetchResult, size int) ([]FetchResult, error) {
+	results := []FetchResult{}
+
 	var lastError error
 
-	for i := 0; i < len(chunks); i++ {
+	for i := 0; i < size; i++ {
 		r := <-channel
 		if r.err != nil {
 			lastError = r.err
@@ -166,6 +283,59 @@ func (f Fetcher) Fetch(req query.Request, chunks []model.Chunk, urlPath string)
 	return results, lastError
 }
 
+// newChunkRequest returns the request sent to the store for the chunk.
+func newChunkRequest(req query.Request, c model.Chunk) query.Request {
+	r := req
+	r.PodUid = c.PodUid
+	r.Container = c.Container
+	r.Source = c.Source
+	r.Cold = c.Cold
+
+	return r
+}
+
+func (f Fetcher) fetchChunk(ctx context.Context, req query.Request, c model.Chunk, urlPath string) FetchResult {
+	result := FetchResult{c, query.Response{}, nil, time.Time{}}
+
+	body, err := json.Marshal(newChunkRequest(req, c))
+	if err != nil {
+		result.err = err
+		return result
+	}
+
+	resp, err := f.client.Do((&http.Request{
+		Method: http.MethodPost,
+		URL: &url.URL{
+			Scheme: mtls.Scheme(),
+			Host:   c.StoreAddr,
+			Path:   fmt.Sprintf("/api/%s%s", req.Version, urlPath),
+		},
+		Body: io.NopCloser(bytes.NewBuffer(body)),
+	}).WithContext(ctx))
+	if err != nil {
+		result.err = err
+		return result
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		result.err = err
+		return result
+	}
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		result.err = errors.ErrorByStatusCode(resp.StatusCode)
+		return result
+	}
+
+	if err := json.Unmarshal(b, &result.response); err != nil {
+		glog.Errorf("%s | %s", err.Error(), string(b))
+	}
+
+	return result
+}
+
 func limitChunksBySize(req query.Request, chunks []model.Chunk, seriesData model.SeriesData, limit uint64) ([]model.Chunk, bool) {
 	result := []model.Chunk{}
 	chunkMap := map[string]model.Chunk{}
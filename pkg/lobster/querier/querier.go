@@ -17,6 +17,7 @@
 package querier
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -24,6 +25,9 @@ import (
 	"time"
 
 	"github.com/golang/glog"
+	"github.com/naver/lobster/pkg/lobster/cache"
+	"github.com/naver/lobster/pkg/lobster/coldtier"
+	"github.com/naver/lobster/pkg/lobster/hash"
 	"github.com/naver/lobster/pkg/lobster/metrics"
 	"github.com/naver/lobster/pkg/lobster/model"
 	"github.com/naver/lobster/pkg/lobster/querier/broker"
@@ -46,6 +50,7 @@ type Querier struct {
 	db       Database
 	storeMap sync.Map
 	buffer   chan pushedData
+	cold     *coldtier.Index
 	broker.Broker
 	Fetcher
 }
@@ -56,6 +61,10 @@ func init() {
 }
 
 func NewQuerier() *Querier {
+	if err := validateTailConfig(); err != nil {
+		panic(err)
+	}
+
 	db, err := NewDatabase()
 	if err != nil {
 		panic(err)
@@ -66,14 +75,35 @@ func NewQuerier() *Querier {
 		panic(fmt.Errorf("failed to find services"))
 	}
 
+	var cold *coldtier.Index
+	if coldtier.Enabled() {
+		client, err := coldtier.NewClient()
+		if err != nil {
+			panic(err)
+		}
+		operator := hash.HashOperator{Modulus: *conf.Modulus}
+		cold = coldtier.NewIndex(client, func(chunk model.Chunk) bool {
+			return *conf.Modulus == 0 || operator.Modulo(chunk.HotKey()) == uint64(*conf.Id)
+		})
+	}
+
+	var resultCache *cache.Cache
+	if cache.Enabled() {
+		resultCache, err = cache.NewCache()
+		if err != nil {
+			panic(err)
+		}
+	}
+
 	return &Querier{
 		Id:       uint64(*conf.Id),
 		Modulus:  *conf.Modulus,
 		db:       db,
 		storeMap: sync.Map{},
 		buffer:   make(chan pushedData, 10000),
+		cold:     cold,
 		Broker:   broker.NewBroker(addrs),
-		Fetcher:  NewFetcher(*conf.FetchTimeout, *conf.FetchResponseHeaderTimeout),
+		Fetcher:  NewFetcher(*conf.FetchTimeout, *conf.FetchResponseHeaderTimeout, *conf.StoreGrpcPort, *conf.StoreGrpcMaxRecvMsgSize, resultCache),
 	}
 }
 
@@ -87,7 +117,7 @@ func (q *Querier) UpdateStoreStatus(storeAddr string) {
 	q.storeMap.Store(storeAddr, time.Now())
 }
 
-func (q *Querier) GetChunksWithinRange(req query.Request) (chunks []model.Chunk, err error) {
+func (q *Querier) GetChunksWithinRange(ctx context.Context, req query.Request) (chunks []model.Chunk, err error) {
 	var receivedChunks []model.Chunk
 
 	chunks, err = q.getLocalChunksWithinRange(req)
@@ -97,18 +127,18 @@ func (q *Querier) GetChunksWithinRange(req query.Request) (chunks []model.Chunk,
 
 	if !req.Local {
 		req.Local = true
-		receivedChunks, err = q.RequestChunksWithinRange(req, false)
+		receivedChunks, err = q.RequestChunksWithinRange(ctx, req, false)
 		if err != nil {
 			return
 		}
-		chunks = append(chunks, receivedChunks...)
+		chunks = routeColdChunks(append(chunks, receivedChunks...))
 	}
 
 	glog.V(3).Infof("%d chunks | %s", len(chunks), req.String())
 	return
 }
 
-func (q *Querier) GetSeriesInBlocksWithinRange(req query.Request) (numOfChunk int, series model.SeriesData, err error) {
+func (q *Querier) GetSeriesInBlocksWithinRange(ctx context.Context, req query.Request) (numOfChunk int, series model.SeriesData, err error) {
 	var (
 		chunks       []model.Chunk
 		remoteChunks []model.Chunk
@@ -121,13 +151,13 @@ func (q *Querier) GetSeriesInBlocksWithinRange(req query.Request) (numOfChunk in
 	}
 
 	req.Local = true
-	remoteChunks, err = q.RequestChunksWithinRange(req, false)
+	remoteChunks, err = q.RequestChunksWithinRange(ctx, req, false)
 	if err != nil {
 		return
 	}
 
-	chunks = append(chunks, remoteChunks...)
-	results, err = q.Fetch(req, chunks, logHandler.PathLogSeries)
+	chunks = routeColdChunks(append(chunks, remoteChunks...))
+	results, err = q.Fetch(ctx, req, chunks, logHandler.PathLogSeries)
 	if err != nil {
 		return
 	}
@@ -142,11 +172,49 @@ func (q *Querier) GetSeriesInBlocksWithinRange(req query.Request) (numOfChunk in
 	return
 }
 
-func (q *Querier) GetBlocksWithinRange(req query.Request) (data []byte, _, _ time.Time, numOfChunk int, pageInfo model.PageInfo, err error) {
+func (q *Querier) GetAggregationWithinRange(ctx context.Context, req query.Request) (numOfChunk int, data model.AggregationData, err error) {
+	var (
+		chunks       []model.Chunk
+		remoteChunks []model.Chunk
+		results      []FetchResult
+	)
+
+	chunks, err = q.getLocalChunksWithinRange(req)
+	if err != nil {
+		return
+	}
+
+	req.Local = true
+	remoteChunks, err = q.RequestChunksWithinRange(ctx, req, false)
+	if err != nil {
+		return
+	}
+
+	chunks = routeColdChunks(append(chunks, remoteChunks...))
+	results, err = q.Fetch(ctx, req, chunks, logHandler.PathLogAggregate)
+	if err != nil {
+		return
+	}
+
+	data, err = NewAggregationBuilder(results).
+		Merge().
+		Build(*req.Aggregation)
+	if err != nil {
+		return
+	}
+
+	numOfChunk = len(chunks)
+
+	glog.V(3).Infof("chunks %d | fetched %d | groups %d | %s", numOfChunk, len(results), len(data), req.String())
+	return
+}
+
+func (q *Querier) GetBlocksWithinRange(ctx context.Context, req query.Request) (data []byte, _, _ time.Time, numOfChunk int, pageInfo model.PageInfo, err error) {
 	var (
 		chunks           []model.Chunk
 		remoteChunks     []model.Chunk
 		results          []FetchResult
+		subReq           query.Request
 		isPartialEntries bool
 		limit            = *conf.ContentsLimit
 	)
@@ -161,21 +229,25 @@ func (q *Querier) GetBlocksWithinRange(req query.Request) (data []byte, _, _ tim
 	}
 
 	req.Local = true
-	remoteChunks, err = q.RequestChunksWithinRange(req, false)
+	remoteChunks, err = q.RequestChunksWithinRange(ctx, req, false)
 	if err != nil {
 		return
 	}
 
-	chunks = append(chunks, remoteChunks...)
-	results, pageInfo, err = q.GetLogEntries(req, chunks, limit)
+	chunks = routeColdChunks(append(chunks, remoteChunks...))
+	results, subReq, pageInfo, err = q.GetLogEntries(ctx, req, chunks, limit)
 	if err != nil {
 		return
 	}
 
-	data, isPartialEntries = NewEntryBuilder(results, limit).
-		Merge(ParseEntryRaw).
-		SortAscending().
-		BuildRawLogs()
+	builder := NewEntryBuilder(results, limit).
+		Paginate(subReq, pageInfo.HasNext).
+		Merge(query.ParseEntryRaw).
+		SortByDirection()
+
+	data, isPartialEntries = builder.BuildRawLogs()
+	pageInfo.Cursor = builder.NextCursor()
+	pageInfo.HasNext = len(pageInfo.Cursor) > 0
 
 	if isPartialEntries || pageInfo.IsPartialContents {
 		pageInfo.IsPartialContents = true
@@ -188,11 +260,12 @@ func (q *Querier) GetBlocksWithinRange(req query.Request) (data []byte, _, _ tim
 	return
 }
 
-func (q *Querier) GetEntriesWithinRange(req query.Request) (data []model.Entry, numOfChunk int, pageInfo model.PageInfo, err error) {
+func (q *Querier) GetEntriesWithinRange(ctx context.Context, req query.Request) (data []model.Entry, numOfChunk int, pageInfo model.PageInfo, err error) {
 	var (
 		chunks           []model.Chunk
 		remoteChunks     []model.Chunk
 		results          []FetchResult
+		subReq           query.Request
 		isPartialEntries bool
 		limit            = *conf.ContentsLimit
 	)
@@ -207,21 +280,25 @@ func (q *Querier) GetEntriesWithinRange(req query.Request) (data []model.Entry,
 	}
 
 	req.Local = true
-	remoteChunks, err = q.RequestChunksWithinRange(req, false)
+	remoteChunks, err = q.RequestChunksWithinRange(ctx, req, false)
 	if err != nil {
 		return
 	}
 
-	chunks = append(chunks, remoteChunks...)
-	results, pageInfo, err = q.GetLogEntries(req, chunks, limit)
+	chunks = routeColdChunks(append(chunks, remoteChunks...))
+	results, subReq, pageInfo, err = q.GetLogEntries(ctx, req, chunks, limit)
 	if err != nil {
 		return
 	}
 
-	data, isPartialEntries = NewEntryBuilder(results, limit).
-		Merge(ParseEntry).
-		SortAscending().
-		Build()
+	builder := NewEntryBuilder(results, limit).
+		Paginate(subReq, pageInfo.HasNext).
+		Merge(query.ParseEntry).
+		SortByDirection()
+
+	data, isPartialEntries = builder.Build()
+	pageInfo.Cursor = builder.NextCursor()
+	pageInfo.HasNext = len(pageInfo.Cursor) > 0
 
 	if isPartialEntries || pageInfo.IsPartialContents {
 		pageInfo.IsPartialContents = true
@@ -237,15 +314,29 @@ func (q *Querier) GetEntriesWithinRange(req query.Request) (data []model.Entry,
 func (q *Querier) getLocalChunksWithinRange(req query.Request) ([]model.Chunk, error) {
 	chunks := []model.Chunk{}
 	localChunks := []model.Chunk{}
-	chunkMatcher := NewChunkMatcher(req)
+	chunkMatcher := query.NewChunkMatcher(req)
 
 	if len(req.Namespaces) == 0 && len(req.Namespace) == 0 {
-		return q.db.getAllChunksWithinRange(req.Start.Time, req.End.Time)
+		allChunks, err := q.db.getAllChunksWithinRange(req.Start.Time, req.End.Time)
+		if err != nil {
+			return allChunks, err
+		}
+		return append(allChunks, q.getColdChunksWithinRange(req, allChunks)...), nil
 	}
 
 	namespaces := append(req.Namespaces, req.Namespace)
 
 	for _, ns := range namespaces {
+		if len(req.Workloads) > 0 {
+			storedChunks, err := q.getChunksForWorkloadsWithinRange(ns, req)
+			if err != nil {
+				return chunks, err
+			}
+
+			localChunks = append(localChunks, storedChunks...)
+			continue
+		}
+
 		storedChunks, err := q.db.getChunksForNamespaceWithinRange(ns, req.Start.Time, req.End.Time)
 		if err != nil {
 			return chunks, err
@@ -262,6 +353,29 @@ func (q *Querier) getLocalChunksWithinRange(req query.Request) ([]model.Chunk, e
 		chunks = append(chunks, chunk)
 	}
 
+	return append(chunks, q.getColdChunksWithinRange(req, chunks)...), nil
+}
+
+func (q *Querier) getChunksForWorkloadsWithinRange(namespace string, req query.Request) ([]model.Chunk, error) {
+	chunks := []model.Chunk{}
+	found := map[string]bool{}
+
+	for _, workload := range req.Workloads {
+		storedChunks, err := q.db.getChunksForWorkloadWithinRange(namespace, workload, req.Start.Time, req.End.Time)
+		if err != nil {
+			return chunks, err
+		}
+
+		// pods are owned by several workloads in a chain
+		for _, chunk := range storedChunks {
+			if found[chunk.Id] {
+				continue
+			}
+			found[chunk.Id] = true
+			chunks = append(chunks, chunk)
+		}
+	}
+
 	return chunks, nil
 }
 
@@ -280,6 +394,9 @@ func (q *Querier) HandlePushData(data []byte, sourceAddr string) {
 func (q *Querier) Run(stopChan chan struct{}) {
 	go q.receiveChunks(stopChan)
 	go q.handleStatus(stopChan)
+	if q.cold != nil {
+		go q.cold.Run(stopChan)
+	}
 }
 
 func (q *Querier) receiveChunks(stopChan chan struct{}) {
@@ -344,9 +461,11 @@ func (q *Querier) handleStoreStatus() {
 	now := time.Now()
 	q.storeMap.Range(func(key, value interface{}) bool {
 		if *conf.StoreRetentionTime < now.Sub(value.(time.Time)) {
-			if err := q.db.deleteByAddr(key.(string)); err != nil {
+			chunks, err := q.db.deleteByAddr(key.(string))
+			if err != nil {
 				glog.Error(err)
 			}
+			q.invalidateResults(chunks)
 			glog.Infof("delete chunks by store addr %s", key.(string))
 		}
 		return true
@@ -361,11 +480,23 @@ func (q *Querier) handleRetention(chunks []model.Chunk) {
 			if err := q.db.delete(chunk); err != nil {
 				glog.Error(err)
 			}
+			q.invalidateResults([]model.Chunk{chunk})
 			glog.V(3).Infof("deleted chunk : %s_%s_%s", chunk.Namespace, chunk.Pod, chunk.Container)
 		}
 	}
 }
 
+// invalidateResults drops results of chunks which disappeared from the database.
+func (q *Querier) invalidateResults(chunks []model.Chunk) {
+	if q.cache == nil {
+		return
+	}
+
+	for _, chunk := range chunks {
+		q.cache.Invalidate(chunk.Key())
+	}
+}
+
 func (q *Querier) updateMetrics(chunks []model.Chunk) {
 	metrics.SetStoredChunks(float64(len(chunks)))
 }
@@ -0,0 +1,291 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package querier
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/naver/lobster/pkg/lobster/cache"
+	"github.com/naver/lobster/pkg/lobster/model"
+	"github.com/naver/lobster/pkg/lobster/query"
+	logHandler "github.com/naver/lobster/pkg/lobster/server/handler/log"
+)
+
+// fetchWithCache takes results of closed ranges from the cache and fetches the others.
+func (f Fetcher) fetchWithCache(ctx context.Context, req query.Request, chunks []model.Chunk, urlPath string) ([]FetchResult, error) {
+	cutoff := f.cache.Cutoff(time.Now())
+
+	if urlPath == logHandler.PathLogSeries {
+		return f.fetchSeriesWithCache(ctx, req, chunks, cutoff)
+	}
+
+	// logs are paginated, so they are cached only if the whole range is closed
+	if req.End.Time.After(cutoff) {
+		return f.fetch(ctx, req, chunks, urlPath)
+	}
+
+	generations := f.generations(chunks)
+	keys := make([]string, len(chunks))
+	for i, chunk := range chunks {
+		keys[i] = resultKey(urlPath, req, chunk, generations[chunk.Key()])
+	}
+
+	values := f.cache.Get(keys)
+	results := []FetchResult{}
+	missed := []model.Chunk{}
+
+	for i, chunk := range chunks {
+		response := query.Response{}
+		if value, ok := values[keys[i]]; ok && json.Unmarshal(value, &response) == nil {
+			results = append(results, FetchResult{chunk, response, nil, time.Time{}})
+			continue
+		}
+		missed = append(missed, chunk)
+	}
+
+	fetched, err := f.fetch(ctx, req, missed, urlPath)
+	for _, result := range fetched {
+		f.keepResult(resultKey(urlPath, req, result.Chunk, generations[result.Key()]), result)
+	}
+
+	return append(results, fetched...), err
+}
+
+// fetchSeriesWithCache takes series of intervals aligned within closed ranges from the cache and fetches partial intervals at both edges.
+// Chunks missing any of the intervals are fetched for the whole range, and their intervals are kept.
+func (f Fetcher) fetchSeriesWithCache(ctx context.Context, req query.Request, chunks []model.Chunk, cutoff time.Time) ([]FetchResult, error) {
+	start, end := f.cache.Window(req.Start.Time, req.End.Time, cutoff)
+	if !start.Before(end) {
+		return f.fetch(ctx, req, chunks, logHandler.PathLogSeries)
+	}
+
+	intervals := f.cache.Split(start, end)
+	generations := f.generations(chunks)
+	cached, missed := f.getCachedSeries(req, chunks, intervals, generations)
+
+	results, lastError := f.fetch(ctx, req, missed, logHandler.PathLogSeries)
+	for _, result := range results {
+		f.keepSeries(req, result, intervals, generations[result.Key()])
+	}
+
+	if len(cached) == 0 {
+		return results, lastError
+	}
+
+	cachedChunks := []model.Chunk{}
+	for _, result := range cached {
+		cachedChunks = append(cachedChunks, result.Chunk)
+	}
+
+	edges := []query.Request{}
+	if req.Start.Time.Before(start) {
+		head := req
+		head.End.Time = start.Add(-time.Nanosecond)
+		edges = append(edges, head)
+	}
+	if end.Before(req.End.Time) {
+		tail := req
+		tail.Start.Time = end
+		edges = append(edges, tail)
+	}
+
+	for _, edge := range edges {
+		edgeResults, err := f.fetch(ctx, edge, cachedChunks, logHandler.PathLogSeries)
+		if err != nil {
+			lastError = err
+		}
+
+		for _, result := range edgeResults {
+			if base, ok := cached[chunkId(result.Chunk)]; ok && result.response.SeriesData != nil {
+				merged := mergeSeries(*base.response.SeriesData, *result.response.SeriesData)
+				base.response.SeriesData = &merged
+			}
+		}
+	}
+
+	for _, result := range cached {
+		results = append(results, *result)
+	}
+
+	return results, lastError
+}
+
+// getCachedSeries returns results of chunks having series of all intervals in the cache and the other chunks.
+func (f Fetcher) getCachedSeries(req query.Request, chunks []model.Chunk, intervals [][2]time.Time, generations map[string]string) (map[string]*FetchResult, []model.Chunk) {
+	keys := make([][]string, len(chunks))
+	allKeys := []string{}
+
+	for i, chunk := range chunks {
+		for _, interval := range intervals {
+			key := resultKey(logHandler.PathLogSeries, intervalRequest(req, interval), chunk, generations[chunk.Key()])
+			keys[i] = append(keys[i], key)
+			allKeys = append(allKeys, key)
+		}
+	}
+
+	values := f.cache.Get(allKeys)
+	cached := map[string]*FetchResult{}
+	missed := []model.Chunk{}
+
+	for i, chunk := range chunks {
+		seriesData, ok := model.SeriesData{}, true
+
+		for _, key := range keys[i] {
+			intervalSeries := model.SeriesData{}
+			value, found := values[key]
+			if !found || json.Unmarshal(value, &intervalSeries) != nil {
+				ok = false
+				break
+			}
+			seriesData = mergeSeries(seriesData, intervalSeries)
+		}
+
+		if !ok {
+			missed = append(missed, chunk)
+			continue
+		}
+
+		cached[chunkId(chunk)] = &FetchResult{chunk, query.Response{SeriesData: &seriesData}, nil, time.Time{}}
+	}
+
+	return cached, missed
+}
+
+// keepSeries divides series of the chunk fetched for the whole range into intervals to keep them.
+func (f Fetcher) keepSeries(req query.Request, result FetchResult, intervals [][2]time.Time, generation string) {
+	// no series if the store doesn't have the chunk, which may be found later
+	if result.err != nil || result.response.SeriesData == nil || len(*result.response.SeriesData) == 0 {
+		return
+	}
+
+	for _, interval := range intervals {
+		intervalSeries := model.SeriesData{}
+
+		for _, series := range *result.response.SeriesData {
+			s := &model.Series{ChunkKey: series.ChunkKey, Name: series.Name}
+			for _, sample := range series.Samples {
+				if !sample.Timestamp.Before(interval[0]) && sample.Timestamp.Before(interval[1]) {
+					s.Append(sample)
+				}
+			}
+
+			if len(s.Samples) > 0 {
+				intervalSeries = append(intervalSeries, s)
+			}
+		}
+
+		data, err := json.Marshal(intervalSeries)
+		if err != nil {
+			glog.Error(err)
+			return
+		}
+		f.cache.Set(result.Key(), resultKey(logHandler.PathLogSeries, intervalRequest(req, interval), result.Chunk, generation), data)
+	}
+}
+
+// keepResult keeps logs of the chunk; failures and empty logs, e.g. of stores not having the chunk yet, are not kept.
+func (f Fetcher) keepResult(key string, result FetchResult) {
+	if result.err != nil || len(result.response.Contents) == 0 {
+		return
+	}
+
+	data, err := json.Marshal(result.response)
+	if err != nil {
+		glog.Error(err)
+		return
+	}
+
+	f.cache.Set(result.Key(), key, data)
+}
+
+// generations returns generations of chunks by their keys.
+func (f Fetcher) generations(chunks []model.Chunk) map[string]string {
+	keys := make([]string, len(chunks))
+	for i, chunk := range chunks {
+		keys[i] = chunk.Key()
+	}
+
+	return f.cache.Generations(keys)
+}
+
+// intervalRequest returns the request of the interval which excludes its end.
+func intervalRequest(req query.Request, interval [2]time.Time) query.Request {
+	r := req
+	r.Start.Time = interval[0]
+	r.End.Time = interval[1].Add(-time.Nanosecond)
+
+	return r
+}
+
+// resultKey identifies results of the chunk for the request.
+// The chunk start is included since results change when old blocks of the chunk are deleted,
+// and the generation is included since results are dropped when the chunk is invalidated.
+func resultKey(urlPath string, req query.Request, chunk model.Chunk, generation string) string {
+	r := newChunkRequest(req, chunk)
+
+	// chunks are selected already, so conditions to select them don't change results
+	r.ID = ""
+	r.Clusters = nil
+	r.Namespaces = nil
+	r.Labels = nil
+	r.SetNames = nil
+	r.Workloads = nil
+	r.Pods = nil
+	r.Containers = nil
+	r.Sources = nil
+	r.Namespace = ""
+	r.SetName = ""
+	r.Pod = ""
+	r.Local = false
+	r.Attachment = false
+
+	return cache.Key(urlPath, r.Version, r, chunk.Key(), chunk.StartedAt, generation)
+}
+
+// chunkId distinguishes cold chunks from hot chunks of the same key.
+func chunkId(chunk model.Chunk) string {
+	return fmt.Sprintf("%s/%t", chunk.Key(), chunk.Cold)
+}
+
+// mergeSeries merges series of the same name whose samples are in different ranges.
+func mergeSeries(seriesData, other model.SeriesData) model.SeriesData {
+	merged := model.SeriesData{}
+	byName := map[string]*model.Series{}
+
+	for _, series := range append(append(model.SeriesData{}, seriesData...), other...) {
+		s, ok := byName[series.Name]
+		if !ok {
+			s = &model.Series{ChunkKey: series.ChunkKey, Name: series.Name}
+			byName[series.Name] = s
+			merged = append(merged, s)
+		}
+
+		for _, sample := range series.Samples {
+			s.Append(sample)
+		}
+	}
+
+	for _, series := range merged {
+		series.ReorderSamples()
+	}
+
+	return merged
+}
@@ -0,0 +1,171 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package querier
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/naver/lobster/pkg/lobster/cache"
+	"github.com/naver/lobster/pkg/lobster/model"
+	"github.com/naver/lobster/pkg/lobster/query"
+	logHandler "github.com/naver/lobster/pkg/lobster/server/handler/log"
+	"github.com/naver/lobster/pkg/lobster/util"
+)
+
+func newTestResultCache(t *testing.T) *cache.Cache {
+	if err := flag.Set("cache.backend", cache.BackendMemory); err != nil {
+		t.Fatal(err)
+	}
+	resultCache, err := cache.NewCache()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return resultCache
+}
+
+// newTestSeriesStore serves a sample every minute, or no content if empty is set, and records requested ranges.
+func newTestSeriesStore(t *testing.T, empty bool, requested *[][2]time.Time) string {
+	lock := sync.Mutex{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := query.Request{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error(err)
+		}
+
+		lock.Lock()
+		*requested = append(*requested, [2]time.Time{req.Start.Time, req.End.Time})
+		lock.Unlock()
+
+		if empty {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		series := &model.Series{ChunkKey: req.Container, Name: req.Container}
+		for ts := req.Start.Time.Truncate(time.Minute); !ts.After(req.End.Time); ts = ts.Add(time.Minute) {
+			if !ts.Before(req.Start.Time) {
+				series.Append(model.Sample{Timestamp: ts, Lines: 1})
+			}
+		}
+
+		data, _ := json.Marshal(query.Response{SeriesData: &model.SeriesData{series}})
+		_, _ = w.Write(data)
+	}))
+	t.Cleanup(server.Close)
+
+	return server.Listener.Addr().String()
+}
+
+func TestFetchSeriesWithCache(t *testing.T) {
+	requested := [][2]time.Time{}
+	storeAddr := newTestSeriesStore(t, false, &requested)
+
+	fetcher := NewFetcher(time.Second, time.Second, "", 1024*1024, newTestResultCache(t))
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	req := query.Request{
+		Version: logHandler.ApiV2,
+		Start:   util.Timestamp{Time: base.Add(5 * time.Minute)},
+		End:     util.Timestamp{Time: base.Add(47 * time.Minute)},
+	}
+	chunks := testChunks(storeAddr, "a")
+
+	for i, expectedRequests := range []int{1, 2} {
+		requested = [][2]time.Time{}
+
+		results, err := fetcher.Fetch(context.Background(), req, chunks, logHandler.PathLogSeries)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(requested) != expectedRequests {
+			t.Errorf("#%d: expected %d requests but got %v", i, expectedRequests, requested)
+		}
+
+		if len(results) != 1 || results[0].response.SeriesData == nil {
+			t.Fatalf("#%d: unexpected results %v", i, results)
+		}
+
+		series := (*results[0].response.SeriesData)[0]
+		if series.Lines != 43 || len(series.Samples) != 43 {
+			t.Errorf("#%d: expected 43 samples but got %d(%d lines)", i, len(series.Samples), series.Lines)
+		}
+	}
+
+	// only the edges out of the cached intervals are fetched
+	if !requested[0][1].Before(base.Add(10*time.Minute)) || !requested[1][0].Equal(base.Add(40*time.Minute)) {
+		t.Errorf("unexpected requests of edges %v", requested)
+	}
+}
+
+func TestFetchSeriesWithCacheAlignedEnd(t *testing.T) {
+	requested := [][2]time.Time{}
+	storeAddr := newTestSeriesStore(t, false, &requested)
+
+	fetcher := NewFetcher(time.Second, time.Second, "", 1024*1024, newTestResultCache(t))
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	req := query.Request{
+		Version: logHandler.ApiV2,
+		Start:   util.Timestamp{Time: base.Add(5 * time.Minute)},
+		End:     util.Timestamp{Time: base.Add(40 * time.Minute)},
+	}
+	chunks := testChunks(storeAddr, "a")
+
+	for i := 0; i < 2; i++ {
+		requested = [][2]time.Time{}
+		if _, err := fetcher.Fetch(context.Background(), req, chunks, logHandler.PathLogSeries); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// no tail edge is fetched if the range ends at the last interval
+	if len(requested) != 1 || !requested[0][1].Before(base.Add(10*time.Minute)) {
+		t.Errorf("expected only the head edge but got %v", requested)
+	}
+}
+
+func TestFetchSeriesWithCacheSkipsEmpty(t *testing.T) {
+	requested := [][2]time.Time{}
+	storeAddr := newTestSeriesStore(t, true, &requested)
+
+	fetcher := NewFetcher(time.Second, time.Second, "", 1024*1024, newTestResultCache(t))
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	req := query.Request{
+		Version: logHandler.ApiV2,
+		Start:   util.Timestamp{Time: base},
+		End:     util.Timestamp{Time: base.Add(time.Hour)},
+	}
+	chunks := testChunks(storeAddr, "a")
+
+	for i := 0; i < 2; i++ {
+		if _, err := fetcher.Fetch(context.Background(), req, chunks, logHandler.PathLogSeries); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// chunks without series are fetched again since stores may have them later
+	if len(requested) != 2 || !requested[1][0].Equal(base) || !requested[1][1].Equal(base.Add(time.Hour)) {
+		t.Errorf("expected the whole range fetched twice but got %v", requested)
+	}
+}
@@ -0,0 +1,194 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package querier
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/naver/lobster/pkg/lobster/model"
+	"github.com/naver/lobster/pkg/lobster/mtls"
+	"github.com/naver/lobster/pkg/lobster/proto"
+	"github.com/naver/lobster/pkg/lobster/query"
+	"github.com/naver/lobster/pkg/lobster/server/errors"
+	logHandler "github.com/naver/lobster/pkg/lobster/server/handler/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// streamClient fetches chunks of a store through one grpc stream instead of a request per chunk.
+type streamClient struct {
+	port           string
+	maxRecvMsgSize int
+	timeout        time.Duration
+	converter      proto.Converter
+	lock           sync.Mutex
+	conns          map[string]*grpc.ClientConn
+	// stores answering streams with Unimplemented, which are fetched over http
+	unimplemented map[string]bool
+}
+
+func newStreamClient(port string, maxRecvMsgSize int, timeout time.Duration) *streamClient {
+	return &streamClient{
+		port:           port,
+		maxRecvMsgSize: maxRecvMsgSize,
+		timeout:        timeout,
+		conns:          map[string]*grpc.ClientConn{},
+		unimplemented:  map[string]bool{},
+	}
+}
+
+func (s *streamClient) supports(urlPath string) bool {
+	return urlPath == logHandler.PathLogSeries || urlPath == logHandler.PathLogRange
+}
+
+// implemented returns false if the store has answered that it does not serve streams.
+func (s *streamClient) implemented(storeAddr string) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return !s.unimplemented[storeAddr]
+}
+
+func (s *streamClient) markUnimplemented(storeAddr string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.unimplemented[storeAddr] = true
+}
+
+// conn returns a connection to the grpc server of the store serving http on storeAddr.
+func (s *streamClient) conn(storeAddr string) (*grpc.ClientConn, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if conn, ok := s.conns[storeAddr]; ok {
+		return conn, nil
+	}
+
+	host, _, err := net.SplitHostPort(storeAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := grpc.NewClient(
+		net.JoinHostPort(host, s.port),
+		grpc.WithTransportCredentials(mtls.GrpcCredentials()),
+		grpc.WithConnectParams(grpc.ConnectParams{MinConnectTimeout: time.Second}),
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(s.maxRecvMsgSize)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	s.conns[storeAddr] = conn
+
+	return conn, nil
+}
+
+// fetch returns results in the order of chunks; results of chunks not answered by the store are nil.
+func (s *streamClient) fetch(ctx context.Context, req query.Request, storeAddr string, chunks []model.Chunk, urlPath string) ([]*FetchResult, error) {
+	results := make([]*FetchResult, len(chunks))
+
+	conn, err := s.conn(storeAddr)
+	if err != nil {
+		return results, err
+	}
+
+	fetchReq := &proto.FetchRequest{Version: req.Version}
+	for _, chunk := range chunks {
+		body, err := json.Marshal(newChunkRequest(req, chunk))
+		if err != nil {
+			return results, err
+		}
+		fetchReq.Requests = append(fetchReq.Requests, body)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	client := proto.NewChunkServiceClient(conn)
+
+	var stream grpc.ServerStreamingClient[proto.FetchResponse]
+	if urlPath == logHandler.PathLogSeries {
+		stream, err = client.FetchSeries(ctx, fetchReq)
+	} else {
+		stream, err = client.FetchLogs(ctx, fetchReq)
+	}
+	if err != nil {
+		return results, s.checkUnimplemented(storeAddr, err)
+	}
+
+	received := 0
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return results, s.checkUnimplemented(storeAddr, err)
+		}
+
+		index := int(resp.Index)
+		if index < 0 || index >= len(chunks) || results[index] != nil {
+			continue
+		}
+
+		results[index] = s.toResult(chunks[index], resp, urlPath)
+		received++
+	}
+
+	if received < len(chunks) {
+		return results, fmt.Errorf("%d/%d responses are received", received, len(chunks))
+	}
+
+	return results, nil
+}
+
+// checkUnimplemented remembers stores of older versions without streams, which are not asked again.
+func (s *streamClient) checkUnimplemented(storeAddr string, err error) error {
+	if status.Code(err) == codes.Unimplemented {
+		s.markUnimplemented(storeAddr)
+	}
+
+	return err
+}
+
+func (s *streamClient) toResult(chunk model.Chunk, resp *proto.FetchResponse, urlPath string) *FetchResult {
+	result := &FetchResult{chunk, query.Response{}, nil, time.Time{}}
+
+	switch resp.Status {
+	case http.StatusOK:
+		if urlPath == logHandler.PathLogSeries {
+			seriesData := s.converter.ToSeriesData(resp.Series)
+			result.response.SeriesData = &seriesData
+		}
+		result.response.Contents = string(resp.Contents)
+		result.response.PageInfo = s.converter.ToPageInfo(resp.PageInfo)
+	case http.StatusNoContent:
+	default:
+		result.err = errors.ErrorByStatusCode(int(resp.Status))
+	}
+
+	return result
+}
@@ -0,0 +1,204 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package querier
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/naver/lobster/pkg/lobster/model"
+	"github.com/naver/lobster/pkg/lobster/proto"
+	"github.com/naver/lobster/pkg/lobster/query"
+	serverErrors "github.com/naver/lobster/pkg/lobster/server/errors"
+	logHandler "github.com/naver/lobster/pkg/lobster/server/handler/log"
+	"google.golang.org/grpc"
+)
+
+type fakeChunkService struct {
+	// responds only to requests before failAt
+	failAt int
+	status int32
+	proto.UnimplementedChunkServiceServer
+}
+
+func (s *fakeChunkService) FetchSeries(req *proto.FetchRequest, stream grpc.ServerStreamingServer[proto.FetchResponse]) error {
+	for i, body := range req.Requests {
+		if i == s.failAt {
+			return errors.New("broken stream")
+		}
+
+		r := query.Request{}
+		if err := json.Unmarshal(body, &r); err != nil {
+			return err
+		}
+
+		if err := stream.Send(&proto.FetchResponse{
+			Index:  int32(i),
+			Status: s.status,
+			Series: []*proto.ProtoSeries{{ChunkKey: r.Container, Lines: 1}},
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func newTestFetcher(t *testing.T, service proto.ChunkServiceServer, requested *int64) (Fetcher, string) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	grpcServer := grpc.NewServer()
+	proto.RegisterChunkServiceServer(grpcServer, service)
+	go func() { _ = grpcServer.Serve(lis) }()
+	t.Cleanup(grpcServer.Stop)
+
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(requested, 1)
+
+		req := query.Request{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error(err)
+		}
+
+		data, _ := json.Marshal(query.Response{SeriesData: &model.SeriesData{{ChunkKey: req.Container, Lines: 1}}})
+		_, _ = w.Write(data)
+	}))
+	t.Cleanup(httpServer.Close)
+
+	_, port, _ := net.SplitHostPort(lis.Addr().String())
+
+	return NewFetcher(time.Second, time.Second, port, 1024*1024, nil), httpServer.Listener.Addr().String()
+}
+
+func testChunks(storeAddr string, containers ...string) []model.Chunk {
+	chunks := []model.Chunk{}
+	for _, container := range containers {
+		chunks = append(chunks, model.Chunk{PodUid: "uid", Container: container, StoreAddr: storeAddr})
+	}
+
+	return chunks
+}
+
+func TestFetchByStream(t *testing.T) {
+	var requested int64
+	fetcher, storeAddr := newTestFetcher(t, &fakeChunkService{failAt: -1, status: http.StatusOK}, &requested)
+
+	results, err := fetcher.Fetch(context.Background(), query.Request{Version: logHandler.ApiV2}, testChunks(storeAddr, "a", "b", "c"), logHandler.PathLogSeries)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results but got %d", len(results))
+	}
+
+	for _, result := range results {
+		if result.response.SeriesData == nil || (*result.response.SeriesData)[0].ChunkKey != result.Container {
+			t.Errorf("unexpected series of %s: %v", result.Container, result.response.SeriesData)
+		}
+	}
+
+	if n := atomic.LoadInt64(&requested); n != 0 {
+		t.Errorf("expected no http requests but got %d", n)
+	}
+}
+
+func TestFetchByStreamFallsBackToHttp(t *testing.T) {
+	var requested int64
+	fetcher, storeAddr := newTestFetcher(t, &fakeChunkService{failAt: 1, status: http.StatusOK}, &requested)
+
+	results, err := fetcher.Fetch(context.Background(), query.Request{Version: logHandler.ApiV2}, testChunks(storeAddr, "a", "b", "c"), logHandler.PathLogSeries)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results but got %d", len(results))
+	}
+
+	if n := atomic.LoadInt64(&requested); n != 2 {
+		t.Errorf("expected 2 http requests for chunks not streamed but got %d", n)
+	}
+}
+
+func TestFetchByStreamUnimplemented(t *testing.T) {
+	var requested int64
+	fetcher, storeAddr := newTestFetcher(t, proto.UnimplementedChunkServiceServer{}, &requested)
+
+	for i := 0; i < 2; i++ {
+		results, err := fetcher.Fetch(context.Background(), query.Request{Version: logHandler.ApiV2}, testChunks(storeAddr, "a", "b"), logHandler.PathLogSeries)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(results) != 2 {
+			t.Fatalf("expected 2 results but got %d", len(results))
+		}
+	}
+
+	if fetcher.streams.implemented(storeAddr) {
+		t.Error("stores without streams must be remembered")
+	}
+
+	if n := atomic.LoadInt64(&requested); n != 4 {
+		t.Errorf("expected 4 http requests but got %d", n)
+	}
+}
+
+func TestFetchByStreamStatus(t *testing.T) {
+	var requested int64
+	fetcher, storeAddr := newTestFetcher(t, &fakeChunkService{failAt: -1, status: http.StatusTooManyRequests}, &requested)
+
+	results, err := fetcher.Fetch(context.Background(), query.Request{Version: logHandler.ApiV2}, testChunks(storeAddr, "a"), logHandler.PathLogSeries)
+	if err != serverErrors.ErrTooManyRequests {
+		t.Errorf("expected too many requests but got %v", err)
+	}
+
+	if len(results) != 0 {
+		t.Errorf("expected no results but got %d", len(results))
+	}
+}
+
+func TestFetchCanceled(t *testing.T) {
+	var requested int64
+	fetcher, storeAddr := newTestFetcher(t, &fakeChunkService{failAt: -1, status: http.StatusOK}, &requested)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results, err := fetcher.Fetch(ctx, query.Request{Version: logHandler.ApiV2}, testChunks(storeAddr, "a", "b"), logHandler.PathLogSeries)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected canceled but got %v", err)
+	}
+
+	if len(results) != 0 {
+		t.Errorf("expected no results but got %d", len(results))
+	}
+
+	if n := atomic.LoadInt64(&requested); n != 0 {
+		t.Errorf("expected no http requests after cancellation but got %d", n)
+	}
+}
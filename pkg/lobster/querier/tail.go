@@ -0,0 +1,239 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package querier
+
+import (
+	"bufio"
+	"bytes"
+	"container/heap"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/naver/lobster/pkg/lobster/auth"
+	"github.com/naver/lobster/pkg/lobster/model"
+	"github.com/naver/lobster/pkg/lobster/mtls"
+	"github.com/naver/lobster/pkg/lobster/query"
+	"github.com/naver/lobster/pkg/lobster/server/errors"
+	logHandler "github.com/naver/lobster/pkg/lobster/server/handler/log"
+)
+
+const (
+	tailEventPrefix   = "data: "
+	minTailMergeDelay = 10 * time.Millisecond
+)
+
+// Streams are kept open as long as clients follow logs
+var tailClient = &http.Client{Transport: &http.Transport{TLSClientConfig: mtls.ClientConfig()}}
+
+type tailResult struct {
+	addr string
+	err  error
+}
+
+func validateTailConfig() error {
+	if *conf.TailMergeDelay < minTailMergeDelay {
+		return fmt.Errorf("querier.tailMergeDelay must be at least %s", minTailMergeDelay)
+	}
+	if *conf.TailRefreshInterval <= 0 {
+		return fmt.Errorf("querier.tailRefreshInterval must be positive")
+	}
+
+	return nil
+}
+
+// Tail follows logs from stores having local chunks matched with the request and from remote queriers following their own stores.
+// Entries are held for a merge delay to be sent in time order across stores.
+func (q *Querier) Tail(ctx context.Context, req query.Request, send func(model.Entry) error) error {
+	if err := q.Validate(req); err != nil {
+		return err
+	}
+
+	var (
+		entries           = make(chan model.Entry, 1000)
+		results           = make(chan tailResult)
+		streams           = map[string]context.CancelFunc{}
+		pending           = &entryHeap{}
+		mergeTicker       = time.NewTicker(*conf.TailMergeDelay / 2)
+		refreshTicker     = time.NewTicker(*conf.TailRefreshInterval)
+		streamCtx, cancel = context.WithCancel(ctx)
+	)
+
+	defer func() {
+		cancel()
+		mergeTicker.Stop()
+		refreshTicker.Stop()
+		for range streams {
+			<-results
+		}
+	}()
+
+	refresh := func() {
+		for _, addr := range q.findTailedAddrs(req) {
+			if _, ok := streams[addr]; ok {
+				continue
+			}
+
+			c, cancelStream := context.WithCancel(streamCtx)
+			streams[addr] = cancelStream
+
+			go func(addr string) {
+				results <- tailResult{addr, tailFrom(c, addr, req, entries)}
+			}(addr)
+		}
+	}
+
+	refresh()
+
+	for {
+		select {
+		case entry := <-entries:
+			heap.Push(pending, entry)
+		case <-mergeTicker.C:
+			deadline := time.Now().Add(-*conf.TailMergeDelay)
+			for pending.Len() > 0 && (*pending)[0].Timestamp.Before(deadline) {
+				if err := send(heap.Pop(pending).(model.Entry)); err != nil {
+					return err
+				}
+			}
+		case <-refreshTicker.C:
+			refresh()
+		case result := <-results:
+			streams[result.addr]()
+			delete(streams, result.addr)
+			if result.err != nil && ctx.Err() == nil {
+				glog.Errorf("failed to tail %s: %s", result.addr, result.err.Error())
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// findTailedAddrs returns stores of local chunks recently updated and, unless the request is from another querier, remote queriers.
+// Each store pushes its chunks to a single querier, so stores are not followed twice.
+func (q *Querier) findTailedAddrs(req query.Request) []string {
+	var (
+		addrs = []string{}
+		seen  = map[string]bool{}
+		now   = time.Now()
+	)
+
+	req.Start.Time = now.Add(-*conf.TailLookback)
+	req.End.Time = now
+
+	chunks, err := q.getLocalChunksWithinRange(req)
+	if err != nil {
+		glog.Error(err)
+	}
+
+	for _, chunk := range chunks {
+		if len(chunk.StoreAddr) == 0 || seen[chunk.StoreAddr] {
+			continue
+		}
+		seen[chunk.StoreAddr] = true
+		addrs = append(addrs, chunk.StoreAddr)
+	}
+
+	if !req.Local {
+		addrs = append(addrs, q.RemoteAddresses()...)
+	}
+
+	return addrs
+}
+
+// tailFrom follows logs of a store or a remote querier, which serve the same stream of entries.
+func tailFrom(ctx context.Context, addr string, req query.Request, entries chan model.Entry) error {
+	req.Local = true
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	r, err := http.NewRequestWithContext(ctx, http.MethodPost, (&url.URL{
+		Scheme: mtls.Scheme(),
+		Host:   addr,
+		Path:   fmt.Sprintf("/api/%s%s", req.Version, logHandler.PathLogTail),
+	}).String(), io.NopCloser(bytes.NewBuffer(body)))
+	if err != nil {
+		return err
+	}
+	auth.Forward(ctx, r)
+
+	resp, err := tailClient.Do(r)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.ErrorByStatusCode(resp.StatusCode)
+	}
+
+	reader := bufio.NewReader(resp.Body)
+
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			if err == io.EOF || ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+
+		if !strings.HasPrefix(line, tailEventPrefix) {
+			continue
+		}
+
+		entry := model.Entry{}
+		if err := json.Unmarshal([]byte(strings.TrimPrefix(line, tailEventPrefix)), &entry); err != nil {
+			glog.Errorf("%s | %s", err.Error(), line)
+			continue
+		}
+
+		select {
+		case entries <- entry:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+type entryHeap []model.Entry
+
+func (h entryHeap) Len() int           { return len(h) }
+func (h entryHeap) Less(i, j int) bool { return h[i].Timestamp.Before(h[j].Timestamp) }
+func (h entryHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *entryHeap) Push(x any) {
+	*h = append(*h, x.(model.Entry))
+}
+
+func (h *entryHeap) Pop() any {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[:n-1]
+	return x
+}
@@ -0,0 +1,42 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package querier
+
+import (
+	"testing"
+	"time"
+)
+
+func TestValidateTailConfig(t *testing.T) {
+	mergeDelay := *conf.TailMergeDelay
+	defer func() { *conf.TailMergeDelay = mergeDelay }()
+
+	for _, tc := range []struct {
+		mergeDelay time.Duration
+		valid      bool
+	}{
+		{time.Second, true},
+		{minTailMergeDelay, true},
+		{time.Nanosecond, false},
+		{0, false},
+	} {
+		*conf.TailMergeDelay = tc.mergeDelay
+		if err := validateTailConfig(); (err == nil) != tc.valid {
+			t.Errorf("merge delay %s: expected valid %t but got %v", tc.mergeDelay, tc.valid, err)
+		}
+	}
+}
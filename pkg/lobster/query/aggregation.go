@@ -0,0 +1,120 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package query
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/naver/lobster/pkg/lobster/model"
+)
+
+const (
+	FunctionCountOverTime = "count_over_time"
+	FunctionRate          = "rate"
+	FunctionBytesOverTime = "bytes_over_time"
+	FunctionBytesRate     = "bytes_rate"
+
+	GroupByCluster   = "cluster"
+	GroupByNamespace = "namespace"
+	GroupBySetName   = "set_name"
+	GroupByPod       = "pod"
+	GroupByContainer = "container"
+
+	defaultAggregationStep = time.Minute
+)
+
+// Aggregation
+// @Description Aggregate logs matched with conditions into time buckets per group instead of returning them.
+type Aggregation struct {
+	// One of count_over_time, rate(lines per second), bytes_over_time and bytes_rate(bytes per second)
+	Function string `json:"function"`
+	// Width of time buckets; e.g. `1m`(default)
+	Step string `json:"step,omitempty"`
+	// Group by cluster, namespace, set_name, pod, container or names of pod labels
+	By []string `json:"by,omitempty"`
+	// Return only k groups having the largest totals within range if it is greater than 0
+	TopK int `json:"topk,omitempty"`
+}
+
+func (a Aggregation) Validate() error {
+	switch a.Function {
+	case FunctionCountOverTime, FunctionRate, FunctionBytesOverTime, FunctionBytesRate:
+	default:
+		return fmt.Errorf("unsupported aggregation function %q", a.Function)
+	}
+
+	step, err := a.StepDuration()
+	if err != nil {
+		return err
+	}
+	if step < model.BucketPrecision {
+		return fmt.Errorf("aggregation step must be at least %s", model.BucketPrecision)
+	}
+
+	if a.TopK < 0 {
+		return errors.New("invalid parameter value `topk`")
+	}
+
+	return nil
+}
+
+func (a Aggregation) StepDuration() (time.Duration, error) {
+	if len(a.Step) == 0 {
+		return defaultAggregationStep, nil
+	}
+
+	step, err := time.ParseDuration(a.Step)
+	if err != nil {
+		return 0, errors.New("invalid parameter value `step`")
+	}
+
+	return step, nil
+}
+
+// GroupLabels returns values of the chunk for names to group by.
+func (a Aggregation) GroupLabels(chunk model.Chunk) map[string]string {
+	labels := map[string]string{}
+
+	for _, name := range a.By {
+		switch name {
+		case GroupByCluster:
+			labels[name] = chunk.Cluster
+		case GroupByNamespace:
+			labels[name] = chunk.Namespace
+		case GroupBySetName:
+			labels[name] = chunk.SetName
+		case GroupByPod:
+			labels[name] = chunk.Pod
+		case GroupByContainer:
+			labels[name] = chunk.Container
+		default:
+			labels[name] = chunk.Labels[name]
+		}
+	}
+
+	return labels
+}
+
+// BucketStart aligns ts to the start of the step it belongs to from start.
+func BucketStart(start, ts time.Time, step time.Duration) time.Time {
+	if ts.Before(start) {
+		return start
+	}
+	return start.Add(ts.Sub(start) / step * step)
+}
@@ -0,0 +1,57 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package query
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// Cursor is the position where the previous response ended.
+// Logs of each chunk at the timestamp are returned in the order they are stored,
+// so the numbers of logs already returned at the timestamp are enough to resume.
+type Cursor struct {
+	Timestamp time.Time      `json:"t"`
+	Skips     map[string]int `json:"s,omitempty"`
+}
+
+func ParseCursor(value string) (Cursor, error) {
+	cursor := Cursor{}
+
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return cursor, errors.New("invalid parameter value `cursor`")
+	}
+
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.Timestamp.IsZero() {
+		return cursor, errors.New("invalid parameter value `cursor`")
+	}
+
+	return cursor, nil
+}
+
+func (c Cursor) String() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// Skip returns the number of logs of the chunk already returned at the timestamp of the cursor.
+func (c Cursor) Skip(chunkKey string) int {
+	return c.Skips[chunkKey]
+}
@@ -0,0 +1,90 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package query
+
+import (
+	"strings"
+
+	"github.com/naver/lobster/pkg/lobster/logline"
+	"github.com/naver/lobster/pkg/lobster/model"
+)
+
+type ParseFunc func(string, model.Chunk) (model.Entry, error)
+
+func ParseEntry(line string, chunk model.Chunk) (model.Entry, error) {
+	var (
+		e   = model.NewEntryFromChunk(chunk)
+		err error
+	)
+
+	line, e.Context = strings.CutPrefix(line, model.ContextLinePrefix)
+
+	e.Timestamp, err = logline.ParseTimestamp(line)
+	if err != nil {
+		return e, err
+	}
+
+	if chunk.Source.Type == model.LogTypeStdStream {
+		e.Stream, err = logline.ParseStream(line)
+		if err != nil {
+			return e, err
+		}
+
+		e.Tag, err = logline.ParseTag(line)
+		if err != nil {
+			return e, err
+		}
+
+		e.Message, err = logline.ParseLogMessage(line)
+		if err != nil {
+			return e, err
+		}
+	} else {
+		e.Message = line
+	}
+
+	e.Message = stripNewline(model.ExpandMultiline(e.Message))
+
+	return e, nil
+}
+
+// ParseEntryRaw keeps the prefix of context lines in the message to tell them from matched lines in raw contents.
+// Multiline logs are kept as a line, since raw contents have a line per entry.
+func ParseEntryRaw(line string, chunk model.Chunk) (model.Entry, error) {
+	var (
+		e   = model.NewEntryFromChunk(chunk)
+		err error
+	)
+
+	e.Message = line
+	line, e.Context = strings.CutPrefix(line, model.ContextLinePrefix)
+
+	e.Timestamp, err = logline.ParseTimestamp(line)
+	if err != nil {
+		return e, err
+	}
+
+	return e, nil
+}
+
+func stripNewline(msg string) string {
+	if len(msg) == 0 {
+		return msg
+	}
+
+	return msg[:len(msg)-1]
+}
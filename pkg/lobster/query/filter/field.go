@@ -0,0 +1,297 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package filter
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/naver/lobster/pkg/lobster/logline"
+)
+
+const (
+	FormatJson   = "json"
+	FormatLogfmt = "logfmt"
+
+	OpEqual        = "="
+	OpNotEqual     = "!="
+	OpMatch        = "=~"
+	OpNotMatch     = "!~"
+	OpGreater      = ">"
+	OpGreaterEqual = ">="
+	OpLess         = "<"
+	OpLessEqual    = "<="
+	OpExists       = "exists"
+	OpNotExists    = "!exists"
+)
+
+// FieldFilter describes a condition on a field of json or logfmt messages.
+type FieldFilter struct {
+	// Format of messages; json or logfmt
+	Format string `json:"format"`
+	// Dot-separated path of the field; e.g. `level`, `http.status` or `items.0.id`
+	Path string `json:"path"`
+	// One of =, !=, =~, !~, >, >=, <, <=, exists and !exists
+	Op string `json:"op"`
+	// Value to compare with; not used for exists and !exists
+	Value string `json:"value,omitempty"`
+}
+
+func (f FieldFilter) Filterer() (*FieldFilterer, error) {
+	return NewFieldFilterer(f.Format, f.Path, f.Op, f.Value)
+}
+
+// FieldFilterer parses the message of a line as json or logfmt and compares a field of it with a value.
+// Numbers are compared numerically if both the field and the value are numbers.
+// Lines that are not json objects are filtered out for json.
+type FieldFilterer struct {
+	format   string
+	path     string
+	op       string
+	value    string
+	number   float64
+	isNumber bool
+	compiled *regexp.Regexp
+}
+
+func NewFieldFilterer(format, path, op, value string) (*FieldFilterer, error) {
+	if format != FormatJson && format != FormatLogfmt {
+		return nil, fmt.Errorf("unsupported format %q", format)
+	}
+
+	if len(path) == 0 {
+		return nil, errors.New("empty field path")
+	}
+
+	f := &FieldFilterer{format: format, path: path, op: op, value: value}
+	number, err := strconv.ParseFloat(value, 64)
+	f.number, f.isNumber = number, err == nil
+
+	switch op {
+	case OpEqual, OpNotEqual, OpExists, OpNotExists:
+	case OpMatch, OpNotMatch:
+		if v, ok := compiledRegexpCache.Get(value); ok {
+			f.compiled = v.(*regexp.Regexp)
+			break
+		}
+		compiled, err := regexp.Compile(value)
+		if err != nil {
+			return nil, err
+		}
+		compiledRegexpCache.Add(value, compiled)
+		f.compiled = compiled
+	case OpGreater, OpGreaterEqual, OpLess, OpLessEqual:
+		if !f.isNumber {
+			return nil, fmt.Errorf("%s requires a number but %q", op, value)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported operator %q", op)
+	}
+
+	return f, nil
+}
+
+func (f *FieldFilterer) Filter(input string, _ time.Time) (Result, error) {
+	field, exists, ok := lookupField(f.format, messageOf(input), f.path)
+	if !ok {
+		return Filtered, nil
+	}
+
+	if f.isMatched(field, exists) {
+		return Read, nil
+	}
+
+	return Filtered, nil
+}
+
+func (f *FieldFilterer) isMatched(field string, exists bool) bool {
+	switch f.op {
+	case OpExists:
+		return exists
+	case OpNotExists:
+		return !exists
+	}
+
+	if !exists {
+		return f.op == OpNotEqual || f.op == OpNotMatch
+	}
+
+	number, err := strconv.ParseFloat(field, 64)
+	isNumber := err == nil && f.isNumber
+
+	switch f.op {
+	case OpEqual:
+		if isNumber {
+			return number == f.number
+		}
+		return field == f.value
+	case OpNotEqual:
+		if isNumber {
+			return number != f.number
+		}
+		return field != f.value
+	case OpMatch:
+		return f.compiled.MatchString(field)
+	case OpNotMatch:
+		return !f.compiled.MatchString(field)
+	case OpGreater:
+		return isNumber && number > f.number
+	case OpGreaterEqual:
+		return isNumber && number >= f.number
+	case OpLess:
+		return isNumber && number < f.number
+	case OpLessEqual:
+		return isNumber && number <= f.number
+	}
+
+	return false
+}
+
+// messageOf returns the message part if input is a whole stored line like lines of log files.
+func messageOf(input string) string {
+	msg, _ := logline.CutStoredPrefix(input)
+	return msg
+}
+
+// lookupField returns the field and whether it exists; ok is false if input can't be parsed.
+func lookupField(format, input, path string) (field string, exists bool, ok bool) {
+	if format == FormatLogfmt {
+		field, exists = parseLogfmtFields(input)[path]
+		return field, exists, true
+	}
+
+	var decoded interface{}
+
+	decoder := json.NewDecoder(strings.NewReader(input))
+	decoder.UseNumber()
+	if err := decoder.Decode(&decoded); err != nil {
+		return "", false, false
+	}
+
+	object, ok := decoded.(map[string]interface{})
+	if !ok {
+		return "", false, false
+	}
+
+	// keys having dots like `http.status` are looked up before nested fields
+	if value, exists := object[path]; exists {
+		return stringify(value), true, true
+	}
+
+	value := decoded
+	for _, key := range strings.Split(path, ".") {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			if value, exists = v[key]; !exists {
+				return "", false, true
+			}
+		case []interface{}:
+			index, err := strconv.Atoi(key)
+			if err != nil || index < 0 || index >= len(v) {
+				return "", false, true
+			}
+			value = v[index]
+		default:
+			return "", false, true
+		}
+	}
+
+	return stringify(value), true, true
+}
+
+func stringify(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	case nil:
+		return ""
+	default:
+		b, _ := json.Marshal(v)
+		return string(b)
+	}
+}
+
+// parseLogfmtFields parses `key=value key="quoted value"` pairs; a key without value is set to "true".
+func parseLogfmtFields(input string) map[string]string {
+	var (
+		fields = map[string]string{}
+		data   = []byte(strings.TrimSpace(input))
+	)
+
+	for len(data) > 0 {
+		data = bytes.TrimLeft(data, " \t")
+
+		end := bytes.IndexAny(data, "= \t")
+		if end < 0 {
+			end = len(data)
+		}
+
+		key := string(data[:end])
+		data = data[end:]
+
+		if len(data) == 0 || data[0] != '=' {
+			if len(key) > 0 {
+				fields[key] = "true"
+			}
+			continue
+		}
+		data = data[1:]
+
+		var value string
+		if len(data) > 0 && data[0] == '"' {
+			value, data = parseLogfmtQuoted(data)
+		} else {
+			end := bytes.IndexAny(data, " \t")
+			if end < 0 {
+				end = len(data)
+			}
+			value, data = string(data[:end]), data[end:]
+		}
+
+		if len(key) > 0 {
+			fields[key] = value
+		}
+	}
+
+	return fields
+}
+
+func parseLogfmtQuoted(data []byte) (string, []byte) {
+	for i := 1; i < len(data); i++ {
+		switch data[i] {
+		case '\\':
+			i++
+		case '"':
+			if value, err := strconv.Unquote(string(data[:i+1])); err == nil {
+				return value, data[i+1:]
+			}
+			return string(data[1:i]), data[i+1:]
+		}
+	}
+
+	return string(data[1:]), nil
+}
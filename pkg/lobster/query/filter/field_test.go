@@ -0,0 +1,125 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package filter
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/naver/lobster/pkg/lobster/logline"
+)
+
+func TestMain(m *testing.M) {
+	logline.Setup()
+	os.Exit(m.Run())
+}
+
+func TestFieldFiltererLogfmt(t *testing.T) {
+	tests := []struct {
+		key      string
+		op       string
+		value    string
+		expected Result
+	}{
+		{"level", OpEqual, "error", Read},
+		{"level", OpNotEqual, "error", Filtered},
+		{"msg", OpMatch, "^connection .+", Read},
+		{"latency", OpGreater, "1.5", Read},
+		{"latency", OpLessEqual, "1.5", Filtered},
+		{"retry", OpEqual, "true", Read},
+		{"missing", OpGreaterEqual, "0", Filtered},
+	}
+
+	input := `level=error msg="connection \"db\" refused" latency=2.25 retry`
+
+	for _, test := range tests {
+		f, err := NewFieldFilterer(FormatLogfmt, test.key, test.op, test.value)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		result, err := f.Filter(input, time.Now())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result != test.expected {
+			t.Fatalf("%s %s %s: expected %d but %d", test.key, test.op, test.value, test.expected, result)
+		}
+	}
+}
+
+func TestFieldFiltererJson(t *testing.T) {
+	tests := []struct {
+		path     string
+		op       string
+		value    string
+		expected Result
+	}{
+		{"level", OpEqual, "error", Read},
+		{"http.status", OpGreaterEqual, "500", Read},
+		{"http.status", OpEqual, "503.0", Read},
+		{"http.headers.0.name", OpEqual, "x-request-id", Read},
+		{"trace.id", OpEqual, "abc", Read},
+		{"http.status", OpLess, "500", Filtered},
+		{"user", OpExists, "", Filtered},
+		{"user", OpNotExists, "", Read},
+		{"user", OpNotEqual, "admin", Read},
+		{"http.headers.1.name", OpExists, "", Filtered},
+	}
+
+	msg := `{"level":"error","trace.id":"abc","http":{"status":503,"headers":[{"name":"x-request-id"}]}}`
+
+	for _, input := range []string{msg, time.Now().Format(time.RFC3339Nano) + " stdout F " + msg} {
+		for _, test := range tests {
+			f, err := NewFieldFilterer(FormatJson, test.path, test.op, test.value)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			result, err := f.Filter(input, time.Now())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result != test.expected {
+				t.Fatalf("%s %s %s: expected %d but %d for %s", test.path, test.op, test.value, test.expected, result, input)
+			}
+		}
+	}
+
+	f, _ := NewFieldFilterer(FormatJson, "level", OpNotExists, "")
+	if result, _ := f.Filter("not a json", time.Now()); result != Filtered {
+		t.Fatal("lines that are not json should be filtered")
+	}
+}
+
+func TestMessageOf(t *testing.T) {
+	tests := map[string]string{
+		`2024-05-13T14:57:24.123+09:00 stdout F level=error`: `level=error`,
+		`2024-05-13T14:57:24Z {"level":"error"}`:             `{"level":"error"}`,
+		`2024-05-13T14:57:24Z Processing request level=info`: `Processing request level=info`,
+		`2024-05-13 14:57:24 Processing request level=info`:  `2024-05-13 14:57:24 Processing request level=info`,
+		`1715579844 Processing request level=info`:           `1715579844 Processing request level=info`,
+		`level=error msg="Failed to process" latency=2.25`:   `level=error msg="Failed to process" latency=2.25`,
+	}
+
+	for input, expected := range tests {
+		if msg := messageOf(input); msg != expected {
+			t.Errorf("%s: expected %s but got %s", input, expected, msg)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package filter
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRequiredLiterals(t *testing.T) {
+	testCases := map[string][]string{
+		"4bf92f3577b34da6":      {"4bf92f3577b34da6"},
+		"status=(500|502) path": {"status=", "50", " path"},
+		"error.*timeout":        {"error", "timeout"},
+		"(?i)error":             nil,
+		"error|warn":            nil,
+		"[":                     nil,
+	}
+
+	for expr, expected := range testCases {
+		literals := RequiredLiterals(expr)
+		if len(literals) == 0 && len(expected) == 0 {
+			continue
+		}
+		if !reflect.DeepEqual(literals, expected) {
+			t.Fatalf("literals of %s should be %v but %v", expr, expected, literals)
+		}
+	}
+}
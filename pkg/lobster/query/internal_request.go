@@ -45,6 +45,7 @@ func ParseRequestWithUri(uri string) (Request, error) {
 	pNamespaces := u.Query().Get("namespaces")
 	pLabels := u.Query().Get("labels")
 	pSetNames := u.Query().Get("setNames")
+	pWorkloads := u.Query().Get("workloads")
 	pPods := u.Query().Get("pods")
 	pContainers := u.Query().Get("containers")
 	pSources := u.Query().Get("sources")
@@ -58,8 +59,14 @@ func ParseRequestWithUri(uri string) (Request, error) {
 	pBurst := u.Query().Get("burst")
 	pInclude := u.Query().Get("include")
 	pExclude := u.Query().Get("exclude")
+	pQuery := u.Query().Get("query")
+	pContext := u.Query().Get("context")
+	pBefore := u.Query().Get("before")
+	pAfter := u.Query().Get("after")
+	pDirection := u.Query().Get("direction")
 
 	req.Namespace = pNamespace
+	req.Direction = pDirection
 	req.SetName = pSetName
 	req.Pod = pPod
 	req.Container = pContainer
@@ -88,6 +95,15 @@ func ParseRequestWithUri(uri string) (Request, error) {
 	if len(pSetNames) > 0 {
 		req.SetNames = strings.Split(pSetNames, OrDelimiter)
 	}
+	if len(pWorkloads) > 0 {
+		for _, str := range strings.Split(pWorkloads, OrDelimiter) {
+			workload, err := model.ParseWorkload(str)
+			if err != nil {
+				return req, err
+			}
+			req.Workloads = append(req.Workloads, workload)
+		}
+	}
 	if len(pPods) > 0 {
 		req.Pods = strings.Split(pPods, OrDelimiter)
 	}
@@ -152,6 +168,42 @@ func ParseRequestWithUri(uri string) (Request, error) {
 		req.FilterExcludeExpr = expr
 	}
 
+	if len(pQuery) != 0 {
+		expr, err := url.QueryUnescape(pQuery)
+		if err != nil {
+			return req, errors.New("invalid parameter value `query`")
+		}
+		req.Query = expr
+	}
+
+	if len(pContext) != 0 {
+		context, err := strconv.Atoi(pContext)
+		if err != nil {
+			return req, errors.New("invalid parameter value `context`")
+		}
+
+		req.Before = context
+		req.After = context
+	}
+
+	if len(pBefore) != 0 {
+		before, err := strconv.Atoi(pBefore)
+		if err != nil {
+			return req, errors.New("invalid parameter value `before`")
+		}
+
+		req.Before = before
+	}
+
+	if len(pAfter) != 0 {
+		after, err := strconv.Atoi(pAfter)
+		if err != nil {
+			return req, errors.New("invalid parameter value `after`")
+		}
+
+		req.After = after
+	}
+
 	if len(pPage) != 0 {
 		page, err := strconv.Atoi(pPage)
 		if err != nil {
@@ -0,0 +1,136 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package logql
+
+import (
+	"strconv"
+	"strings"
+)
+
+type tokenType int
+
+const (
+	tokenEOF tokenType = iota
+	tokenIdent
+	tokenString
+	tokenNumber
+	tokenLeftBrace
+	tokenRightBrace
+	tokenComma
+	tokenPipe
+	tokenOp
+)
+
+type token struct {
+	typ   tokenType
+	value string
+	pos   int
+}
+
+var operators = []string{"|=", "|~", "!=", "!~", "=~", "==", ">=", "<=", "=", ">", "<"}
+
+type lexer struct {
+	input string
+	pos   int
+}
+
+func (l *lexer) next() (token, error) {
+	for l.pos < len(l.input) && strings.ContainsRune(" \t\r\n", rune(l.input[l.pos])) {
+		l.pos++
+	}
+
+	start := l.pos
+	if start >= len(l.input) {
+		return token{tokenEOF, "", start}, nil
+	}
+
+	c := l.input[start]
+	switch {
+	case c == '{':
+		l.pos++
+		return token{tokenLeftBrace, "{", start}, nil
+	case c == '}':
+		l.pos++
+		return token{tokenRightBrace, "}", start}, nil
+	case c == ',':
+		l.pos++
+		return token{tokenComma, ",", start}, nil
+	case c == '"' || c == '`':
+		return l.lexString()
+	case isDigit(c) || (c == '-' && start+1 < len(l.input) && isDigit(l.input[start+1])):
+		l.pos++
+		for l.pos < len(l.input) && (isDigit(l.input[l.pos]) || l.input[l.pos] == '.') {
+			l.pos++
+		}
+		return token{tokenNumber, l.input[start:l.pos], start}, nil
+	case isIdentStart(c):
+		for l.pos < len(l.input) && isIdentPart(l.input[l.pos]) {
+			l.pos++
+		}
+		return token{tokenIdent, l.input[start:l.pos], start}, nil
+	}
+
+	for _, op := range operators {
+		if strings.HasPrefix(l.input[start:], op) {
+			l.pos = l.pos + len(op)
+			return token{tokenOp, op, start}, nil
+		}
+	}
+
+	if c == '|' {
+		l.pos++
+		return token{tokenPipe, "|", start}, nil
+	}
+
+	return token{}, newParseError(start, "unexpected character %q", c)
+}
+
+func (l *lexer) lexString() (token, error) {
+	start := l.pos
+	quote := l.input[start]
+
+	for i := start + 1; i < len(l.input); i++ {
+		switch l.input[i] {
+		case '\\':
+			if quote == '"' {
+				i++
+			}
+		case quote:
+			l.pos = i + 1
+			value, err := strconv.Unquote(l.input[start:l.pos])
+			if err != nil {
+				return token{}, newParseError(start, "invalid string %s", l.input[start:l.pos])
+			}
+			return token{tokenString, value, start}, nil
+		}
+	}
+
+	return token{}, newParseError(start, "unterminated string")
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
+
+// Label names of kubernetes may have dots, slashes and dashes like `app.kubernetes.io/name`
+func isIdentPart(c byte) bool {
+	return isIdentStart(c) || isDigit(c) || c == '.' || c == '/' || c == '-'
+}
@@ -0,0 +1,336 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package logql parses a LogQL-style query like
+// `{namespace="pay", app="api"} |= "ERROR" != "healthz" | json | status >= 500`.
+package logql
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/naver/lobster/pkg/lobster/query/filter"
+)
+
+const (
+	StageLineFilter  = "line"
+	StageParser      = "parser"
+	StageFieldFilter = "field"
+)
+
+type ParseError struct {
+	// Zero-based byte offset in the query
+	Pos     int
+	Message string
+}
+
+func newParseError(pos int, format string, args ...interface{}) *ParseError {
+	return &ParseError{pos, fmt.Sprintf(format, args...)}
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("parse error at position %d: %s", e.Pos+1, e.Message)
+}
+
+type Query struct {
+	Matchers []Matcher
+	Stages   []Stage
+}
+
+// Matcher selects chunks whose Name is one of Values.
+type Matcher struct {
+	Name   string
+	Values []string
+	Pos    int
+}
+
+type Stage struct {
+	Type   string
+	Op     string
+	Format string
+	Field  string
+	Value  string
+	Pos    int
+}
+
+type parser struct {
+	lexer lexer
+	token token
+}
+
+func Parse(input string) (Query, error) {
+	p := &parser{lexer: lexer{input: input}}
+	if err := p.advance(); err != nil {
+		return Query{}, err
+	}
+
+	q := Query{}
+
+	if p.token.typ == tokenLeftBrace {
+		matchers, err := p.parseSelector()
+		if err != nil {
+			return q, err
+		}
+		q.Matchers = matchers
+	}
+
+	stages, err := p.parsePipeline()
+	if err != nil {
+		return q, err
+	}
+	q.Stages = stages
+
+	return q, nil
+}
+
+func (p *parser) advance() (err error) {
+	p.token, err = p.lexer.next()
+	return
+}
+
+func (p *parser) expect(typ tokenType, description string) (token, error) {
+	t := p.token
+	if t.typ != typ {
+		return t, p.unexpected(description)
+	}
+	return t, p.advance()
+}
+
+func (p *parser) unexpected(description string) error {
+	if p.token.typ == tokenEOF {
+		return newParseError(p.token.pos, "unexpected end of query, expected %s", description)
+	}
+	return newParseError(p.token.pos, "unexpected %q, expected %s", p.token.value, description)
+}
+
+func (p *parser) parseSelector() ([]Matcher, error) {
+	var (
+		matchers = []Matcher{}
+		names    = map[string]bool{}
+	)
+
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	for p.token.typ != tokenRightBrace {
+		if len(matchers) > 0 {
+			if _, err := p.expect(tokenComma, "',' or '}'"); err != nil {
+				return nil, err
+			}
+		}
+
+		matcher, err := p.parseMatcher()
+		if err != nil {
+			return nil, err
+		}
+
+		if names[matcher.Name] {
+			return nil, newParseError(matcher.Pos, "duplicated matcher for %q", matcher.Name)
+		}
+		names[matcher.Name] = true
+
+		matchers = append(matchers, matcher)
+	}
+
+	return matchers, p.advance()
+}
+
+func (p *parser) parseMatcher() (Matcher, error) {
+	name, err := p.expect(tokenIdent, "label name")
+	if err != nil {
+		return Matcher{}, err
+	}
+
+	op := p.token
+	if op.typ != tokenOp || (op.value != "=" && op.value != "=~" && op.value != "!=" && op.value != "!~") {
+		return Matcher{}, p.unexpected("'=' or '=~'")
+	}
+	if err := p.advance(); err != nil {
+		return Matcher{}, err
+	}
+
+	value, err := p.expect(tokenString, "string")
+	if err != nil {
+		return Matcher{}, err
+	}
+
+	matcher := Matcher{Name: name.value, Pos: name.pos}
+
+	switch op.value {
+	case "=":
+		matcher.Values = []string{value.value}
+	case "=~":
+		// chunks are looked up by exact names, so only alternatives of names are allowed
+		for _, alternative := range strings.Split(value.value, "|") {
+			if len(alternative) == 0 || regexp.QuoteMeta(alternative) != alternative {
+				return Matcher{}, newParseError(value.pos, "only alternatives of names like \"a|b\" are supported for '=~'")
+			}
+			matcher.Values = append(matcher.Values, alternative)
+		}
+	default:
+		return Matcher{}, newParseError(op.pos, "negative matcher %q is not supported in selectors", op.value)
+	}
+
+	return matcher, nil
+}
+
+func (p *parser) parsePipeline() ([]Stage, error) {
+	var (
+		stages = []Stage{}
+		format = ""
+	)
+
+	for p.token.typ != tokenEOF {
+		t := p.token
+
+		switch {
+		case t.typ == tokenOp && (t.value == "|=" || t.value == "|~" || t.value == "!=" || t.value == "!~"):
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+
+			value, err := p.expect(tokenString, "string")
+			if err != nil {
+				return nil, err
+			}
+
+			stages = append(stages, Stage{Type: StageLineFilter, Op: t.value, Value: value.value, Pos: t.pos})
+		case t.typ == tokenPipe:
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+
+			name, err := p.expect(tokenIdent, "parser or field name")
+			if err != nil {
+				return nil, err
+			}
+
+			if name.value == filter.FormatJson || name.value == filter.FormatLogfmt {
+				format = name.value
+				stages = append(stages, Stage{Type: StageParser, Format: format, Pos: name.pos})
+				continue
+			}
+
+			if len(format) == 0 {
+				return nil, newParseError(name.pos, "field filter on %q requires '| json' or '| logfmt' before it", name.value)
+			}
+
+			stage, err := p.parseFieldFilter(format, name)
+			if err != nil {
+				return nil, err
+			}
+			stages = append(stages, stage)
+		default:
+			return nil, p.unexpected("'|=', '!=', '|~', '!~' or '|'")
+		}
+	}
+
+	return stages, nil
+}
+
+func (p *parser) parseFieldFilter(format string, name token) (Stage, error) {
+	op := p.token
+	if op.typ != tokenOp || op.value == "|=" || op.value == "|~" {
+		return Stage{}, p.unexpected("comparison operator")
+	}
+	if err := p.advance(); err != nil {
+		return Stage{}, err
+	}
+
+	value := p.token
+	if value.typ != tokenString && value.typ != tokenNumber {
+		return Stage{}, p.unexpected("string or number")
+	}
+	if err := p.advance(); err != nil {
+		return Stage{}, err
+	}
+
+	stage := Stage{Type: StageFieldFilter, Op: op.value, Format: format, Field: name.value, Value: value.value, Pos: name.pos}
+	if stage.Op == "==" {
+		stage.Op = filter.OpEqual
+	}
+
+	if _, err := filter.NewFieldFilterer(stage.Format, stage.Field, stage.Op, stage.Value); err != nil {
+		return Stage{}, newParseError(value.pos, "%s", err.Error())
+	}
+
+	return stage, nil
+}
+
+// Filterers returns filterers for stages of the pipeline in order.
+func (q Query) Filterers() ([]filter.Filterer, error) {
+	filterers := []filter.Filterer{}
+
+	for _, stage := range q.Stages {
+		var (
+			f   filter.Filterer
+			err error
+		)
+
+		switch stage.Type {
+		case StageLineFilter:
+			f, err = newLineFilterer(stage.Op, stage.Value)
+		case StageFieldFilter:
+			f, err = filter.NewFieldFilterer(stage.Format, stage.Field, stage.Op, stage.Value)
+		default:
+			continue
+		}
+
+		if err != nil {
+			return nil, newParseError(stage.Pos, "%s", err.Error())
+		}
+
+		filterers = append(filterers, f)
+	}
+
+	return filterers, nil
+}
+
+func newLineFilterer(op, value string) (filter.Filterer, error) {
+	switch op {
+	case "|=":
+		return filter.NewRegexpFilterer(regexp.QuoteMeta(value))
+	case "!=":
+		return filter.NewNegativeRegexpFilterer(regexp.QuoteMeta(value))
+	case "|~":
+		return filter.NewRegexpFilterer(value)
+	case "!~":
+		return filter.NewNegativeRegexpFilterer(value)
+	}
+	return nil, fmt.Errorf("unsupported line filter %q", op)
+}
+
+// Literals returns literals that lines matched by line filters must contain.
+func (q Query) Literals() []string {
+	literals := []string{}
+
+	for _, stage := range q.Stages {
+		if stage.Type != StageLineFilter {
+			continue
+		}
+
+		switch stage.Op {
+		case "|=":
+			literals = append(literals, stage.Value)
+		case "|~":
+			literals = append(literals, filter.RequiredLiterals(stage.Value)...)
+		}
+	}
+
+	return literals
+}
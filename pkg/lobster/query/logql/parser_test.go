@@ -0,0 +1,101 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package logql
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/naver/lobster/pkg/lobster/logline"
+	"github.com/naver/lobster/pkg/lobster/query/filter"
+)
+
+func TestMain(m *testing.M) {
+	logline.Setup()
+	os.Exit(m.Run())
+}
+
+func TestParse(t *testing.T) {
+	q, err := Parse(`{namespace="pay", app=~"api|web"} |= "ERROR" != "healthz" | json | status >= 500`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedMatchers := []Matcher{
+		{Name: "namespace", Values: []string{"pay"}, Pos: 1},
+		{Name: "app", Values: []string{"api", "web"}, Pos: 18},
+	}
+	if !reflect.DeepEqual(q.Matchers, expectedMatchers) {
+		t.Fatalf("expected %v but %v", expectedMatchers, q.Matchers)
+	}
+
+	if len(q.Stages) != 4 {
+		t.Fatalf("expected 4 stages but %v", q.Stages)
+	}
+	if !reflect.DeepEqual(q.Literals(), []string{"ERROR"}) {
+		t.Fatalf("unexpected literals %v", q.Literals())
+	}
+
+	filterers, err := q.Filterers()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]filter.Result{
+		`{"level":"ERROR","status":503}`:    filter.Read,
+		`{"level":"ERROR","status":404}`:    filter.Filtered,
+		`{"level":"INFO","status":503}`:     filter.Filtered,
+		`ERROR GET /healthz {"status":503}`: filter.Filtered,
+		`ERROR not a json`:                  filter.Filtered,
+	}
+
+	for input, expected := range tests {
+		result, err := filter.DoFilter(input, time.Now(), filterers...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result != expected {
+			t.Fatalf("%s: expected %d but %d", input, expected, result)
+		}
+	}
+}
+
+func TestParseError(t *testing.T) {
+	tests := map[string]int{
+		`{namespace="pay"`:                     17,
+		`{namespace!="pay"}`:                   11,
+		`{namespace="pay"} | status >= 500`:    21,
+		`{namespace="pay"} | json | level ~ 1`: 34,
+		`{pod=~"api-.*"}`:                      7,
+		`{namespace="pay"} |= "unterminated`:   22,
+	}
+
+	for input, expected := range tests {
+		_, err := Parse(input)
+
+		parseErr := &ParseError{}
+		if !errors.As(err, &parseErr) {
+			t.Fatalf("%s: expected parse error but %v", input, err)
+		}
+		if parseErr.Pos+1 != expected {
+			t.Fatalf("%s: expected error at %d but %s", input, expected, parseErr.Error())
+		}
+	}
+}
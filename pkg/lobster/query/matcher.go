@@ -14,11 +14,10 @@
  * limitations under the License.
  */
 
-package querier
+package query
 
 import (
 	"github.com/naver/lobster/pkg/lobster/model"
-	"github.com/naver/lobster/pkg/lobster/query"
 )
 
 type keyFunc func(model.Chunk) interface{}
@@ -28,7 +27,7 @@ type chunkMatcher struct {
 	predicates []predicate
 }
 
-func NewChunkMatcher(req query.Request) chunkMatcher {
+func NewChunkMatcher(req Request) chunkMatcher {
 	predicates := []predicate{}
 
 	if matchers := nameMatchers(req); len(matchers) > 0 {
@@ -41,7 +40,7 @@ func NewChunkMatcher(req query.Request) chunkMatcher {
 	return chunkMatcher{predicates}
 }
 
-func labelMatchers(req query.Request) []matcher {
+func labelMatchers(req Request) []matcher {
 	matchers := []matcher{}
 
 	for _, label := range req.Labels {
@@ -55,7 +54,7 @@ func labelMatchers(req query.Request) []matcher {
 	return matchers
 }
 
-func nameMatchers(req query.Request) []matcher {
+func nameMatchers(req Request) []matcher {
 	matchers := []matcher{}
 
 	if len(req.Clusters) > 0 {
@@ -64,6 +63,13 @@ func nameMatchers(req query.Request) []matcher {
 	if len(req.SetNames) > 0 {
 		matchers = append(matchers, newMatcher(req.SetNames, seekByKeyString, func(c model.Chunk) interface{} { return c.SetName }))
 	}
+	if len(req.Workloads) > 0 {
+		workloads := []string{}
+		for _, workload := range req.Workloads {
+			workloads = append(workloads, workload.String())
+		}
+		matchers = append(matchers, newMatcher(workloads, seekByAnyKey, func(c model.Chunk) interface{} { return c.Workloads.KeyMap() }))
+	}
 	if len(req.Pods) > 0 {
 		matchers = append(matchers, newMatcher(req.Pods, seekByKeyString, func(c model.Chunk) interface{} { return c.Pod }))
 	}
@@ -119,6 +125,19 @@ func seekByKeyString(requestedData map[string]bool, key interface{}) bool {
 	return requestedData[key.(string)]
 }
 
+// seekByAnyKey returns true if any of requested keys is in the key map.
+func seekByAnyKey(requestedData map[string]bool, keyMap interface{}) bool {
+	converted := keyMap.(map[string]bool)
+
+	for key := range requestedData {
+		if converted[key] {
+			return true
+		}
+	}
+
+	return false
+}
+
 func seekByKeyValuePairMap(requestedData map[string]bool, keyValuesPairMap interface{}) bool {
 	matchedCnt := 0
 	converted := keyValuesPairMap.(map[string]bool)
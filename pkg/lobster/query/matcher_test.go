@@ -14,7 +14,7 @@
  * limitations under the License.
  */
 
-package querier
+package query
 
 import (
 	"fmt"
@@ -23,7 +23,6 @@ import (
 	"testing"
 
 	"github.com/naver/lobster/pkg/lobster/model"
-	"github.com/naver/lobster/pkg/lobster/query"
 )
 
 var (
@@ -50,7 +49,7 @@ func init() {
 }
 
 func TestEntireChunks(t *testing.T) {
-	matcher := NewChunkMatcher(query.Request{})
+	matcher := NewChunkMatcher(Request{})
 
 	matchedChunks := []model.Chunk{}
 	for _, chunk := range chunks {
@@ -67,7 +66,7 @@ func TestEntireChunks(t *testing.T) {
 func TestMatchSingleCluster(t *testing.T) {
 	var expectedClusters = []string{"cluster-a"}
 
-	matcher := NewChunkMatcher(query.Request{
+	matcher := NewChunkMatcher(Request{
 		Clusters: expectedClusters,
 	})
 
@@ -89,7 +88,7 @@ func TestMatchSingleCluster(t *testing.T) {
 func TestMatchMultipleClusters(t *testing.T) {
 	var expectedClusters = []string{"cluster-a", "cluster-b"}
 
-	matcher := NewChunkMatcher(query.Request{
+	matcher := NewChunkMatcher(Request{
 		Clusters: expectedClusters,
 	})
 
@@ -119,7 +118,7 @@ func TestMatchSingleLabel(t *testing.T) {
 		expectedLabelStrings = append(expectedLabelStrings, label.String())
 	}
 
-	matcher := NewChunkMatcher(query.Request{
+	matcher := NewChunkMatcher(Request{
 		Clusters: expectedClusters,
 		Labels:   expectedLabels,
 	})
@@ -164,7 +163,7 @@ func TestMatchMultipleLabels(t *testing.T) {
 		expectedLabelStrings = append(expectedLabelStrings, label.String())
 	}
 
-	matcher := NewChunkMatcher(query.Request{
+	matcher := NewChunkMatcher(Request{
 		Clusters: expectedClusters,
 		Labels:   expectedLabels,
 	})
@@ -197,3 +196,34 @@ func TestMatchMultipleLabels(t *testing.T) {
 		}
 	}
 }
+
+func TestMatchWorkloads(t *testing.T) {
+	owned := []model.Chunk{
+		{Id: "a", Namespace: "pay", Workloads: model.Workloads{{Kind: "ReplicaSet", Name: "api-1"}, {Kind: "Deployment", Name: "api"}}},
+		{Id: "b", Namespace: "pay", Workloads: model.Workloads{{Kind: "ReplicaSet", Name: "api-2"}, {Kind: "Deployment", Name: "api"}}},
+		{Id: "c", Namespace: "pay", Workloads: model.Workloads{{Kind: "Job", Name: "batch-1"}, {Kind: "CronJob", Name: "batch"}}},
+		{Id: "d", Namespace: "pay"},
+	}
+
+	testData := map[string][]model.Workload{
+		"ab":  {{Kind: "Deployment", Name: "api"}},
+		"b":   {{Kind: "ReplicaSet", Name: "api-2"}},
+		"abc": {{Kind: "Deployment", Name: "api"}, {Kind: "CronJob", Name: "batch"}},
+		"":    {{Kind: "DaemonSet", Name: "api"}},
+	}
+
+	for expected, workloads := range testData {
+		matcher := NewChunkMatcher(Request{Workloads: workloads})
+
+		matched := ""
+		for _, chunk := range owned {
+			if matcher.IsRequestedChunk(chunk) {
+				matched = matched + chunk.Id
+			}
+		}
+
+		if matched != expected {
+			t.Errorf("%v: expected %q but %q", workloads, expected, matched)
+		}
+	}
+}
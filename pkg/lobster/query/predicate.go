@@ -14,7 +14,7 @@
  * limitations under the License.
  */
 
-package querier
+package query
 
 import (
 	"github.com/naver/lobster/pkg/lobster/model"
@@ -17,15 +17,23 @@
 package query
 
 import (
+	"context"
 	"time"
 
 	"github.com/naver/lobster/pkg/lobster/model"
 )
 
 type Queryable interface {
-	GetChunksWithinRange(Request) ([]model.Chunk, error)
-	GetSeriesInBlocksWithinRange(Request) (int, model.SeriesData, error)
-	GetBlocksWithinRange(Request) ([]byte, time.Time, time.Time, int, model.PageInfo, error)
-	GetEntriesWithinRange(Request) ([]model.Entry, int, model.PageInfo, error)
+	// methods stop reading and fetching logs once ctx is done
+	GetChunksWithinRange(context.Context, Request) ([]model.Chunk, error)
+	GetSeriesInBlocksWithinRange(context.Context, Request) (int, model.SeriesData, error)
+	GetBlocksWithinRange(context.Context, Request) ([]byte, time.Time, time.Time, int, model.PageInfo, error)
+	GetEntriesWithinRange(context.Context, Request) ([]model.Entry, int, model.PageInfo, error)
+	GetAggregationWithinRange(context.Context, Request) (int, model.AggregationData, error)
 	Validate(Request) error
 }
+
+type Tailable interface {
+	// Tail sends log entries matched with the request as they are written until ctx is done.
+	Tail(context.Context, Request, func(model.Entry) error) error
+}
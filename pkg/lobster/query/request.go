@@ -19,15 +19,25 @@ package query
 import (
 	"encoding/json"
 	"errors"
+	"maps"
+	"slices"
 	"strings"
 
 	"github.com/naver/lobster/pkg/lobster/loader"
 	"github.com/naver/lobster/pkg/lobster/model"
 	"github.com/naver/lobster/pkg/lobster/query/filter"
+	"github.com/naver/lobster/pkg/lobster/query/logql"
 	"github.com/naver/lobster/pkg/lobster/util"
 	sinkV1 "github.com/naver/lobster/pkg/operator/api/v1"
 )
 
+const (
+	MaxContextLines = 100
+
+	DirectionForward  = "forward"
+	DirectionBackward = "backward"
+)
+
 type Request struct {
 	// Use internally
 	ID string `json:"id,omitempty"`
@@ -39,6 +49,8 @@ type Request struct {
 	Labels []model.Labels `json:"labels,omitempty"`
 	// Get chunks belongs to namespace and set names(replicaset/statefulset)
 	SetNames []string `json:"setNames,omitempty"`
+	// Get chunks belongs to namespace and workloads owning pods(e.g. Deployment, CronJob, DaemonSet)
+	Workloads []model.Workload `json:"workloads,omitempty"`
 	// Get chunks belongs to namespace and pods
 	Pods []string `json:"pods,omitempty"`
 	// Get chunks belongs to namespace and containers
@@ -58,6 +70,8 @@ type Request struct {
 	Container string `json:"container,omitempty"`
 	// Use internally
 	Source model.Source `json:"source,omitempty"`
+	// Use internally
+	Cold bool `json:"cold,omitempty"`
 
 	// Start time for query
 	Start util.Timestamp `json:"start,omitempty" swaggertype:"string"`
@@ -67,6 +81,20 @@ type Request struct {
 	Page int `json:"page,omitempty"`
 	// The number of logs that can be returned in one page and this can be greater or less than burst
 	Burst int `json:"burst,omitempty"`
+	// Continuation cursor from `pageInfo.cursor` of the previous response; page is ignored if it is set
+	Cursor string `json:"cursor,omitempty"`
+	// Order of returned logs; forward(oldest first, default) or backward(newest first) and pages are numbered in the same order
+	Direction string `json:"direction,omitempty"`
+	// LogQL-style query to select chunks and filter logs; e.g. `{namespace="pay", app="api"} |= "ERROR" | json | status >= 500`
+	Query string `json:"query,omitempty"`
+	// Conditions on fields of json or logfmt messages, which are all satisfied by returned logs
+	FieldFilters []filter.FieldFilter `json:"fields,omitempty"`
+	// Aggregate logs instead of returning them; used for aggregation requests
+	Aggregation *Aggregation `json:"aggregation,omitempty"`
+	// The number of lines returned before each matched log as context; up to 100
+	Before int `json:"before,omitempty"`
+	// The number of lines returned after each matched log as context; up to 100
+	After int `json:"after,omitempty"`
 	// Regular expression to search logs
 	FilterIncludeExpr string            `json:"include,omitempty"`
 	FilterExcludeExpr string            `json:"exclude,omitempty"`
@@ -91,6 +119,27 @@ func ParseRequestWithBody(body []byte) (Request, error) {
 	return req, req.Init()
 }
 
+// ParseTailRequestWithBody parses a request for live logs which has no time range.
+func ParseTailRequestWithBody(body []byte) (Request, error) {
+	req := newRequest()
+
+	if err := json.Unmarshal(body, &req); err != nil {
+		return req, err
+	}
+
+	req.Filterers = []filter.Filterer{}
+
+	if err := req.InitQuery(); err != nil {
+		return req, err
+	}
+
+	if err := req.InitTextFilterer(); err != nil {
+		return req, err
+	}
+
+	return req, req.InitSource()
+}
+
 func newRequest() Request {
 	return Request{Filterers: []filter.Filterer{}}
 }
@@ -106,6 +155,11 @@ func NewRequestFromFilter(f sinkV1.Filter) Request {
 		sources = append(sources, model.Source{Type: source.Type, Path: source.Path})
 	}
 
+	fieldFilters := []filter.FieldFilter{}
+	for _, field := range f.Fields {
+		fieldFilters = append(fieldFilters, filter.FieldFilter{Format: field.Format, Path: field.Path, Op: field.Operator, Value: field.Value})
+	}
+
 	return Request{
 		Clusters:          f.Clusters,
 		Namespace:         f.Namespace,
@@ -116,11 +170,24 @@ func NewRequestFromFilter(f sinkV1.Filter) Request {
 		Containers:        f.Containers,
 		FilterIncludeExpr: f.FilterIncludeExpr,
 		FilterExcludeExpr: f.FilterExcludeExpr,
+		FieldFilters:      fieldFilters,
 		Filterers:         []filter.Filterer{},
 	}
 }
 
 func (r *Request) Init() error {
+	if err := r.InitQuery(); err != nil {
+		return err
+	}
+
+	if err := r.InitDirection(); err != nil {
+		return err
+	}
+
+	if err := r.InitCursor(); err != nil {
+		return err
+	}
+
 	if err := r.InitRangeFilterer(); err != nil {
 		return err
 	}
@@ -129,9 +196,46 @@ func (r *Request) Init() error {
 		return err
 	}
 
+	if err := r.InitContext(); err != nil {
+		return err
+	}
+
 	return r.InitSource()
 }
 
+func (r *Request) InitDirection() error {
+	switch r.Direction {
+	case "", DirectionForward, DirectionBackward:
+		return nil
+	default:
+		return errors.New("invalid parameter value `direction`")
+	}
+}
+
+// InitCursor moves the start of the range, or the end for backward, to the position of the cursor.
+func (r *Request) InitCursor() error {
+	if len(r.Cursor) == 0 {
+		return nil
+	}
+
+	cursor, err := ParseCursor(r.Cursor)
+	if err != nil {
+		return err
+	}
+
+	if cursor.Timestamp.Before(r.Start.Time) || cursor.Timestamp.After(r.End.Time) {
+		return errors.New("cursor is out of range")
+	}
+
+	if r.IsBackward() {
+		r.End = util.Timestamp{Time: cursor.Timestamp}
+	} else {
+		r.Start = util.Timestamp{Time: cursor.Timestamp}
+	}
+
+	return nil
+}
+
 func (r *Request) InitRangeFilterer() error {
 	if r.Start.Time.IsZero() || r.End.Time.IsZero() {
 		return errors.New("invalid parameter value `start` or `end`")
@@ -161,6 +265,23 @@ func (r *Request) InitTextFilterer() error {
 		r.Filterers = append(r.Filterers, filter)
 	}
 
+	for _, field := range r.FieldFilters {
+		filter, err := field.Filterer()
+		if err != nil {
+			return err
+		}
+
+		r.Filterers = append(r.Filterers, filter)
+	}
+
+	return nil
+}
+
+func (r *Request) InitContext() error {
+	if r.Before < 0 || r.After < 0 || r.Before > MaxContextLines || r.After > MaxContextLines {
+		return errors.New("invalid parameter value `before` or `after`")
+	}
+
 	return nil
 }
 
@@ -169,13 +290,120 @@ func (r *Request) InitSource() error {
 		r.Source.Type = model.LogTypeEmptyDirFile
 	}
 
+	if r.Container == loader.HostDescription {
+		r.Source.Type = model.LogTypeHostFile
+	}
+
+	if r.Container == loader.EventDescription {
+		r.Source.Type = model.LogTypeEvent
+	}
+
+	return nil
+}
+
+// InitQuery merges the selector of the query into request fields and appends filterers of its pipeline.
+// Merging is idempotent since the query is passed along with the merged fields to other components.
+func (r *Request) InitQuery() error {
+	if len(r.Query) == 0 {
+		return nil
+	}
+
+	q, err := logql.Parse(r.Query)
+	if err != nil {
+		return err
+	}
+
+	labels := []model.Labels{{}}
+
+	for _, matcher := range q.Matchers {
+		switch matcher.Name {
+		case "cluster":
+			r.Clusters = appendUnique(r.Clusters, matcher.Values...)
+		case "namespace":
+			r.Namespaces = appendUnique(r.Namespaces, matcher.Values...)
+		case "set_name":
+			r.SetNames = appendUnique(r.SetNames, matcher.Values...)
+		case "pod":
+			r.Pods = appendUnique(r.Pods, matcher.Values...)
+		case "container":
+			r.Containers = appendUnique(r.Containers, matcher.Values...)
+		default:
+			// alternatives of labels are expanded to combinations of them
+			expanded := []model.Labels{}
+			for _, l := range labels {
+				for _, value := range matcher.Values {
+					next := maps.Clone(l)
+					next[matcher.Name] = value
+					expanded = append(expanded, next)
+				}
+			}
+			labels = expanded
+		}
+	}
+
+	for _, l := range labels {
+		if len(l) == 0 || slices.ContainsFunc(r.Labels, func(e model.Labels) bool { return maps.Equal(e, l) }) {
+			continue
+		}
+		r.Labels = append(r.Labels, l)
+	}
+
+	filterers, err := q.Filterers()
+	if err != nil {
+		return err
+	}
+
+	r.Filterers = append(r.Filterers, filterers...)
+
 	return nil
 }
 
+func appendUnique(values []string, elems ...string) []string {
+	for _, elem := range elems {
+		if !slices.Contains(values, elem) {
+			values = append(values, elem)
+		}
+	}
+	return values
+}
+
+// IncludeLiterals returns literals that logs matched by the include expression and the query must contain.
+func (r Request) IncludeLiterals() []string {
+	literals := []string{}
+
+	if len(r.FilterIncludeExpr) > 0 {
+		literals = append(literals, filter.RequiredLiterals(r.FilterIncludeExpr)...)
+	}
+
+	if len(r.Query) > 0 {
+		if q, err := logql.Parse(r.Query); err == nil {
+			literals = append(literals, q.Literals()...)
+		}
+	}
+
+	return literals
+}
+
+func (r Request) IsBackward() bool {
+	return r.Direction == DirectionBackward
+}
+
+func (r Request) HasCursor() bool {
+	return len(r.Cursor) > 0
+}
+
+func (r Request) HasContext() bool {
+	return r.Before > 0 || r.After > 0
+}
+
 func (r Request) HasSetNames() bool {
 	return len(r.Namespaces) != 0 && len(r.SetNames) != 0
 }
 
+func (r Request) HasWorkloads() bool {
+	return len(r.Namespaces) != 0 && len(r.Workloads) != 0
+}
+
 func (r Request) HasPods() bool {
 	return len(r.Namespaces) != 0 && len(r.Pods) != 0
 }
@@ -21,9 +21,10 @@ import "github.com/naver/lobster/pkg/lobster/model"
 // Response struct
 // @Description Response wrapping series and logs from store.
 type Response struct {
-	SeriesData *model.SeriesData `json:"series,omitempty"`   // timeseries data
-	Contents   string            `json:"contents"`           // logs in string
-	PageInfo   *model.PageInfo   `json:"pageInfo,omitempty"` // page information
+	SeriesData  *model.SeriesData      `json:"series,omitempty"`      // timeseries data
+	Contents    string                 `json:"contents"`              // logs in string
+	PageInfo    *model.PageInfo        `json:"pageInfo,omitempty"`    // page information
+	Aggregation *model.AggregationData `json:"aggregation,omitempty"` // aggregated series
 }
 
 // ResponseEntries struct
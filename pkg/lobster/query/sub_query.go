@@ -51,12 +51,18 @@ func MakeSubQuery(req Request, seriesData model.SeriesData, pageBurst int64) (Re
 
 	pageInfo.HasNext = pageInfo.Current < pageInfo.Total
 
+	// the first page has the latest logs for backward
+	index := pageInfo.Current - 1
+	if req.IsBackward() {
+		index = pageInfo.Total - pageInfo.Current
+	}
+
 	subReq := req
 	subReq.Version = req.Version
-	subReq.Start = util.Timestamp{Time: pageBuckets[pageInfo.Current-1].Start}
-	subReq.End = util.Timestamp{Time: pageBuckets[pageInfo.Current-1].End}
+	subReq.Start = util.Timestamp{Time: pageBuckets[index].Start}
+	subReq.End = util.Timestamp{Time: pageBuckets[index].End}
 
-	return subReq, pageInfo, pageBuckets[pageInfo.Current-1].Size, nil
+	return subReq, pageInfo, pageBuckets[index].Size, nil
 }
 
 func makePageBuckets(req Request, seriesData model.SeriesData, pageBurst int64) []pageBucket {
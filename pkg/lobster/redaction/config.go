@@ -0,0 +1,33 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package redaction
+
+import (
+	"flag"
+)
+
+type config struct {
+	RuleFile *string
+}
+
+func setup() config {
+	ruleFile := flag.String("redaction.ruleFile", "", "Path to a yaml file of rules to mask or drop sensitive logs before they are stored")
+
+	return config{
+		RuleFile: ruleFile,
+	}
+}
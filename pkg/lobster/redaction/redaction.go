@@ -0,0 +1,190 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package redaction
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"sync"
+
+	"github.com/naver/lobster/pkg/lobster/model"
+	"github.com/naver/lobster/pkg/lobster/policy"
+)
+
+const (
+	ActionMask = "mask"
+	ActionDrop = "drop"
+
+	DetectorEmail      = "email"
+	DetectorJWT        = "jwt"
+	DetectorCreditCard = "creditCard"
+	DetectorIP         = "ip"
+
+	defaultMask = "****"
+)
+
+var (
+	conf  config
+	rules []*Rule
+	once  sync.Once
+
+	detectors = map[string]string{
+		DetectorEmail:      `[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`,
+		DetectorJWT:        `eyJ[A-Za-z0-9_-]+\.eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+`,
+		DetectorCreditCard: `\b\d(?:[ -]?\d){12,18}\b`,
+		DetectorIP:         `\b(?:(?:25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)\.){3}(?:25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)\b`,
+	}
+)
+
+func init() {
+	conf = setup()
+	log.Println("redaction configuration is loaded")
+}
+
+// Rule masks matches of a pattern or a built-in detector in logs of selected pods, or drops the lines.
+type Rule struct {
+	Name string `json:"name"`
+	policy.Selector
+	// One of a regular expression and a detector(email, jwt, creditCard or ip)
+	Pattern  string `json:"pattern,omitempty"`
+	Detector string `json:"detector,omitempty"`
+	// mask(default) or drop
+	Action string `json:"action,omitempty"`
+	// Replacement of matches; `****` by default
+	Mask string `json:"mask,omitempty"`
+
+	regexp *regexp.Regexp
+}
+
+func (r *Rule) init() error {
+	expr := r.Pattern
+
+	if len(r.Detector) > 0 {
+		if len(r.Pattern) > 0 {
+			return fmt.Errorf("redaction rule %q requires one of pattern and detector", r.Name)
+		}
+
+		detected, ok := detectors[r.Detector]
+		if !ok {
+			return fmt.Errorf("unknown detector %q of redaction rule %q", r.Detector, r.Name)
+		}
+		expr = detected
+	}
+
+	if len(expr) == 0 {
+		return fmt.Errorf("redaction rule %q requires one of pattern and detector", r.Name)
+	}
+
+	compiled, err := regexp.Compile(expr)
+	if err != nil {
+		return fmt.Errorf("invalid pattern of redaction rule %q: %w", r.Name, err)
+	}
+	r.regexp = compiled
+
+	switch r.Action {
+	case "":
+		r.Action = ActionMask
+	case ActionMask, ActionDrop:
+	default:
+		return fmt.Errorf("unknown action %q of redaction rule %q", r.Action, r.Name)
+	}
+
+	if len(r.Mask) == 0 {
+		r.Mask = defaultMask
+	}
+
+	return nil
+}
+
+// replace returns msg with matches masked and whether anything matched.
+func (r Rule) replace(msg string) (string, bool) {
+	matched := false
+
+	replaced := r.regexp.ReplaceAllStringFunc(msg, func(match string) string {
+		if r.Detector == DetectorCreditCard && !isLuhn(match) {
+			return match
+		}
+		matched = true
+		return r.Mask
+	})
+
+	return replaced, matched
+}
+
+// isLuhn filters out numbers like ids which are not card numbers.
+func isLuhn(number string) bool {
+	sum := 0
+	digits := 0
+
+	for i := len(number) - 1; i >= 0; i-- {
+		c := number[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+
+		d := int(c - '0')
+		if digits%2 == 1 {
+			d = d * 2
+			if d > 9 {
+				d = d - 9
+			}
+		}
+		sum = sum + d
+		digits = digits + 1
+	}
+
+	return digits >= 13 && sum%10 == 0
+}
+
+func NewRules(loaded []*Rule) ([]*Rule, error) {
+	for _, rule := range loaded {
+		if err := rule.init(); err != nil {
+			return nil, err
+		}
+	}
+
+	return loaded, nil
+}
+
+// LoadRules reads rules from redaction.ruleFile once; logs are not redacted if it is empty.
+func LoadRules() (err error) {
+	once.Do(func() {
+		var loaded []*Rule
+
+		if loaded, err = policy.Load[*Rule](*conf.RuleFile); err != nil {
+			return
+		}
+
+		rules, err = NewRules(loaded)
+	})
+
+	return
+}
+
+// RulesOf returns all rules selecting the chunk.
+func RulesOf(chunk model.Chunk) []*Rule {
+	selected := []*Rule{}
+
+	for _, rule := range rules {
+		if rule.Selects(chunk) {
+			selected = append(selected, rule)
+		}
+	}
+
+	return selected
+}
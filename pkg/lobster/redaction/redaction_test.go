@@ -0,0 +1,118 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package redaction
+
+import (
+	"os"
+	"testing"
+
+	"github.com/naver/lobster/pkg/lobster/logline"
+	"github.com/naver/lobster/pkg/lobster/model"
+	"github.com/naver/lobster/pkg/lobster/policy"
+)
+
+func TestMain(m *testing.M) {
+	logline.Setup()
+	os.Exit(m.Run())
+}
+
+func newTestRedactor(t *testing.T, chunk *model.Chunk, loaded []*Rule) *Redactor {
+	selected, err := NewRules(loaded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rules = selected
+
+	return NewRedactor(chunk)
+}
+
+func TestRedactorMasksDetectedValues(t *testing.T) {
+	chunk := &model.Chunk{Namespace: "pay", Source: model.Source{Type: model.LogTypeStdStream}}
+	redactor := newTestRedactor(t, chunk, []*Rule{
+		{Name: "email", Detector: DetectorEmail},
+		{Name: "jwt", Detector: DetectorJWT, Mask: "<jwt>"},
+		{Name: "card", Detector: DetectorCreditCard},
+		{Name: "ip", Detector: DetectorIP},
+	})
+
+	testData := map[string]string{
+		"2024-01-01T00:00:00Z stdout F mail from user@example.com\n":               "2024-01-01T00:00:00Z stdout F mail from ****\n",
+		"2024-01-01T00:00:00Z stdout F token eyJhbGciOi.eyJzdWIiOi.c2lnbmF0dXJl\n": "2024-01-01T00:00:00Z stdout F token <jwt>\n",
+		"2024-01-01T00:00:00Z stdout F card 4111 1111 1111 1111\n":                 "2024-01-01T00:00:00Z stdout F card ****\n",
+		"2024-01-01T00:00:00Z stdout F order 1234567890123456\n":                   "2024-01-01T00:00:00Z stdout F order 1234567890123456\n",
+		"2024-01-01T00:00:00Z stdout F client 10.0.0.1 connected\n":                "2024-01-01T00:00:00Z stdout F client **** connected\n",
+	}
+
+	for question, expected := range testData {
+		returned, ok := redactor.Redact(question)
+		if !ok {
+			t.Fatalf("%q must not be dropped", question)
+		}
+		if returned != expected {
+			t.Errorf("expected %q but %q", expected, returned)
+		}
+	}
+}
+
+func TestRedactorDropsLines(t *testing.T) {
+	chunk := &model.Chunk{Namespace: "pay", Source: model.Source{Type: model.LogTypeEmptyDirFile}}
+	redactor := newTestRedactor(t, chunk, []*Rule{{Name: "password", Pattern: `password=\S+`, Action: ActionDrop}})
+
+	if _, ok := redactor.Redact("login password=secret\n"); ok {
+		t.Error("line must be dropped")
+	}
+	if _, ok := redactor.Redact("login succeeded\n"); !ok {
+		t.Error("line must not be dropped")
+	}
+}
+
+func TestRulesOf(t *testing.T) {
+	loaded, err := NewRules([]*Rule{
+		{Name: "ns", Selector: policy.Selector{Namespaces: []string{"pay"}}, Detector: DetectorEmail},
+		{Name: "label", Selector: policy.Selector{Labels: map[string]string{"app": "api"}}, Detector: DetectorIP},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rules = loaded
+
+	if selected := RulesOf(model.Chunk{Namespace: "pay", Labels: model.Labels{"app": "api"}}); len(selected) != 2 {
+		t.Errorf("expected 2 rules but %d", len(selected))
+	}
+	if selected := RulesOf(model.Chunk{Namespace: "shop", Labels: model.Labels{"app": "web"}}); len(selected) != 0 {
+		t.Errorf("expected no rule but %d", len(selected))
+	}
+	if NewRedactor(&model.Chunk{Namespace: "shop"}) != nil {
+		t.Error("redactor must be nil without rules")
+	}
+}
+
+func TestInvalidRules(t *testing.T) {
+	invalid := [][]*Rule{
+		{{Name: "empty"}},
+		{{Name: "both", Pattern: "a", Detector: DetectorIP}},
+		{{Name: "detector", Detector: "phone"}},
+		{{Name: "action", Pattern: "a", Action: "hash"}},
+		{{Name: "pattern", Pattern: "("}},
+	}
+
+	for _, loaded := range invalid {
+		if _, err := NewRules(loaded); err == nil {
+			t.Errorf("rule %q must be invalid", loaded[0].Name)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package redaction
+
+import (
+	"strings"
+
+	"github.com/naver/lobster/pkg/lobster/logline"
+	"github.com/naver/lobster/pkg/lobster/metrics"
+	"github.com/naver/lobster/pkg/lobster/model"
+)
+
+// Redactor applies rules selecting a chunk to lines of the chunk.
+type Redactor struct {
+	chunk *model.Chunk
+	rules []*Rule
+}
+
+// NewRedactor returns nil if no rule selects the chunk.
+func NewRedactor(chunk *model.Chunk) *Redactor {
+	selected := RulesOf(*chunk)
+	if len(selected) == 0 {
+		return nil
+	}
+
+	return &Redactor{chunk, selected}
+}
+
+// Redact returns the line with matches masked or false if the line is dropped.
+// The timestamp, stream and tag of stdstream lines are kept as they are.
+func (r *Redactor) Redact(line string) (string, bool) {
+	prefix, msg := "", line
+
+	if r.chunk.Source.Type == model.LogTypeStdStream {
+		if parsed, err := logline.ParseLogMessage(line); err == nil && strings.HasSuffix(line, parsed) {
+			prefix, msg = line[:len(line)-len(parsed)], parsed
+		}
+	}
+
+	for _, rule := range r.rules {
+		replaced, matched := rule.replace(msg)
+		if !matched {
+			continue
+		}
+
+		metrics.AddRedactedCount(r.chunk.Namespace, r.chunk.Pod, r.chunk.Container, r.chunk.Source.Type, r.chunk.Source.Path, rule.Name, rule.Action)
+
+		if rule.Action == ActionDrop {
+			return "", false
+		}
+		msg = replaced
+	}
+
+	return prefix + msg, true
+}
@@ -22,13 +22,14 @@ import (
 )
 
 type config struct {
-	ServerAddr   *string
-	ServerPort   *string
-	MetricsAddr  *string
-	MetricsPort  *string
-	WriteTimeout *time.Duration
-	ReadTimeout  *time.Duration
-	IdleTimeout  *time.Duration
+	ServerAddr     *string
+	ServerPort     *string
+	MetricsAddr    *string
+	MetricsPort    *string
+	WriteTimeout   *time.Duration
+	ReadTimeout    *time.Duration
+	IdleTimeout    *time.Duration
+	RequestTimeout *time.Duration
 }
 
 func setup() config {
@@ -39,14 +40,16 @@ func setup() config {
 	writeTimeout := flag.Duration("server.writeTimeout", 300*time.Second, "write timeout seconds")
 	readTimeout := flag.Duration("server.readTimeout", 300*time.Second, "read timeout seconds")
 	idleTimeout := flag.Duration("server.idleTimeout", 15*time.Second, "idle timeout seconds")
+	requestTimeout := flag.Duration("server.requestTimeout", 60*time.Second, "deadline to handle log queries; 0 means no deadline")
 
 	return config{
-		ServerAddr:   serverAddr,
-		ServerPort:   serverPort,
-		MetricsAddr:  metricsAddr,
-		MetricsPort:  metricsPort,
-		WriteTimeout: writeTimeout,
-		ReadTimeout:  readTimeout,
-		IdleTimeout:  idleTimeout,
+		ServerAddr:     serverAddr,
+		ServerPort:     serverPort,
+		MetricsAddr:    metricsAddr,
+		MetricsPort:    metricsPort,
+		WriteTimeout:   writeTimeout,
+		ReadTimeout:    readTimeout,
+		IdleTimeout:    idleTimeout,
+		RequestTimeout: requestTimeout,
 	}
 }
@@ -17,6 +17,7 @@
 package errors
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/pkg/errors"
@@ -29,6 +30,8 @@ var (
 	ErrNotImplemented      = errors.New("not implemented")
 	ErrBadRequest          = errors.New("bad request")
 	ErrInternalServerError = errors.New("internal error")
+	ErrUnauthorized        = errors.New("unauthorized")
+	ErrForbidden           = errors.New("forbidden")
 )
 
 func ErrorByStatusCode(statusCode int) error {
@@ -39,21 +42,44 @@ func ErrorByStatusCode(statusCode int) error {
 		return ErrNotImplemented
 	case http.StatusBadRequest:
 		return ErrBadRequest
+	case http.StatusUnauthorized:
+		return ErrUnauthorized
+	case http.StatusForbidden:
+		return ErrForbidden
+	case http.StatusGatewayTimeout:
+		return context.DeadlineExceeded
 	}
 
 	return ErrInternalServerError
 }
 
-func HandleError(w http.ResponseWriter, err error) {
+func StatusCode(err error) int {
+	// errors of requests to other components wrap the deadline of the request
+	if errors.Is(err, context.DeadlineExceeded) {
+		return http.StatusGatewayTimeout
+	}
+
 	switch errors.Cause(err) {
 	case ErrTooManyRequests:
-		http.Error(w, err.Error(), http.StatusTooManyRequests)
+		return http.StatusTooManyRequests
 	case ErrNotImplemented:
-		http.Error(w, err.Error(), http.StatusNotImplemented)
+		return http.StatusNotImplemented
 	case ErrBadRequest:
-		http.Error(w, err.Error(), http.StatusBadRequest)
-	default:
+		return http.StatusBadRequest
+	case ErrUnauthorized:
+		return http.StatusUnauthorized
+	case ErrForbidden:
+		return http.StatusForbidden
+	}
+
+	return http.StatusInternalServerError
+}
+
+func HandleError(w http.ResponseWriter, err error) {
+	statusCode := StatusCode(err)
+	if statusCode == http.StatusInternalServerError {
 		glog.Error(err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
 	}
+
+	http.Error(w, err.Error(), statusCode)
 }
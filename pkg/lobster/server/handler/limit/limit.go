@@ -0,0 +1,56 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package limit
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/golang/glog"
+	"github.com/naver/lobster/pkg/lobster/store"
+)
+
+const PathLimits = "/limits"
+
+type LimitStatProvider interface {
+	GetLimitStats() []store.LimitStat
+}
+
+// LimitHandler responds the usage of tiers of ingestion limit profiles.
+type LimitHandler struct {
+	Provider LimitStatProvider
+}
+
+func (h LimitHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	defer func() { _ = r.Body.Close() }()
+
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	contents, err := json.Marshal(h.Provider.GetLimitStats())
+	if err != nil {
+		http.Error(w, "Failed to read limits", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if _, err := w.Write(contents); err != nil {
+		glog.Error(err)
+	}
+}
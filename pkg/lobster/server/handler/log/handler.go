@@ -26,7 +26,6 @@ import (
 )
 
 const (
-	Scheme  = "http"
 	PathApi = "/api/{version}"
 
 	ApiV1 = "v1"
@@ -67,3 +66,24 @@ func parseRequest(r *http.Request) (query.Request, error) {
 
 	return req, nil
 }
+
+func parseTailRequest(r *http.Request) (query.Request, error) {
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		return query.Request{}, fmt.Errorf("invalid request")
+	}
+
+	version, ok := mux.Vars(r)["version"]
+	if !ok || version != ApiV2 {
+		return query.Request{}, fmt.Errorf("invalid version")
+	}
+
+	req, err := query.ParseTailRequestWithBody(data)
+	if err != nil {
+		return query.Request{}, err
+	}
+
+	req.Version = version
+
+	return req, nil
+}
@@ -21,7 +21,9 @@ import (
 	"net/http"
 
 	"github.com/golang/glog"
+	"github.com/naver/lobster/pkg/lobster/auth"
 	"github.com/naver/lobster/pkg/lobster/query"
+	"github.com/naver/lobster/pkg/lobster/server/errors"
 )
 
 const (
@@ -60,15 +62,31 @@ func (h ListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	found, err := auth.LimitRequest(r.Context(), &req, h.Querier)
+	if err != nil {
+		errors.HandleError(w, err)
+		return
+	}
+	if !found {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
 	glog.Infof("ListHandler handling request: %s", req.String())
 
-	chunks, err := h.Querier.GetChunksWithinRange(req)
+	chunks, err := h.Querier.GetChunksWithinRange(r.Context(), req)
 	if err != nil {
 		glog.Error(err)
 		http.Error(w, "Failed to read logs", http.StatusInternalServerError)
 		return
 	}
 
+	chunks, err = auth.FilterChunks(r.Context(), chunks)
+	if err != nil {
+		errors.HandleError(w, err)
+		return
+	}
+
 	if len(chunks) == 0 {
 		w.WriteHeader(http.StatusNoContent)
 		return
@@ -18,9 +18,11 @@ package log
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 
 	"github.com/golang/glog"
+	"github.com/naver/lobster/pkg/lobster/auth"
 	"github.com/naver/lobster/pkg/lobster/model"
 	"github.com/naver/lobster/pkg/lobster/query"
 	"github.com/naver/lobster/pkg/lobster/server/errors"
@@ -30,6 +32,19 @@ const (
 	PathLogRange = "/logs/range"
 )
 
+// ValidateRange validates the request for logs within range, which is also served through grpc streams of stores.
+func ValidateRange(q query.Queryable, req query.Request) error {
+	if err := q.Validate(req); err != nil {
+		return err
+	}
+
+	if !req.HasCursor() && (req.Page == 0 || req.Page < query.LastPageNum) {
+		return fmt.Errorf("invalid page number")
+	}
+
+	return nil
+}
+
 type RangeHandler struct {
 	Querier                          query.Queryable
 	ShouldResponseStringContentsOnly bool
@@ -51,20 +66,15 @@ type RangeHandler struct {
 //	@Failure		501		{string}	string	"Not supported version"
 //	@Router			/api/v1/logs/range [post]
 func (h RangeHandler) ServeForStringContents(req query.Request, w http.ResponseWriter, r *http.Request) {
-	if err := h.Querier.Validate(req); err != nil {
+	if err := ValidateRange(h.Querier, req); err != nil {
 		glog.Info(err.Error())
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	if req.Page == 0 || req.Page < query.LastPageNum {
-		http.Error(w, "invalid page number", http.StatusBadRequest)
-		return
-	}
-
 	glog.Infof("RangeHandler handling request: %s", req.String())
 
-	contents, _, _, numOfChunk, pageInfo, err := h.Querier.GetBlocksWithinRange(req)
+	contents, _, _, numOfChunk, pageInfo, err := h.Querier.GetBlocksWithinRange(r.Context(), req)
 	if err != nil {
 		errors.HandleError(w, err)
 		glog.Error(err)
@@ -108,18 +118,13 @@ func (h RangeHandler) ServeForStringContents(req query.Request, w http.ResponseW
 //	@Failure		501		{string}	string	"Not supported version"
 //	@Router			/api/v2/logs/range [post]
 func (h RangeHandler) ServeForEntriesContents(req query.Request, w http.ResponseWriter, r *http.Request) {
-	if err := h.Querier.Validate(req); err != nil {
+	if err := ValidateRange(h.Querier, req); err != nil {
 		glog.Info(err.Error())
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	if req.Page == 0 || req.Page < query.LastPageNum {
-		http.Error(w, "invalid page number", http.StatusBadRequest)
-		return
-	}
-
-	entries, numOfChunk, pageInfo, err := h.Querier.GetEntriesWithinRange(req)
+	entries, numOfChunk, pageInfo, err := h.Querier.GetEntriesWithinRange(r.Context(), req)
 	if err != nil {
 		errors.HandleError(w, err)
 		return
@@ -159,6 +164,16 @@ func (h RangeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	found, err := auth.LimitRequest(r.Context(), &req, h.Querier)
+	if err != nil {
+		errors.HandleError(w, err)
+		return
+	}
+	if !found {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
 	switch req.Version {
 	case ApiV1:
 		req.Source.Type = model.LogTypeStdStream
@@ -21,6 +21,7 @@ import (
 	"net/http"
 
 	"github.com/golang/glog"
+	"github.com/naver/lobster/pkg/lobster/auth"
 	"github.com/naver/lobster/pkg/lobster/query"
 	"github.com/naver/lobster/pkg/lobster/server/errors"
 )
@@ -61,6 +62,16 @@ func (h SeriesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	found, err := auth.LimitRequest(r.Context(), &req, h.Querier)
+	if err != nil {
+		errors.HandleError(w, err)
+		return
+	}
+	if !found {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
 	if err := h.Querier.Validate(req); err != nil {
 		glog.Info(err.Error())
 		http.Error(w, err.Error(), http.StatusBadRequest)
@@ -69,7 +80,7 @@ func (h SeriesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	glog.Infof("SeriesHandler handling request: %s", req.String())
 
-	numOfChunk, seriesData, err := h.Querier.GetSeriesInBlocksWithinRange(req)
+	numOfChunk, seriesData, err := h.Querier.GetSeriesInBlocksWithinRange(r.Context(), req)
 	if err != nil {
 		errors.HandleError(w, err)
 		return
@@ -0,0 +1,138 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package log
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/naver/lobster/pkg/lobster/auth"
+	"github.com/naver/lobster/pkg/lobster/model"
+	"github.com/naver/lobster/pkg/lobster/query"
+	"github.com/naver/lobster/pkg/lobster/server/errors"
+)
+
+const (
+	PathLogTail = "/logs/tail"
+
+	tailHeartbeatInterval = 15 * time.Second
+)
+
+type TailHandler struct {
+	Querier query.Tailable
+}
+
+// ServeHTTP
+//
+//	@Summary		Follow logs
+//	@Description	Stream logs for conditions as server-sent events as they are written; `start`, `end` and `page` are ignored
+//	@Tags			Post
+//	@Produce		text/event-stream
+//	@Param			request	body		query.Request	true	"request parameters"
+//	@Success		200		{object}	model.Entry		"stream of `data: {entry}` events"
+//	@Failure		400		{string}	string			"Invalid parameters"
+//	@Failure		405		{string}	string			"Method not allowed"
+//	@Failure		500		{string}	string			"Failed to stream logs"
+//	@Router			/api/v2/logs/tail [post]
+func (h TailHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	req, err := parseTailRequest(r)
+	if err != nil {
+		glog.Info(err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if _, err := auth.LimitRequest(r.Context(), &req, nil); err != nil {
+		errors.HandleError(w, err)
+		return
+	}
+
+	glog.Infof("TailHandler handling request: %s", req.String())
+
+	var (
+		lock       = sync.Mutex{}
+		controller = http.NewResponseController(w)
+		ctx        = r.Context()
+		isStarted  = false
+	)
+
+	// streams are not bounded by the write timeout of the server
+	if err := controller.SetWriteDeadline(time.Time{}); err != nil {
+		glog.V(3).Info(err.Error())
+	}
+
+	write := func(event string) error {
+		lock.Lock()
+		defer lock.Unlock()
+
+		if !isStarted {
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.Header().Set("Cache-Control", "no-cache")
+			w.WriteHeader(http.StatusOK)
+			isStarted = true
+		}
+
+		if _, err := fmt.Fprint(w, event); err != nil {
+			return err
+		}
+
+		return controller.Flush()
+	}
+
+	go func() {
+		ticker := time.NewTicker(tailHeartbeatInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if err := write(": heartbeat\n\n"); err != nil {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	if err := write(": connected\n\n"); err != nil {
+		glog.Error(err)
+		return
+	}
+
+	err = h.Querier.Tail(ctx, req, func(e model.Entry) error {
+		data, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+
+		return write(fmt.Sprintf("data: %s\n\n", data))
+	})
+	if err != nil && ctx.Err() == nil {
+		glog.Error(err)
+		_ = write(fmt.Sprintf("event: error\ndata: %s\n\n", err.Error()))
+	}
+}
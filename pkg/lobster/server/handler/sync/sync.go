@@ -27,7 +27,6 @@ import (
 )
 
 const (
-	Scheme   = "http"
 	PathSync = "/sync/{type}"
 )
 
@@ -41,6 +41,7 @@ type page struct {
 	IsPartialContents bool
 	Contents          []byte
 	HistogramScript   string
+	TokenRequired     bool
 }
 
 func init() {
@@ -17,10 +17,14 @@
 package web
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/golang/glog"
+	"github.com/naver/lobster/pkg/lobster/auth"
 	"github.com/naver/lobster/pkg/lobster/query"
+	"github.com/naver/lobster/pkg/lobster/query/logql"
+	serverErrors "github.com/naver/lobster/pkg/lobster/server/errors"
 	"github.com/naver/lobster/pkg/lobster/server/handler/log"
 )
 
@@ -29,6 +33,8 @@ const webContentsLimit = 1000 * 1000 * 3 // 3mb
 type WebHandler struct {
 	Addresses []string
 	Querier   query.Queryable
+	// Authorizer authenticates tokens in auth.TokenCookie; the page is served to anyone if it is nil
+	Authorizer *auth.Authorizer
 }
 
 func (h WebHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
@@ -38,27 +44,50 @@ func (h WebHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 
 	page := newPage()
-	req, _ := query.ParseRequestWithUri(r.RequestURI)
+	req, err := query.ParseRequestWithUri(r.RequestURI)
+	if parseErr := new(logql.ParseError); errors.As(err, &parseErr) {
+		http.Error(w, parseErr.Error(), http.StatusBadRequest)
+		return
+	}
 	req.Version = log.ApiV2
 	req.ContentsLimit = webContentsLimit
 
-	if !req.Start.Time.IsZero() && !req.End.Time.IsZero() {
-		chunks, err := h.Querier.GetChunksWithinRange(req)
+	r, authenticated := h.authenticate(r)
+	page.TokenRequired = h.Authorizer != nil
+
+	if authenticated && !req.Start.Time.IsZero() && !req.End.Time.IsZero() {
+		found, err := auth.LimitRequest(r.Context(), &req, h.Querier)
+		if err != nil {
+			serverErrors.HandleError(w, err)
+			return
+		}
+		if !found {
+			renderPage(w, page)
+			return
+		}
+
+		chunks, err := h.Querier.GetChunksWithinRange(r.Context(), req)
 		if err != nil {
 			glog.Error(err)
 			http.Error(w, "invalid request", http.StatusBadRequest)
 			return
 		}
 
+		chunks, err = auth.FilterChunks(r.Context(), chunks)
+		if err != nil {
+			serverErrors.HandleError(w, err)
+			return
+		}
+
 		page.fillPanel(chunks)
 
 		if shouldRespondLogs(req) {
-			_, seriesData, err := h.Querier.GetSeriesInBlocksWithinRange(req)
+			_, seriesData, err := h.Querier.GetSeriesInBlocksWithinRange(r.Context(), req)
 			if err != nil {
 				glog.Error(err)
 			}
 
-			contents, _, _, _, pageInfo, err := h.Querier.GetBlocksWithinRange(req)
+			contents, _, _, _, pageInfo, err := h.Querier.GetBlocksWithinRange(r.Context(), req)
 			if err != nil {
 				glog.Error(err)
 			}
@@ -69,6 +98,10 @@ func (h WebHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	renderPage(w, page)
+}
+
+func renderPage(w http.ResponseWriter, page page) {
 	if err := page.render(w); err != nil {
 		glog.Error(err)
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -77,6 +110,22 @@ func (h WebHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "text/html")
 }
 
+// authenticate returns the request having the caller of the token cookie.
+// Callers failing to authenticate get the page without logs, which lets them enter tokens.
+func (h WebHandler) authenticate(r *http.Request) (*http.Request, bool) {
+	if h.Authorizer == nil {
+		return r, true
+	}
+
+	ctx, err := h.Authorizer.Authenticate(r)
+	if err != nil {
+		glog.Info(err.Error())
+		return r, false
+	}
+
+	return r.WithContext(ctx), true
+}
+
 func shouldRespondLogs(req query.Request) bool {
-	return req.HasLabels() || req.HasSetNames() || req.HasPods() || req.HasContainers()
+	return req.HasLabels() || req.HasSetNames() || req.HasWorkloads() || req.HasPods() || req.HasContainers()
 }
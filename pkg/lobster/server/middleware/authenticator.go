@@ -0,0 +1,48 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/golang/glog"
+	"github.com/naver/lobster/pkg/lobster/auth"
+	"github.com/naver/lobster/pkg/lobster/server/errors"
+)
+
+// Authenticator passes requests with callers of their bearer tokens; all requests pass if Authorizer is nil.
+type Authenticator struct {
+	Authorizer *auth.Authorizer
+}
+
+func (a Authenticator) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if a.Authorizer == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ctx, err := a.Authorizer.Authenticate(r)
+		if err != nil {
+			glog.Info(err.Error())
+			errors.HandleError(w, err)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
@@ -36,10 +36,15 @@ func (r *Recorder) WriteHeader(status int) {
 }
 
 func (r *Recorder) Write(data []byte) (int, error) {
-	r.Size = len(data)
+	r.Size = r.Size + len(data)
 	return r.ResponseWriter.Write(data)
 }
 
+// Unwrap lets http.ResponseController flush streaming responses
+func (r *Recorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
 type Inspector struct{}
 
 func (i Inspector) Middleware(next http.Handler) http.Handler {
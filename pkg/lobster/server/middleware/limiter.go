@@ -30,20 +30,30 @@ type Limiter struct {
 
 func (rl *Limiter) Middleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		loaded := atomic.LoadInt64(&rl.count)
-
-		if loaded < rl.Limit {
-			defer func() {
-				if loaded+1 == rl.Limit {
-					time.Sleep(rl.CooldownSecond)
-				}
-				atomic.AddInt64(&rl.count, -1)
-			}()
-
-			atomic.AddInt64(&rl.count, 1)
-			next.ServeHTTP(w, r)
-		} else {
+		release, ok := rl.Acquire()
+		if !ok {
 			w.WriteHeader(http.StatusTooManyRequests)
+			return
 		}
+		defer release()
+
+		next.ServeHTTP(w, r)
 	})
 }
+
+// Acquire takes a slot if the limit is not reached; the slot must be given back by calling release.
+func (rl *Limiter) Acquire() (release func(), ok bool) {
+	loaded := atomic.LoadInt64(&rl.count)
+	if loaded >= rl.Limit {
+		return nil, false
+	}
+
+	atomic.AddInt64(&rl.count, 1)
+
+	return func() {
+		if loaded+1 == rl.Limit {
+			time.Sleep(rl.CooldownSecond)
+		}
+		atomic.AddInt64(&rl.count, -1)
+	}, true
+}
@@ -25,10 +25,12 @@ import (
 	"os/signal"
 	"runtime"
 	"syscall"
+	"time"
 
 	"github.com/golang/glog"
 	"github.com/gorilla/mux"
 	"github.com/naver/lobster/pkg/lobster/metrics"
+	"github.com/naver/lobster/pkg/lobster/mtls"
 	"github.com/naver/lobster/pkg/lobster/util"
 )
 
@@ -44,6 +46,10 @@ type ApiServer struct {
 }
 
 func NewApiServer(router *mux.Router) *ApiServer {
+	if err := mtls.Check(); err != nil {
+		glog.Fatal(err)
+	}
+
 	return &ApiServer{
 		&http.Server{
 			Addr:         fmt.Sprintf("%s:%s", *conf.ServerAddr, *conf.ServerPort),
@@ -78,12 +84,24 @@ func (s *ApiServer) Run(doShutdown func()) {
 		glog.Fatal(err)
 	}
 	glog.Info("Start server")
+	if mtls.Enabled() {
+		s.TLSConfig = mtls.ServerConfig(false)
+		if err := s.ListenAndServeTLS("", ""); err != nil {
+			glog.Fatal(err)
+		}
+		return
+	}
 	if err := s.ListenAndServe(); err != nil {
 		glog.Fatal(err)
 	}
 
 }
 
+// RequestTimeout returns the deadline to handle log queries.
+func RequestTimeout() time.Duration {
+	return *conf.RequestTimeout
+}
+
 func (s ApiServer) GetLocalEndpoint() string {
 	return fmt.Sprintf("%s:%s", util.GetLocalAddress(), *conf.ServerPort)
 }
@@ -26,6 +26,7 @@ import (
 	"github.com/naver/lobster/pkg/lobster/client"
 	"github.com/naver/lobster/pkg/lobster/metrics"
 	"github.com/naver/lobster/pkg/lobster/model"
+	"github.com/naver/lobster/pkg/lobster/mtls"
 	"github.com/naver/lobster/pkg/lobster/proto"
 	"github.com/naver/lobster/pkg/lobster/query"
 	"github.com/naver/lobster/pkg/lobster/sink/exporter/counter"
@@ -37,7 +38,6 @@ import (
 	"github.com/naver/lobster/pkg/lobster/util"
 	"github.com/pkg/errors"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	sinkV1 "github.com/naver/lobster/pkg/operator/api/v1"
@@ -68,7 +68,7 @@ func NewLogExporter(store *store.Store) LogExporter {
 
 	conn, err := grpc.NewClient(
 		*conf.StoreGrpcServerAddr,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithTransportCredentials(mtls.GrpcCredentials()),
 		grpc.WithConnectParams(grpc.ConnectParams{MinConnectTimeout: time.Second}),
 		grpc.WithDefaultCallOptions(
 			grpc.MaxCallRecvMsgSize(*conf.GrpcMaxCallMsgSize),
@@ -253,7 +253,7 @@ func (e *LogExporter) getAndExportLogs(uploader uploader.Uploader, request query
 	request.End = util.Timestamp{Time: end}
 	request.Page = 1
 
-	_, series, err := e.store.GetSeriesInBlocksWithinRange(request)
+	_, series, err := e.store.GetSeriesInBlocksWithinRange(context.Background(), request)
 	if err != nil {
 		return time.Time{}, 0, err
 	}
@@ -272,7 +272,7 @@ func (e *LogExporter) getAndExportLogs(uploader uploader.Uploader, request query
 			chunk.Key())
 		glog.Flush()
 
-		data, pStart, pEnd, _, _, err := e.store.GetBlocksWithinRange(subReq)
+		data, pStart, pEnd, _, _, err := e.store.GetBlocksWithinRange(context.Background(), subReq)
 		if err != nil {
 			return time.Time{}, 0, err
 		}
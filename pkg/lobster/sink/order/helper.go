@@ -19,7 +19,7 @@ package order
 import (
 	"github.com/golang/glog"
 	"github.com/naver/lobster/pkg/lobster/metrics"
-	"github.com/naver/lobster/pkg/lobster/querier"
+	"github.com/naver/lobster/pkg/lobster/query"
 	"github.com/naver/lobster/pkg/lobster/sink/indexer"
 )
 
@@ -58,7 +58,7 @@ func NewOrdersFromPreorders(preorders []Order, indexer indexer.ChunkIndexer) Ord
 
 func newOrdersForChunks(preorder Order, indexer indexer.ChunkIndexer) (Orders, error) {
 	orders := Orders{}
-	matcher := querier.NewChunkMatcher(preorder.Request)
+	matcher := query.NewChunkMatcher(preorder.Request)
 	chunks := indexer.GetChunks(preorder.RuleNamespace)
 
 	for _, chunk := range chunks {
@@ -25,11 +25,11 @@ import (
 	"net/url"
 	"time"
 
+	"github.com/naver/lobster/pkg/lobster/mtls"
 	"github.com/naver/lobster/pkg/lobster/sink/order"
 )
 
 const (
-	scheme   = "http"
 	PathSync = "/sync"
 )
 
@@ -38,6 +38,7 @@ var (
 		Timeout: 3 * time.Second,
 		Transport: &http.Transport{
 			DisableKeepAlives: true,
+			TLSClientConfig:   mtls.ClientConfig(),
 		},
 	}
 )
@@ -53,7 +54,7 @@ func Request(syncer, sinkType string, namespaces []string) ([]order.Order, error
 	resp, err := client.Do(&http.Request{
 		Method: http.MethodPost,
 		URL: &url.URL{
-			Scheme: scheme,
+			Scheme: mtls.Scheme(),
 			Host:   syncer,
 			Path:   fmt.Sprintf("%s/%s", PathSync, sinkType),
 		},
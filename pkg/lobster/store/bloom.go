@@ -0,0 +1,188 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package store
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+
+	"github.com/cespare/xxhash/v2"
+	cache "github.com/hashicorp/golang-lru"
+	"github.com/naver/lobster/pkg/lobster/model"
+)
+
+// Blocks are summarized by trigrams instead of words,
+// so that a literal is looked up even if it is a part of a word in logs.
+const gramSize = 3
+
+var (
+	bloomCache *cache.Cache
+
+	errInvalidBloomFilter = errors.New("invalid bloom filter")
+)
+
+func init() {
+	bloomCache, _ = cache.New(4096)
+}
+
+type bloomFilter struct {
+	k    uint8
+	bits []uint64
+}
+
+func newBloomFilter(n int, falsePositiveRate float64) *bloomFilter {
+	if n < 1 {
+		n = 1
+	}
+
+	m := math.Ceil(-float64(n) * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2))
+	k := math.Max(1, math.Round(m/float64(n)*math.Ln2))
+
+	return &bloomFilter{
+		k:    uint8(k),
+		bits: make([]uint64, int(m)/64+1),
+	}
+}
+
+// buildBloomFilter summarizes every trigram within lines of data.
+func buildBloomFilter(data []byte, falsePositiveRate float64) *bloomFilter {
+	hashes := map[uint64]struct{}{}
+
+	for i := 0; i+gramSize <= len(data); i++ {
+		gram := data[i : i+gramSize]
+		if gram[0] == '\n' || gram[1] == '\n' || gram[2] == '\n' {
+			continue
+		}
+		hashes[xxhash.Sum64(gram)] = struct{}{}
+	}
+
+	filter := newBloomFilter(len(hashes), falsePositiveRate)
+	for h := range hashes {
+		filter.add(h)
+	}
+
+	return filter
+}
+
+func (f *bloomFilter) location(h uint64, i uint64) uint64 {
+	h1, h2 := h&math.MaxUint32, h>>32
+	return (h1 + i*h2) % (uint64(len(f.bits)) * 64)
+}
+
+func (f *bloomFilter) add(h uint64) {
+	for i := uint64(0); i < uint64(f.k); i++ {
+		loc := f.location(h, i)
+		f.bits[loc/64] |= 1 << (loc % 64)
+	}
+}
+
+func (f *bloomFilter) has(h uint64) bool {
+	for i := uint64(0); i < uint64(f.k); i++ {
+		loc := f.location(h, i)
+		if f.bits[loc/64]&(1<<(loc%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// mayContain returns false only if the literal is never found in the block.
+func (f *bloomFilter) mayContain(literal string) bool {
+	for i := 0; i+gramSize <= len(literal); i++ {
+		if !f.has(xxhash.Sum64String(literal[i : i+gramSize])) {
+			return false
+		}
+	}
+	return true
+}
+
+func (f *bloomFilter) bytes() []byte {
+	data := make([]byte, 0, 1+len(f.bits)*8)
+	data = append(data, f.k)
+	for _, word := range f.bits {
+		data = binary.BigEndian.AppendUint64(data, word)
+	}
+	return data
+}
+
+func parseBloomFilter(data []byte) (*bloomFilter, error) {
+	if len(data) < 9 || (len(data)-1)%8 != 0 || data[0] == 0 {
+		return nil, errInvalidBloomFilter
+	}
+
+	filter := &bloomFilter{k: data[0], bits: make([]uint64, (len(data)-1)/8)}
+	for i := range filter.bits {
+		filter.bits[i] = binary.BigEndian.Uint64(data[1+i*8:])
+	}
+
+	return filter, nil
+}
+
+func loadBloomFilter(dir string, block model.Block) (*bloomFilter, error) {
+	path := fmt.Sprintf("%s/%s", dir, block.BloomFileName())
+	if v, ok := bloomCache.Get(path); ok {
+		return v.(*bloomFilter), nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	filter, err := parseBloomFilter(data)
+	if err != nil {
+		return nil, err
+	}
+
+	bloomCache.Add(path, filter)
+
+	return filter, nil
+}
+
+// storedLiteral replaces newlines of a literal with the delimiter of multiline logs, as summarized in bloom filters.
+func storedLiteral(literal string) string {
+	return strings.ReplaceAll(literal, "\n", model.MultilineDelimiter)
+}
+
+// blockMayContain returns false only if the block has a bloom filter and any of literals is not in it.
+func blockMayContain(dir string, block model.ReadableBlock, literals []string) bool {
+	if len(literals) == 0 {
+		return true
+	}
+
+	b, ok := block.(*model.Block)
+	if !ok {
+		return true
+	}
+
+	filter, err := loadBloomFilter(dir, *b)
+	if err != nil {
+		return true
+	}
+
+	for _, literal := range literals {
+		if !filter.mayContain(storedLiteral(literal)) {
+			return false
+		}
+	}
+
+	return true
+}
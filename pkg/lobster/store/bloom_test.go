@@ -0,0 +1,44 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package store
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBloomFilter(t *testing.T) {
+	data := append(makeTestBlockData(time.Now(), 100), []byte("2024-01-24T01:01:09.334Z stdout F trace_id=4bf92f3577b34da6a3ce929d0e0e4736\n")...)
+	data = append(data, []byte("2024-01-24T01:01:10.334Z stdout F panic: oops\x1egoroutine 1 [running]\n")...)
+
+	filter, err := parseBloomFilter(buildBloomFilter(data, 0.01).bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, literal := range []string{"4bf92f3577b34da6a3ce929d0e0e4736", "b34da6", "test log line 42", "tr", "oops\ngoroutine"} {
+		if !filter.mayContain(storedLiteral(literal)) {
+			t.Fatalf("bloom filter should contain %s", literal)
+		}
+	}
+
+	for _, literal := range []string{"c0ffee0ddba11", "ERROR: connection refused"} {
+		if filter.mayContain(literal) {
+			t.Fatalf("bloom filter should not contain %s", literal)
+		}
+	}
+}
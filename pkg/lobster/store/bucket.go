@@ -19,6 +19,9 @@ package store
 import (
 	"fmt"
 	"time"
+
+	"github.com/naver/lobster/pkg/lobster/metrics"
+	"github.com/naver/lobster/pkg/lobster/model"
 )
 
 const (
@@ -26,43 +29,72 @@ const (
 	LimitedByLine
 )
 
+type overflowedLine struct {
+	ts  time.Time
+	msg string
+}
+
 type leakyBucket struct {
-	limiter  Limiter
+	profiles LimitProfiles
+	profile  *LimitProfile
+	chunk    *model.Chunk
 	limit    *Limit
 	size     int64
 	lines    int64
 	interval time.Duration
 	prevTime time.Time
+	// logs exceeding the limit in the current interval
+	limited    string
+	overflowed int64
+	lastTs     time.Time
+	newest     []overflowedLine
+	head       int
 }
 
-func NewLeakyBucket(limiter Limiter, interval time.Duration) *leakyBucket {
+func NewLeakyBucket(profiles LimitProfiles, chunk *model.Chunk, interval time.Duration) *leakyBucket {
+	profile := profiles.Of(*chunk)
+
 	return &leakyBucket{
-		limiter:  limiter,
-		limit:    limiter.getDefaultLimit(),
+		profiles: profiles,
+		profile:  profile,
+		chunk:    chunk,
+		limit:    profile.limiter.getDefaultLimit(),
 		interval: interval,
 		prevTime: time.Time{},
 	}
 }
 
+// Init starts a new interval with the profile selected for the chunk at the moment.
 func (b *leakyBucket) Init(t time.Time) {
 	b.size = 0
 	b.lines = 1
+	b.limited = ""
+	b.overflowed = 0
+	b.newest = b.newest[:0]
+	b.head = 0
 	b.limit.release()
-	b.limit = b.limiter.getDefaultLimit()
+	b.profile = b.profiles.Of(*b.chunk)
+	b.limit = b.profile.limiter.getDefaultLimit()
 }
 
 func (b *leakyBucket) Pour(size int64) (bool, string) {
+	if len(b.limited) > 0 {
+		return false, b.limited
+	}
+
 	if b.limit.size < b.size {
 		b.limit.release()
-		b.limit = b.limiter.getLimit(b.size)
+		b.limit = b.profile.limiter.getLimit(b.size)
 
 		if b.limit.size < b.size {
-			return false, description(LimitedBySize, b.limit.size)
+			b.limited = description(LimitedBySize, b.limit.size)
+			return false, b.limited
 		}
 	}
 
 	if b.limit.lines < b.lines {
-		return false, description(LimitedByLine, b.limit.lines)
+		b.limited = description(LimitedByLine, b.limit.lines)
+		return false, b.limited
 	}
 
 	b.size = b.size + size
@@ -71,6 +103,58 @@ func (b *leakyBucket) Pour(size int64) (bool, string) {
 	return true, ""
 }
 
+// Overflow returns true if the log exceeding the limit should be written right away by the overflow of the profile.
+// Logs kept as the newest are written by flushOverflow at the end of the interval.
+func (b *leakyBucket) Overflow(ts time.Time, msg string) bool {
+	b.overflowed = b.overflowed + 1
+	b.lastTs = ts
+
+	switch b.profile.Overflow {
+	case OverflowSample:
+		return b.overflowed%int64(b.profile.SampleRate) == 0
+	case OverflowNewest:
+		if len(b.newest) < b.profile.NewestLines {
+			b.newest = append(b.newest, overflowedLine{ts, msg})
+			break
+		}
+		b.newest[b.head] = overflowedLine{ts, msg}
+		b.head = (b.head + 1) % len(b.newest)
+	}
+
+	return false
+}
+
+func (b *leakyBucket) ShouldStop() bool {
+	return b.profile.Overflow == OverflowStop
+}
+
+// flushOverflow writes logs kept as the newest and how many logs exceeded the limit in the interval.
+func (b *leakyBucket) flushOverflow(buf *writeBuffer) {
+	if b.overflowed == 0 {
+		return
+	}
+
+	kept := int64(0)
+
+	switch b.profile.Overflow {
+	case OverflowSample:
+		kept = b.overflowed / int64(b.profile.SampleRate)
+	case OverflowNewest:
+		kept = int64(len(b.newest))
+		for i := range b.newest {
+			line := b.newest[(b.head+i)%len(b.newest)]
+			buf.write(line.ts, line.msg)
+		}
+	}
+
+	buf.write(b.lastTs, fmt.Sprintf("%s stdout F (lobster: %d of %d logs exceeding %s were dropped by %s)\n", b.lastTs.Format(time.RFC3339Nano), b.overflowed-kept, b.overflowed, b.limited, b.profile.Overflow))
+	metrics.AddOverloadedCount(b.chunk.Namespace, b.chunk.Pod, b.chunk.Container, b.chunk.Source.Type, b.chunk.Source.Path, b.limited)
+
+	b.overflowed = 0
+	b.newest = b.newest[:0]
+	b.head = 0
+}
+
 func (b *leakyBucket) Release() {
 	b.limit.release()
 }
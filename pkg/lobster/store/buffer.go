@@ -33,10 +33,12 @@ type writeBuffer struct {
 	lines      int64
 	fileOffset int64
 	lastOffset int64
+	// called with every line written, including lines kept over limits
+	onWrite func(time.Time, string)
 }
 
 func emptyWriteBuffer() *writeBuffer {
-	return &writeBuffer{[]history{}, []byte{}, time.Time{}, time.Time{}, 0, 0, 0}
+	return &writeBuffer{[]history{}, []byte{}, time.Time{}, time.Time{}, 0, 0, 0, nil}
 }
 
 func (w *writeBuffer) write(ts time.Time, input string) {
@@ -59,6 +61,10 @@ func (w *writeBuffer) write(ts time.Time, input string) {
 
 	w.start = w.histories[0].ts
 	w.end = w.histories[len(w.histories)-1].ts
+
+	if w.onWrite != nil {
+		w.onWrite(ts, input)
+	}
 }
 
 func (w writeBuffer) inspect(ts time.Time) (int, int, bool) {
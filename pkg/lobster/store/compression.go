@@ -0,0 +1,295 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package store
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/klauspost/compress/s2"
+	"github.com/klauspost/compress/zstd"
+	"github.com/naver/lobster/pkg/lobster/logline"
+	"github.com/naver/lobster/pkg/lobster/util"
+)
+
+const (
+	CompressionNone   = "none"
+	CompressionZstd   = "zstd"
+	CompressionSnappy = "snappy"
+
+	codecZstd   byte = 1
+	codecSnappy byte = 2
+
+	blockFormatVersion byte = 1
+	frameEntrySize          = 48
+	blockTrailerSize        = 16
+)
+
+// A compressed block is a sequence of independently compressed frames
+// followed by a frame index and a fixed size trailer:
+//
+//	[frame 0]...[frame n-1][index entry 0]...[index entry n-1][trailer]
+//
+// Each index entry keeps the time range and the raw size of its frame,
+// so readers only decompress the frames overlapping a requested range.
+// Plain blocks never end with the trailer magic because every line ends with '\n'.
+var blockMagic = [8]byte{'L', 'O', 'B', 'S', 'T', 'E', 'R', 'Z'}
+
+var (
+	zstdEncoder, _ = zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedDefault))
+	zstdDecoder, _ = zstd.NewReader(nil)
+
+	errInvalidBlockIndex = errors.New("invalid block index")
+)
+
+type frame struct {
+	start          time.Time
+	end            time.Time
+	offset         int64
+	rawOffset      int64
+	compressedSize int64
+	rawSize        int64
+	lines          int64
+}
+
+type blockIndex struct {
+	codec  byte
+	frames []frame
+}
+
+func (i blockIndex) rawSize() (size int64) {
+	for _, f := range i.frames {
+		size = size + f.rawSize
+	}
+	return
+}
+
+func (i blockIndex) framesWithinRange(start, end time.Time) []frame {
+	frames := []frame{}
+	for _, f := range i.frames {
+		if f.end.Before(start) || f.start.After(end) {
+			continue
+		}
+		frames = append(frames, f)
+	}
+	return frames
+}
+
+func codecOf(compression string) (byte, error) {
+	switch compression {
+	case CompressionZstd:
+		return codecZstd, nil
+	case CompressionSnappy:
+		return codecSnappy, nil
+	case CompressionNone, "":
+		return 0, nil
+	default:
+		return 0, fmt.Errorf("unsupported block compression: %s", compression)
+	}
+}
+
+func encodeFrame(codec byte, dst, src []byte) []byte {
+	switch codec {
+	case codecSnappy:
+		return append(dst, s2.EncodeSnappy(nil, src)...)
+	default:
+		return zstdEncoder.EncodeAll(src, dst)
+	}
+}
+
+func decodeFrame(codec byte, dst, src []byte) ([]byte, error) {
+	switch codec {
+	case codecZstd:
+		return zstdDecoder.DecodeAll(src, dst[:0])
+	case codecSnappy:
+		n, err := s2.DecodedLen(src)
+		if err != nil {
+			return nil, err
+		}
+		if cap(dst) < n {
+			dst = make([]byte, n)
+		}
+		return s2.Decode(dst[:n], src)
+	default:
+		return nil, fmt.Errorf("unknown block codec: %d", codec)
+	}
+}
+
+// compressBlock splits plain log lines into frames of about frameSize bytes and compresses each of them.
+// Frames without timestamps take the range of the block from start to end.
+func compressBlock(compression string, data []byte, frameSize int64, start, end time.Time) ([]byte, error) {
+	codec, err := codecOf(compression)
+	if err != nil {
+		return nil, err
+	}
+	if codec == 0 {
+		return data, nil
+	}
+
+	var (
+		out     = make([]byte, 0, len(data)/4)
+		frames  = []frame{}
+		current = frame{}
+		begin   = 0
+		lastTs  time.Time
+	)
+
+	cut := func(end int) {
+		if end <= begin {
+			return
+		}
+		current.offset = int64(len(out))
+		out = encodeFrame(codec, out, data[begin:end])
+		current.compressedSize = int64(len(out)) - current.offset
+		current.rawSize = int64(end - begin)
+		frames = append(frames, current)
+		current = frame{}
+		begin = end
+	}
+
+	for pos := 0; pos < len(data); {
+		next := bytes.IndexByte(data[pos:], '\n')
+		if next < 0 {
+			next = len(data)
+		} else {
+			next = pos + next + 1
+		}
+
+		if ts, err := logline.ParseTimestamp(util.BytesToString(data[pos:next])); err == nil {
+			lastTs = ts
+		}
+		if !lastTs.IsZero() && (current.start.IsZero() || lastTs.Before(current.start)) {
+			current.start = lastTs
+		}
+		if lastTs.After(current.end) {
+			current.end = lastTs
+		}
+		current.lines = current.lines + 1
+		pos = next
+
+		if int64(pos-begin) >= frameSize {
+			cut(pos)
+		}
+	}
+	cut(len(data))
+
+	for i := range frames {
+		if frames[i].start.IsZero() {
+			frames[i].start, frames[i].end = start, end
+		}
+	}
+
+	for _, f := range frames {
+		out = binary.BigEndian.AppendUint64(out, uint64(f.start.UnixNano()))
+		out = binary.BigEndian.AppendUint64(out, uint64(f.end.UnixNano()))
+		out = binary.BigEndian.AppendUint64(out, uint64(f.offset))
+		out = binary.BigEndian.AppendUint64(out, uint64(f.compressedSize))
+		out = binary.BigEndian.AppendUint64(out, uint64(f.rawSize))
+		out = binary.BigEndian.AppendUint64(out, uint64(f.lines))
+	}
+
+	out = binary.BigEndian.AppendUint32(out, uint32(len(frames)))
+	out = append(out, codec, blockFormatVersion, 0, 0)
+	out = append(out, blockMagic[:]...)
+
+	return out, nil
+}
+
+func parseBlockTrailer(trailer []byte) (numOfFrames int, codec byte, ok bool) {
+	if len(trailer) < blockTrailerSize || !bytes.Equal(trailer[len(trailer)-len(blockMagic):], blockMagic[:]) {
+		return 0, 0, false
+	}
+	trailer = trailer[len(trailer)-blockTrailerSize:]
+	return int(binary.BigEndian.Uint32(trailer[0:4])), trailer[4], true
+}
+
+func decodeFrames(entries []byte, numOfFrames int) []frame {
+	var (
+		frames    = make([]frame, 0, numOfFrames)
+		rawOffset = int64(0)
+	)
+
+	for i := 0; i < numOfFrames; i++ {
+		entry := entries[i*frameEntrySize:]
+		frames = append(frames, frame{
+			rawOffset:      rawOffset,
+			start:          time.Unix(0, int64(binary.BigEndian.Uint64(entry[0:8]))),
+			end:            time.Unix(0, int64(binary.BigEndian.Uint64(entry[8:16]))),
+			offset:         int64(binary.BigEndian.Uint64(entry[16:24])),
+			compressedSize: int64(binary.BigEndian.Uint64(entry[24:32])),
+			rawSize:        int64(binary.BigEndian.Uint64(entry[32:40])),
+			lines:          int64(binary.BigEndian.Uint64(entry[40:48])),
+		})
+		rawOffset = rawOffset + frames[i].rawSize
+	}
+	return frames
+}
+
+// parseBlockIndex returns false if data is not a compressed block.
+func parseBlockIndex(data []byte) (blockIndex, bool, error) {
+	numOfFrames, codec, ok := parseBlockTrailer(data)
+	if !ok {
+		return blockIndex{}, false, nil
+	}
+
+	indexStart := len(data) - blockTrailerSize - numOfFrames*frameEntrySize
+	if indexStart < 0 {
+		return blockIndex{}, true, errInvalidBlockIndex
+	}
+
+	index := blockIndex{codec: codec, frames: decodeFrames(data[indexStart:], numOfFrames)}
+	for _, f := range index.frames {
+		if f.offset < 0 || f.compressedSize < 0 || f.offset+f.compressedSize > int64(indexStart) {
+			return blockIndex{}, true, errInvalidBlockIndex
+		}
+	}
+
+	return index, true, nil
+}
+
+// readBlockIndex reads only the index of a block file without loading frames.
+func readBlockIndex(f *os.File, size int64) (blockIndex, bool, error) {
+	trailer := make([]byte, blockTrailerSize)
+	if size < blockTrailerSize {
+		return blockIndex{}, false, nil
+	}
+	if _, err := f.ReadAt(trailer, size-blockTrailerSize); err != nil && err != io.EOF {
+		return blockIndex{}, false, err
+	}
+
+	numOfFrames, codec, ok := parseBlockTrailer(trailer)
+	if !ok {
+		return blockIndex{}, false, nil
+	}
+
+	entriesSize := int64(numOfFrames) * frameEntrySize
+	if entriesSize+blockTrailerSize > size {
+		return blockIndex{}, true, errInvalidBlockIndex
+	}
+
+	entries := make([]byte, entriesSize)
+	if _, err := f.ReadAt(entries, size-blockTrailerSize-entriesSize); err != nil && err != io.EOF {
+		return blockIndex{}, true, err
+	}
+
+	return blockIndex{codec: codec, frames: decodeFrames(entries, numOfFrames)}, true, nil
+}
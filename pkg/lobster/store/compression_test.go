@@ -0,0 +1,158 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package store
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/naver/lobster/pkg/lobster/logline"
+)
+
+func TestMain(m *testing.M) {
+	logline.Setup()
+	os.Exit(m.Run())
+}
+
+func makeTestBlockData(start time.Time, lines int) []byte {
+	buf := bytes.Buffer{}
+	for i := 0; i < lines; i++ {
+		ts := start.Add(time.Duration(i) * time.Second)
+		buf.WriteString(fmt.Sprintf("%s stdout F test log line %d\n", ts.Format(time.RFC3339Nano), i))
+	}
+	return buf.Bytes()
+}
+
+func TestCompressBlock(t *testing.T) {
+	start := time.Date(2024, 1, 24, 1, 0, 0, 0, time.UTC)
+	data := makeTestBlockData(start, 1000)
+
+	for _, compression := range []string{CompressionZstd, CompressionSnappy} {
+		sealed, err := compressBlock(compression, data, 4096, start, start.Add(999*time.Second))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		index, isCompressed, err := parseBlockIndex(sealed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !isCompressed {
+			t.Fatalf("%s block should be compressed", compression)
+		}
+		if index.rawSize() != int64(len(data)) {
+			t.Fatalf("raw size should be %d but %d", len(data), index.rawSize())
+		}
+
+		decoded := []byte{}
+		for _, f := range index.frames {
+			raw, err := decodeFrame(index.codec, nil, sealed[f.offset:f.offset+f.compressedSize])
+			if err != nil {
+				t.Fatal(err)
+			}
+			decoded = append(decoded, raw...)
+		}
+		if !bytes.Equal(decoded, data) {
+			t.Fatalf("decoded %s block is different from the original", compression)
+		}
+
+		frames := index.framesWithinRange(start.Add(500*time.Second), start.Add(510*time.Second))
+		if len(frames) == 0 || len(frames) == len(index.frames) {
+			t.Fatalf("frames within range should be a subset of %d frames but %d", len(index.frames), len(frames))
+		}
+	}
+}
+
+func TestCompressBlockWithoutTimestamps(t *testing.T) {
+	start := time.Date(2024, 1, 24, 1, 0, 0, 0, time.UTC)
+	end := start.Add(99 * time.Second)
+
+	buf := bytes.Buffer{}
+	for i := 0; i < 100; i++ {
+		buf.WriteString(fmt.Sprintf("line without timestamp %d\n", i))
+	}
+	untimed := buf.Len()
+	buf.Write(makeTestBlockData(start, 100))
+
+	sealed, err := compressBlock(CompressionZstd, buf.Bytes(), 512, start, end)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	index, _, err := parseBlockIndex(sealed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, f := range index.frames {
+		if f.rawOffset+f.rawSize > int64(untimed) {
+			break
+		}
+		if !f.start.Equal(start) || !f.end.Equal(end) {
+			t.Errorf("frames without timestamps should take the range of the block but %s ~ %s", f.start, f.end)
+		}
+	}
+
+	if frames := index.framesWithinRange(start, start); len(frames) == 0 || frames[0].rawOffset != 0 {
+		t.Errorf("frames without timestamps should be read at the start of the block: %v", frames)
+	}
+}
+
+func TestPlainBlockIsNotCompressed(t *testing.T) {
+	now := time.Now()
+	data := makeTestBlockData(now, 10)
+
+	sealed, err := compressBlock(CompressionNone, data, 4096, now, now.Add(9*time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, isCompressed, err := parseBlockIndex(sealed); err != nil || isCompressed {
+		t.Fatalf("plain block should not be compressed: %v", err)
+	}
+}
+
+func TestReadBlockIndex(t *testing.T) {
+	now := time.Now()
+	data := makeTestBlockData(now, 100)
+
+	sealed, err := compressBlock(CompressionZstd, data, 1024, now, now.Add(99*time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := os.CreateTemp(t.TempDir(), "block")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(sealed); err != nil {
+		t.Fatal(err)
+	}
+
+	index, isCompressed, err := readBlockIndex(f, int64(len(sealed)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isCompressed || index.rawSize() != int64(len(data)) {
+		t.Fatalf("raw size should be %d but %d", len(data), index.rawSize())
+	}
+}
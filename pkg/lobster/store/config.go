@@ -24,6 +24,7 @@ import (
 type config struct {
 	RetentionSize           *int64
 	RetentionTime           *time.Duration
+	RetentionPolicyFile     *string
 	StoreRootPath           *string
 	BlockSize               *int64
 	SoftLimitRatioForDisk   *float64
@@ -32,11 +33,17 @@ type config struct {
 	ReqCooldownDuration     *time.Duration
 	PageBurst               *int
 	LeakyBucketInterval     *time.Duration
+	LimitProfileFile        *string
+	BlockCompression        *string
+	BlockFrameSize          *int64
+	BlockIndexInterval      *int
+	BloomFalsePositiveRate  *float64
 }
 
 func setup() config {
 	retentionSize := flag.Int64("store.retentionSize", (1 << 31), "Max retention size per container logs")
 	retentionTime := flag.Duration("store.retentionTime", 7*24*time.Hour, "Max retention time to keep logs")
+	retentionPolicyFile := flag.String("store.retentionPolicyFile", "", "Path to a yaml file of retention policies for namespaces and pod labels")
 	storeRootPath := flag.String("store.storeRootPath", "/var/lobster/log", "Path to read/write blocks")
 	blockSize := flag.Int64("store.blockSize", (1 << 20), "Block size")
 	softLimitRatioForDisk := flag.Float64("store.softLimitForDisk", 0.5, "Size limit of log files")
@@ -45,10 +52,16 @@ func setup() config {
 	reqCooldownDuration := flag.Duration("store.request.cooldowDuration", 100*time.Millisecond, "Requests that reach the max burst are included in the limiter's count by the cooldown time.")
 	pageBurst := flag.Int("store.pageBurst", 1000, "Provide lines in and out of busrt per page")
 	leakyBucketInterval := flag.Duration("store.leakyBucketInterval", time.Second, "Interval of flusing logs")
+	limitProfileFile := flag.String("store.limitProfileFile", "", "Path to a yaml file of ingestion limit profiles for namespaces and pods")
+	blockCompression := flag.String("store.blockCompression", CompressionZstd, "Compression of sealed blocks: zstd, snappy or none")
+	blockFrameSize := flag.Int64("store.blockFrameSize", (1 << 16), "Uncompressed size of each frame in a compressed block")
+	blockIndexInterval := flag.Int("store.blockIndexInterval", 256, "Number of lines between entries of the time index of a block")
+	bloomFalsePositiveRate := flag.Float64("store.bloomFalsePositiveRate", 0.01, "False positive rate of bloom filters to skip blocks not containing include terms")
 
 	return config{
 		RetentionSize:           retentionSize,
 		RetentionTime:           retentionTime,
+		RetentionPolicyFile:     retentionPolicyFile,
 		StoreRootPath:           storeRootPath,
 		BlockSize:               blockSize,
 		SoftLimitRatioForDisk:   softLimitRatioForDisk,
@@ -57,5 +70,10 @@ func setup() config {
 		ReqCooldownDuration:     reqCooldownDuration,
 		PageBurst:               pageBurst,
 		LeakyBucketInterval:     leakyBucketInterval,
+		LimitProfileFile:        limitProfileFile,
+		BlockCompression:        blockCompression,
+		BlockFrameSize:          blockFrameSize,
+		BlockIndexInterval:      blockIndexInterval,
+		BloomFalsePositiveRate:  bloomFalsePositiveRate,
 	}
 }
@@ -0,0 +1,115 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package store
+
+import (
+	"time"
+
+	"github.com/naver/lobster/pkg/lobster/query"
+)
+
+type contextLine struct {
+	ts   time.Time
+	data []byte
+}
+
+// contextWindow keeps lines around matched logs across blocks of a chunk.
+// Lines are returned once, so overlapping windows of close matches are merged.
+type contextWindow struct {
+	before    int
+	after     int
+	remaining int
+	pending   []contextLine
+	// loads last lines of blocks skipped before pending lines
+	loadSkipped func(n int) []contextLine
+}
+
+func newContextWindow(req query.Request) *contextWindow {
+	if !req.HasContext() {
+		return nil
+	}
+
+	return &contextWindow{
+		before:  req.Before,
+		after:   req.After,
+		pending: make([]contextLine, 0, req.Before),
+	}
+}
+
+// follows returns true if the unmatched line is in the window after the last match.
+func (w *contextWindow) follows() bool {
+	if w.remaining == 0 {
+		return false
+	}
+
+	w.remaining = w.remaining - 1
+	return true
+}
+
+// keep holds the unmatched line as a candidate for the window before the next match.
+func (w *contextWindow) keep(ts time.Time, data []byte) {
+	if w.before == 0 {
+		return
+	}
+
+	if len(w.pending) == w.before {
+		copy(w.pending, w.pending[1:])
+		w.pending = w.pending[:len(w.pending)-1]
+	}
+
+	w.pending = append(w.pending, contextLine{ts, append([]byte(nil), data...)})
+}
+
+// skip marks a block which is not read since it can't have matches;
+// its last lines are loaded only if the next match needs them.
+func (w *contextWindow) skip(load func(n int) []contextLine) {
+	if w.before == 0 {
+		return
+	}
+
+	pending, loadEarlier := w.pending, w.loadSkipped
+	w.pending = make([]contextLine, 0, w.before)
+	w.loadSkipped = func(n int) []contextLine {
+		lines := load(n)
+		return append(lastLines(pending, loadEarlier, n-len(lines)), lines...)
+	}
+}
+
+// match returns lines before the match and opens the window after it.
+func (w *contextWindow) match() []contextLine {
+	lines := lastLines(w.pending, w.loadSkipped, w.before)
+	w.pending = make([]contextLine, 0, w.before)
+	w.loadSkipped = nil
+	w.remaining = w.after
+
+	return lines
+}
+
+// lastLines returns the last n lines of pending lines preceded by lines of skipped blocks.
+func lastLines(pending []contextLine, loadSkipped func(n int) []contextLine, n int) []contextLine {
+	if n <= 0 {
+		return nil
+	}
+	if len(pending) >= n {
+		return pending[len(pending)-n:]
+	}
+	if loadSkipped == nil {
+		return pending
+	}
+
+	return append(loadSkipped(n-len(pending)), pending...)
+}
@@ -0,0 +1,107 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package store
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/naver/lobster/pkg/lobster/model"
+	"github.com/naver/lobster/pkg/lobster/query"
+	"github.com/naver/lobster/pkg/lobster/util"
+)
+
+func TestScanBlockWithContext(t *testing.T) {
+	start := time.Date(2024, 1, 24, 1, 0, 0, 0, time.UTC)
+	messages := []string{"a", "b", "error 1", "c", "error 2", "d", "e", "f", "g", "error 3"}
+	data := bytes.Buffer{}
+	for i, msg := range messages {
+		data.WriteString(fmt.Sprintf("%s stdout F %s\n", start.Add(time.Duration(i)*time.Second).Format(time.RFC3339Nano), msg))
+	}
+
+	req := query.Request{
+		Start:             util.Timestamp{Time: start},
+		End:               util.Timestamp{Time: start.Add(time.Minute)},
+		FilterIncludeExpr: "error",
+		Before:            1,
+		After:             2,
+	}
+	if err := req.InitTextFilterer(); err != nil {
+		t.Fatal(err)
+	}
+
+	chunk := model.Chunk{Source: model.Source{Type: model.LogTypeStdStream}}
+	buffer := NewReadBuffer()
+	isStartFound := false
+
+	_, err := scanBlock(chunk, bufio.NewReader(&data), false, buffer, model.NewBucketBuilder(start, chunk), req, &isStartFound, newContextWindow(req))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := []string{}
+	for _, line := range strings.Split(strings.TrimSpace(buffer.buf.String()), "\n") {
+		fields := strings.SplitN(line, " ", 4)
+		prefix := ""
+		if strings.HasPrefix(line, model.ContextLinePrefix) {
+			prefix = model.ContextLinePrefix
+		}
+		got = append(got, prefix+fields[3])
+	}
+
+	// windows of error 1 and error 2 overlap and error 3 has only a line before it
+	expected := []string{"-b", "error 1", "-c", "error 2", "-d", "-e", "-g", "error 3"}
+	if strings.Join(got, ",") != strings.Join(expected, ",") {
+		t.Fatalf("expected %v but %v", expected, got)
+	}
+}
+
+func TestContextWindowSkip(t *testing.T) {
+	skipped := []contextLine{{data: []byte("b1")}, {data: []byte("b2")}}
+
+	for _, tc := range []struct {
+		before   int
+		expected string
+		loaded   bool
+	}{
+		{1, "c", false},
+		{3, "b1,b2,c", true},
+		{4, "a,b1,b2,c", true},
+	} {
+		loaded := false
+		window := newContextWindow(query.Request{Before: tc.before})
+		window.keep(time.Time{}, []byte("a"))
+		window.skip(func(n int) []contextLine {
+			loaded = true
+			return skipped[max(len(skipped)-n, 0):]
+		})
+		window.keep(time.Time{}, []byte("c"))
+
+		got := []string{}
+		for _, line := range window.match() {
+			got = append(got, string(line.data))
+		}
+
+		if strings.Join(got, ",") != tc.expected || loaded != tc.loaded {
+			t.Errorf("before %d: expected %s(loaded %t) but %v(loaded %t)", tc.before, tc.expected, tc.loaded, got, loaded)
+		}
+	}
+}
@@ -17,7 +17,26 @@
 package store
 
 import (
+	"fmt"
 	"sync"
+
+	"github.com/naver/lobster/pkg/lobster/model"
+	"github.com/naver/lobster/pkg/lobster/policy"
+)
+
+const (
+	// AnnotationLimitProfile selects a limit profile for pod logs by name.
+	AnnotationLimitProfile = "lobster.naver.com/limit-profile"
+
+	DefaultLimitProfile = "default"
+
+	OverflowStop   = "stop"
+	OverflowDrop   = "drop"
+	OverflowSample = "sample"
+	OverflowNewest = "newest"
+
+	defaultSampleRate  = 10
+	defaultNewestLines = 1000
 )
 
 type Limit struct {
@@ -70,11 +89,11 @@ type Limiter struct {
 	limits []*Limit
 }
 
-func NewLimiter() Limiter {
-	limits := []*Limit{
-		newLimit(999, 1000000, 30000, "1MB/s | 30k lines/s"),
-		newLimit(30, 20000000, 30000, "20MB/s | 30k lines/s"),
-		newLimit(30, 30000000, 30000, "30MB/s | 30k lines/s"),
+func newLimiter(tiers []LimitTier, prefix string) Limiter {
+	limits := []*Limit{}
+
+	for _, tier := range tiers {
+		limits = append(limits, newLimit(tier.Capacity, tier.Size, tier.Lines, prefix+tier.description()))
 	}
 
 	if len(limits) == 0 {
@@ -117,3 +136,183 @@ func (l Limiter) getDefaultLimit() *Limit {
 	l.limits[0].use()
 	return l.limits[0]
 }
+
+// LimitTier is a level of bytes and lines per store.leakyBucketInterval shared by up to capacity containers.
+type LimitTier struct {
+	Capacity int   `json:"capacity"`
+	Size     int64 `json:"size"`
+	Lines    int64 `json:"lines"`
+}
+
+func (t LimitTier) description() string {
+	return fmt.Sprintf("%s/s | %s lines/s", humanize(t.Size, "B"), humanize(t.Lines, ""))
+}
+
+func humanize(value int64, unit string) string {
+	switch {
+	case value >= 1000000 && value%1000000 == 0:
+		return fmt.Sprintf("%dM%s", value/1000000, unit)
+	case value >= 1000 && value%1000 == 0:
+		return fmt.Sprintf("%dk%s", value/1000, unit)
+	}
+	return fmt.Sprintf("%d%s", value, unit)
+}
+
+func defaultLimitTiers() []LimitTier {
+	return []LimitTier{
+		{Capacity: 999, Size: 1000000, Lines: 30000},
+		{Capacity: 30, Size: 20000000, Lines: 30000},
+		{Capacity: 30, Size: 30000000, Lines: 30000},
+	}
+}
+
+// LimitProfile limits logs of containers in namespaces or of pods selecting it by the annotation.
+type LimitProfile struct {
+	Name       string      `json:"name"`
+	Namespaces []string    `json:"namespaces,omitempty"`
+	Tiers      []LimitTier `json:"tiers,omitempty"`
+	// What to do with logs exceeding the limit; stop(default) tailing, drop, sample or newest
+	Overflow string `json:"overflow,omitempty"`
+	// Keep 1 in N logs exceeding the limit for sample
+	SampleRate int `json:"sampleRate,omitempty"`
+	// Keep the newest N logs exceeding the limit in an interval for newest
+	NewestLines int `json:"newestLines,omitempty"`
+
+	limiter Limiter
+}
+
+func (p *LimitProfile) init() error {
+	if len(p.Name) == 0 {
+		return fmt.Errorf("limit profile requires a name")
+	}
+
+	if len(p.Tiers) == 0 {
+		p.Tiers = defaultLimitTiers()
+	}
+
+	for _, tier := range p.Tiers {
+		if tier.Capacity <= 0 || tier.Size <= 0 || tier.Lines <= 0 {
+			return fmt.Errorf("invalid tier of limit profile %q", p.Name)
+		}
+	}
+
+	switch p.Overflow {
+	case "":
+		p.Overflow = OverflowStop
+	case OverflowStop, OverflowDrop, OverflowSample, OverflowNewest:
+	default:
+		return fmt.Errorf("unsupported overflow %q of limit profile %q", p.Overflow, p.Name)
+	}
+
+	if p.SampleRate <= 0 {
+		p.SampleRate = defaultSampleRate
+	}
+	if p.NewestLines <= 0 {
+		p.NewestLines = defaultNewestLines
+	}
+
+	prefix := ""
+	if p.Name != DefaultLimitProfile {
+		prefix = p.Name + ": "
+	}
+	p.limiter = newLimiter(p.Tiers, prefix)
+
+	return nil
+}
+
+// LimitStat is the usage of a tier of a limit profile.
+type LimitStat struct {
+	Profile     string `json:"profile"`
+	Overflow    string `json:"overflow"`
+	Description string `json:"description"`
+	Capacity    int    `json:"capacity"`
+	Used        int    `json:"used"`
+	Size        int64  `json:"size"`
+	Lines       int64  `json:"lines"`
+}
+
+// LimitProfiles selects a profile of a chunk by the annotation of the pod and then by namespaces.
+type LimitProfiles struct {
+	profiles []*LimitProfile
+}
+
+func NewLimitProfiles(profiles []*LimitProfile) (LimitProfiles, error) {
+	hasDefault := false
+
+	for _, profile := range profiles {
+		if err := profile.init(); err != nil {
+			return LimitProfiles{}, err
+		}
+		hasDefault = hasDefault || profile.Name == DefaultLimitProfile
+	}
+
+	if !hasDefault {
+		profile := &LimitProfile{Name: DefaultLimitProfile}
+		if err := profile.init(); err != nil {
+			return LimitProfiles{}, err
+		}
+		profiles = append(profiles, profile)
+	}
+
+	return LimitProfiles{profiles}, nil
+}
+
+// LoadLimitProfiles reads profiles from a yaml or json file; only the default profile is used if path is empty.
+func LoadLimitProfiles(path string) (LimitProfiles, error) {
+	profiles, err := policy.Load[*LimitProfile](path)
+	if err != nil {
+		return LimitProfiles{}, err
+	}
+
+	return NewLimitProfiles(profiles)
+}
+
+func (p LimitProfiles) Of(chunk model.Chunk) *LimitProfile {
+	if name, ok := chunk.Annotations[AnnotationLimitProfile]; ok {
+		for _, profile := range p.profiles {
+			if profile.Name == name {
+				return profile
+			}
+		}
+	}
+
+	for _, profile := range p.profiles {
+		for _, ns := range profile.Namespaces {
+			if ns == chunk.Namespace {
+				return profile
+			}
+		}
+	}
+
+	return p.get(DefaultLimitProfile)
+}
+
+func (p LimitProfiles) get(name string) *LimitProfile {
+	for _, profile := range p.profiles {
+		if profile.Name == name {
+			return profile
+		}
+	}
+	return nil
+}
+
+func (p LimitProfiles) GetLimits() []*Limit {
+	limits := []*Limit{}
+	for _, profile := range p.profiles {
+		limits = append(limits, profile.limiter.GetLimits()...)
+	}
+	return limits
+}
+
+func (p LimitProfiles) Stats() []LimitStat {
+	stats := []LimitStat{}
+
+	for _, profile := range p.profiles {
+		for _, limit := range profile.limiter.GetLimits() {
+			cap, used, size, lines, description := limit.Stat()
+			stats = append(stats, LimitStat{profile.Name, profile.Overflow, description, cap, used, size, lines})
+		}
+	}
+
+	return stats
+}
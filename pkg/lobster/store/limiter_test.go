@@ -17,12 +17,23 @@
 package store
 
 import (
-	"sort"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/naver/lobster/pkg/lobster/model"
 )
 
 func TestLimiter(t *testing.T) {
-	lt := NewLimiter()
+	profiles, err := NewLimitProfiles(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lt := profiles.Of(model.Chunk{}).limiter
 	burst := int64(1500)
 	count := 30
 	receiver := make(chan int64)
@@ -30,18 +41,97 @@ func TestLimiter(t *testing.T) {
 	for i := 0; i < count; i++ {
 		go func() {
 			receiver <- lt.getLimit(burst).size
-
 		}()
 	}
 
-	values := []int64{}
 	for i := 0; i < count; i++ {
-		values = append(values, <-receiver)
+		if size := <-receiver; size != defaultLimitTiers()[0].Size {
+			t.Errorf("expected the first tier of %d but got %d", defaultLimitTiers()[0].Size, size)
+		}
 	}
+}
 
-	sort.Slice(values, func(i, j int) bool {
-		return i < j
+func TestLimitProfilesOf(t *testing.T) {
+	profiles, err := NewLimitProfiles([]*LimitProfile{
+		{Name: "ci", Namespaces: []string{"ci"}, Overflow: OverflowDrop, Tiers: []LimitTier{{Capacity: 10, Size: 1000, Lines: 10}}},
+		{Name: "debug", Overflow: OverflowNewest},
 	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		chunk    model.Chunk
+		expected string
+	}{
+		{model.Chunk{Namespace: "ci"}, "ci"},
+		{model.Chunk{Namespace: "ci", Annotations: model.Labels{AnnotationLimitProfile: "debug"}}, "debug"},
+		{model.Chunk{Namespace: "pay", Annotations: model.Labels{AnnotationLimitProfile: "unknown"}}, DefaultLimitProfile},
+	}
+
+	for _, test := range tests {
+		if profile := profiles.Of(test.chunk); profile.Name != test.expected {
+			t.Fatalf("%v: expected %s but %s", test.chunk, test.expected, profile.Name)
+		}
+	}
+
+	if stats := profiles.Stats(); len(stats) != 7 || stats[0].Description != "ci: 1kB/s | 10 lines/s" || stats[len(stats)-1].Description != "30MB/s | 30k lines/s" {
+		t.Fatalf("unexpected stats %v", stats)
+	}
+
+	if _, err := NewLimitProfiles([]*LimitProfile{{Name: "invalid", Overflow: "block"}}); err == nil {
+		t.Fatal("unsupported overflow should fail")
+	}
+}
+
+func TestLeakyBucketOverflow(t *testing.T) {
+	tiers := []LimitTier{{Capacity: 1, Size: 1000, Lines: 3}}
+	now := time.Now()
+
+	for _, test := range []struct {
+		overflow string
+		written  []int
+		dropped  string
+	}{
+		{OverflowDrop, []int{0, 1, 2}, "(lobster: 7 of 7 logs"},
+		{OverflowSample, []int{0, 1, 2, 4, 6, 8}, "(lobster: 4 of 7 logs"},
+		{OverflowNewest, []int{0, 1, 2, 8, 9}, "(lobster: 5 of 7 logs"},
+	} {
+		profiles, err := NewLimitProfiles([]*LimitProfile{{Name: DefaultLimitProfile, Tiers: tiers, Overflow: test.overflow, SampleRate: 2, NewestLines: 2}})
+		if err != nil {
+			t.Fatal(err)
+		}
 
-	t.Log(values)
+		buf := emptyWriteBuffer()
+		bucket := NewLeakyBucket(profiles, &model.Chunk{}, time.Second)
+		bucket.Init(now)
+
+		for i := 0; i < 10; i++ {
+			ts := now.Add(time.Duration(i) * time.Millisecond)
+			msg := fmt.Sprintf("%d\n", i)
+
+			if ok, _ := bucket.Pour(int64(len(msg))); ok || bucket.Overflow(ts, msg) {
+				buf.write(ts, msg)
+			}
+		}
+		bucket.flushOverflow(buf)
+		bucket.Release()
+
+		written, marker := []int{}, ""
+		for _, line := range strings.Split(strings.TrimSpace(buf.string()), "\n") {
+			n, err := strconv.Atoi(line)
+			if err != nil {
+				marker = line
+				continue
+			}
+			written = append(written, n)
+		}
+
+		if !reflect.DeepEqual(written, test.written) {
+			t.Fatalf("%s: expected %v but %v", test.overflow, test.written, written)
+		}
+		if !strings.Contains(marker, test.dropped) {
+			t.Fatalf("%s: unexpected marker %s", test.overflow, marker)
+		}
+	}
 }
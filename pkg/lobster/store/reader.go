@@ -19,10 +19,12 @@ package store
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -30,6 +32,7 @@ import (
 
 	"github.com/goccy/go-json"
 	"github.com/golang/glog"
+	"github.com/naver/lobster/pkg/lobster/coldtier"
 	"github.com/naver/lobster/pkg/lobster/logline"
 	"github.com/naver/lobster/pkg/lobster/model"
 	"github.com/naver/lobster/pkg/lobster/query"
@@ -51,6 +54,12 @@ var (
 			return newLargeBlockReader()
 		},
 	}
+	frameBufferPool = sync.Pool{
+		New: func() interface{} {
+			buf := make([]byte, 0, 2*(1<<16))
+			return &buf
+		},
+	}
 	readerBufferSize           = 16 * 1024
 	blockBufferSize      int64 = 4 * 1024 * 1024  // 4mb
 	largeBlockBufferSize int64 = 30 * 1024 * 1024 // 30mb
@@ -59,6 +68,20 @@ var (
 type ReadBuffer struct {
 	start, end time.Time
 	buf        *bytes.Buffer
+	// skips logs at skipTs returned in previous pages and stops at limit logs for cursors
+	skipTs time.Time
+	skip   int
+	limit  int
+	lines  int
+	// holds logs of a block to write them in reverse for backward
+	backward bool
+	held     []heldLine
+}
+
+type heldLine struct {
+	ts        time.Time
+	data      []byte
+	isContext bool
 }
 
 func NewReadBuffer() *ReadBuffer {
@@ -67,6 +90,57 @@ func NewReadBuffer() *ReadBuffer {
 	}
 }
 
+func newRequestReadBuffer(chunk model.Chunk, req query.Request) (*ReadBuffer, error) {
+	buffer := NewReadBuffer()
+	buffer.backward = req.IsBackward()
+
+	if !req.HasCursor() {
+		return buffer, nil
+	}
+
+	cursor, err := query.ParseCursor(req.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	buffer.skipTs = cursor.Timestamp
+	buffer.skip = cursor.Skip(chunk.Key())
+	buffer.limit = req.Burst
+	if buffer.limit == 0 {
+		buffer.limit = *conf.PageBurst
+	}
+
+	return buffer, nil
+}
+
+// accept returns false if the log at ts was returned in previous pages.
+func (rb *ReadBuffer) accept(ts time.Time) bool {
+	if rb.skip > 0 && ts.Equal(rb.skipTs) {
+		rb.skip = rb.skip - 1
+		return false
+	}
+
+	rb.lines = rb.lines + 1
+	return true
+}
+
+func (rb *ReadBuffer) isFull() bool {
+	return rb.limit > 0 && rb.lines >= rb.limit
+}
+
+// flush writes logs held from a block newest first.
+func (rb *ReadBuffer) flush(chunk model.Chunk, req query.Request) error {
+	for i := len(rb.held) - 1; i >= 0 && !rb.isFull(); i-- {
+		if err := emitLine(rb, chunk, rb.held[i].ts, rb.held[i].data, rb.held[i].isContext, req); err != nil {
+			return err
+		}
+	}
+
+	rb.held = rb.held[:0]
+
+	return nil
+}
+
 func (rb *ReadBuffer) Write(ts time.Time, data []byte) (int, error) {
 	if rb.start.IsZero() {
 		rb.start = ts
@@ -114,11 +188,15 @@ func loadBlocks(files []model.LogFile, conf config, blockFileFunc func(block mod
 			continue
 		}
 
+		if err := rebuildBlockSidecarsIfNotExist(dir, *block); err != nil {
+			glog.Errorf("failed to rebuild sidecars for %s : %s", file.Path, err.Error())
+		}
+
 		blockFileFunc(block, cp, file)
 	}
 }
 
-func fileToBlock(file model.LogFile) (*model.Block, error) {
+func fileToBlock(file model.LogFile) (block *model.Block, err error) {
 	tokens := strings.Split(strings.ReplaceAll(file.FileName, BlockExt, ""), "_")
 
 	start, err := time.Parse(time.RFC3339Nano, tokens[0])
@@ -141,12 +219,31 @@ func fileToBlock(file model.LogFile) (*model.Block, error) {
 		return nil, err
 	}
 
-	stat, err := os.Stat(file.Path)
+	f, err := os.Open(file.Path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if cErr := f.Close(); cErr != nil && err == nil {
+			block, err = nil, cErr
+		}
+	}()
+
+	stat, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	size := stat.Size()
+	index, isCompressed, err := readBlockIndex(f, stat.Size())
 	if err != nil {
 		return nil, err
 	}
+	if isCompressed {
+		size = index.rawSize()
+	}
 
-	return model.NewBlock(start, end, line, stat.Size(), fileNum), nil
+	return model.NewBlock(start, end, line, size, stat.Size(), fileNum), nil
 }
 
 func loadTempBlock(filePath string, fileNum int64) (*model.TempBlock, error) {
@@ -230,8 +327,7 @@ func loadTempBlock(filePath string, fileNum int64) (*model.TempBlock, error) {
 	return &model.TempBlock{StartedAt: start, EndedAt: end, Line: line, Size: size, FileNum: fileNum}, nil
 }
 
-func readBlocks(chunk model.Chunk, storeRootkDir string, onlySeries bool, req query.Request) (*ReadBuffer, []model.Bucket, error) {
-	buffer := NewReadBuffer()
+func readBlocks(ctx context.Context, chunk model.Chunk, storeRootkDir string, cold *coldtier.Client, onlySeries bool, req query.Request) (*ReadBuffer, []model.Bucket, error) {
 	blocks := chunk.GetBlocksAfterTime(req.Start.Time)
 	bucketBuilder := model.NewBucketBuilder(req.Start.Time, chunk)
 
@@ -239,18 +335,63 @@ func readBlocks(chunk model.Chunk, storeRootkDir string, onlySeries bool, req qu
 		return nil, []model.Bucket{}, errors.New("invalid range")
 	}
 
+	buffer, err := newRequestReadBuffer(chunk, req)
+	if err != nil {
+		return nil, []model.Bucket{}, err
+	}
+
+	// series are built in time order, so only logs are read backward
+	if req.IsBackward() && !onlySeries {
+		slices.Reverse(blocks)
+	}
+
+	blockDir := fmt.Sprintf("%s/%s", storeRootkDir, chunk.RelativeBlockDir)
+	literals := req.IncludeLiterals()
+
+	var window *contextWindow
+	if !onlySeries {
+		window = newContextWindow(req)
+	}
+
 	for _, block := range blocks {
+		if buffer.isFull() {
+			break
+		}
+
+		if err := ctx.Err(); err != nil {
+			return nil, []model.Bucket{}, err
+		}
+
 		if !block.StartTime().Before(req.End.Time) || !block.EndTime().After(req.Start.Time) {
 			continue
 		}
 
-		skip, err := readBlock(chunk, block, fmt.Sprintf("%s/%s/%s", storeRootkDir, chunk.RelativeBlockDir, block.FileName()), onlySeries, buffer, bucketBuilder, req)
+		// blocks without matches are read only for context lines after a match in the previous block
+		if !blockMayContain(blockDir, block, literals) && (window == nil || req.IsBackward() || window.remaining == 0) {
+			if window != nil && !req.IsBackward() {
+				window.skip(func(n int) []contextLine {
+					return readLastLines(chunk, block, blockDir, cold, req, n)
+				})
+			}
+			continue
+		}
+
+		// context windows don't continue across blocks read in reverse
+		if req.IsBackward() && window != nil {
+			window = newContextWindow(req)
+		}
+
+		skip, err := readBlock(chunk, block, blockDir, cold, onlySeries, buffer, bucketBuilder, req, window)
 		if skip {
 			continue
 		}
 		if err != nil {
 			return nil, []model.Bucket{}, err
 		}
+
+		if err := buffer.flush(chunk, req); err != nil {
+			return nil, []model.Bucket{}, err
+		}
 	}
 
 	bucketBuilder.Save()
@@ -258,12 +399,32 @@ func readBlocks(chunk model.Chunk, storeRootkDir string, onlySeries bool, req qu
 	return buffer, bucketBuilder.Build(), nil
 }
 
-func readBlock(chunk model.Chunk, block model.ReadableBlock, blockPath string, onlySeries bool, buffer *ReadBuffer, bucketBuilder *model.BucketBuilder, req query.Request) (bool, error) {
+// readLastLines returns the last n lines of a block having no matches as context lines.
+func readLastLines(chunk model.Chunk, block model.ReadableBlock, blockDir string, cold *coldtier.Client, req query.Request, n int) []contextLine {
+	window := &contextWindow{before: n, pending: make([]contextLine, 0, n)}
+
+	if _, err := readBlock(chunk, block, blockDir, cold, false, NewReadBuffer(), model.NewBucketBuilder(req.Start.Time, chunk), req, window); err != nil {
+		glog.Error(err)
+	}
+
+	return window.pending
+}
+
+func readBlock(chunk model.Chunk, block model.ReadableBlock, blockDir string, cold *coldtier.Client, onlySeries bool, buffer *ReadBuffer, bucketBuilder *model.BucketBuilder, req query.Request, window *contextWindow) (bool, error) {
+	if chunk.Cold {
+		return readColdBlock(chunk, block, cold, onlySeries, buffer, bucketBuilder, req, window)
+	}
+
 	var (
-		blkReader    *blockReader
-		isStartFound bool
+		blkReader *blockReader
+		blockPath = fmt.Sprintf("%s/%s", blockDir, block.FileName())
+		offset    = seekBlock(blockDir, block, req.Start.Time)
 	)
 
+	if skip, isCompressed, err := readCompressedBlock(chunk, block, blockPath, offset, onlySeries, buffer, bucketBuilder, req, window); isCompressed {
+		return skip, err
+	}
+
 	f, err := directio.OpenFile(blockPath, os.O_RDONLY, 0)
 	if err != nil {
 		glog.V(3).Infof("the block may have been removed by gc %s", blockPath)
@@ -294,10 +455,156 @@ func readBlock(chunk model.Chunk, block model.ReadableBlock, blockPath string, o
 		return true, nil
 	}
 
-	reader := blkReader.reader
-	reader.Reset(bytes.NewReader(blkReader.block[:numOfBytes]))
+	return scanBlockData(chunk, blkReader.reader, blkReader.block[:numOfBytes], offset, blockPath, onlySeries, buffer, bucketBuilder, req, window)
+}
+
+// readCompressedBlock reads the index of a block and then only frames within range;
+// it returns false for isCompressed if the block is plain, which is read with direct io.
+func readCompressedBlock(chunk model.Chunk, block model.ReadableBlock, blockPath string, offset int64, onlySeries bool, buffer *ReadBuffer, bucketBuilder *model.BucketBuilder, req query.Request, window *contextWindow) (skip bool, isCompressed bool, err error) {
+	f, err := os.Open(blockPath)
+	if err != nil {
+		glog.V(3).Infof("the block may have been removed by gc %s", blockPath)
+		return true, true, nil
+	}
+	defer func() {
+		err = errors.Join(err, f.Close())
+	}()
+
+	stat, err := f.Stat()
+	if err != nil {
+		glog.V(3).Infof("%v | failed to get stats %s", err, blockPath)
+		return true, true, nil
+	}
+
+	index, isCompressed, err := readBlockIndex(f, stat.Size())
+	if err != nil {
+		glog.V(3).Infof("%v | %s", err, blockPath)
+		return true, true, nil
+	}
+	if !isCompressed {
+		return false, false, nil
+	}
+
+	bucketBuilder.Reset(block.FileNumber(), block.StartTime())
+
+	blkReader := readerPool.Get().(*blockReader)
+	defer readerPool.Put(blkReader)
+
+	compressed := []byte{}
+	skip, err = scanFrames(chunk, blkReader.reader, index, func(frame frame) ([]byte, error) {
+		if int64(cap(compressed)) < frame.compressedSize {
+			compressed = make([]byte, frame.compressedSize)
+		}
+		compressed = compressed[:frame.compressedSize]
+		if _, err := f.ReadAt(compressed, frame.offset); err != nil && err != io.EOF {
+			return nil, err
+		}
+		return compressed, nil
+	}, offset, onlySeries, buffer, bucketBuilder, req, window)
+
+	return skip, true, err
+}
+
+// readColdBlock reads a block offloaded to the cold tier; it has no sidecars, so it is read from the beginning.
+func readColdBlock(chunk model.Chunk, block model.ReadableBlock, cold *coldtier.Client, onlySeries bool, buffer *ReadBuffer, bucketBuilder *model.BucketBuilder, req query.Request, window *contextWindow) (bool, error) {
+	b, ok := block.(*model.Block)
+	if !ok || cold == nil {
+		return true, nil
+	}
+
+	data, err := cold.Get(chunk, *b)
+	if err != nil {
+		glog.V(3).Infof("%v | failed to get cold block %s", err, b.FileName())
+		return true, nil
+	}
+
+	bucketBuilder.Reset(block.FileNumber(), block.StartTime())
+
+	blkReader := readerPool.Get().(*blockReader)
+	defer readerPool.Put(blkReader)
+
+	return scanBlockData(chunk, blkReader.reader, data, 0, b.FileName(), onlySeries, buffer, bucketBuilder, req, window)
+}
+
+// scanBlockData scans the whole data of a block from offset, decoding frames if it is compressed.
+func scanBlockData(chunk model.Chunk, reader *bufio.Reader, data []byte, offset int64, name string, onlySeries bool, buffer *ReadBuffer, bucketBuilder *model.BucketBuilder, req query.Request, window *contextWindow) (bool, error) {
+	var isStartFound bool
+
 	defer reader.Reset(nil)
 
+	index, isCompressed, err := parseBlockIndex(data)
+	if err != nil {
+		glog.V(3).Infof("%v | %s", err, name)
+		return true, nil
+	}
+
+	if !isCompressed {
+		if offset < int64(len(data)) {
+			data = data[offset:]
+		}
+		reader.Reset(bytes.NewReader(data))
+		_, err = scanBlock(chunk, reader, onlySeries, buffer, bucketBuilder, req, &isStartFound, window)
+		return false, err
+	}
+
+	return scanFrames(chunk, reader, index, func(frame frame) ([]byte, error) {
+		return data[frame.offset : frame.offset+frame.compressedSize], nil
+	}, offset, onlySeries, buffer, bucketBuilder, req, window)
+}
+
+// scanFrames decodes and scans frames of a compressed block within range, which are given by readFrame.
+func scanFrames(chunk model.Chunk, reader *bufio.Reader, index blockIndex, readFrame func(frame) ([]byte, error), offset int64, onlySeries bool, buffer *ReadBuffer, bucketBuilder *model.BucketBuilder, req query.Request, window *contextWindow) (bool, error) {
+	var isStartFound bool
+
+	defer reader.Reset(nil)
+
+	frameBuffer := frameBufferPool.Get().(*[]byte)
+	defer frameBufferPool.Put(frameBuffer)
+
+	for _, frame := range index.framesWithinRange(req.Start.Time, req.End.Time) {
+		compressed, err := readFrame(frame)
+		if err != nil {
+			return false, err
+		}
+
+		raw, err := decodeFrame(index.codec, *frameBuffer, compressed)
+		if err != nil {
+			return false, err
+		}
+		*frameBuffer = raw
+
+		if offset > frame.rawOffset && offset < frame.rawOffset+int64(len(raw)) {
+			raw = raw[offset-frame.rawOffset:]
+		}
+
+		reader.Reset(bytes.NewReader(raw))
+		done, err := scanBlock(chunk, reader, onlySeries, buffer, bucketBuilder, req, &isStartFound, window)
+		if err != nil || done {
+			return false, err
+		}
+	}
+
+	return false, nil
+}
+
+// seekBlock returns the logical offset of the block to start reading from by its time index.
+func seekBlock(blockDir string, block model.ReadableBlock, start time.Time) int64 {
+	b, ok := block.(*model.Block)
+	if !ok {
+		return 0
+	}
+
+	index, err := loadTimeIndex(blockDir, *b)
+	if err != nil {
+		return 0
+	}
+
+	return index.seek(start)
+}
+
+// scanBlock filters logs from reader and returns true if no more logs are needed.
+// Unmatched lines around matched logs are also returned as context if window is given.
+func scanBlock(chunk model.Chunk, reader *bufio.Reader, onlySeries bool, buffer *ReadBuffer, bucketBuilder *model.BucketBuilder, req query.Request, isStartFound *bool, window *contextWindow) (bool, error) {
 	for {
 		readBuffer, err := readBytes(reader, '\n')
 		if err != nil {
@@ -308,7 +615,7 @@ func readBlock(chunk model.Chunk, block model.ReadableBlock, blockPath string, o
 		}
 
 		if len(readBuffer) < logline.MinTimestampLen {
-			break // incompleted input (some logs are filed too fast)
+			return true, nil // incompleted input (some logs are filed too fast)
 		}
 
 		ts, err := logline.ParseTimestamp(util.BytesToString(readBuffer))
@@ -317,13 +624,13 @@ func readBlock(chunk model.Chunk, block model.ReadableBlock, blockPath string, o
 			continue
 		}
 
-		if !isStartFound && ts.Before(req.Start.Time) {
+		if !*isStartFound && ts.Before(req.Start.Time) {
 			continue
 		}
-		isStartFound = true
+		*isStartFound = true
 
 		if ts.After(req.End.Time) {
-			break
+			return true, nil
 		}
 
 		var msg string
@@ -334,12 +641,27 @@ func readBlock(chunk model.Chunk, block model.ReadableBlock, blockPath string, o
 			continue
 		}
 
-		result, err := filter.DoFilter(msg, ts, req.Filterers...)
+		result, err := filter.DoFilter(model.ExpandMultiline(msg), ts, req.Filterers...)
 		if err != nil {
 			return false, err
 		}
 
 		if result == filter.Filtered {
+			if window == nil || ts.Before(req.Start.Time) {
+				continue
+			}
+
+			if !window.follows() {
+				window.keep(ts, readBuffer)
+				continue
+			}
+
+			if err := writeLine(buffer, chunk, ts, readBuffer, true, req); err != nil {
+				return false, err
+			}
+			if buffer.isFull() {
+				return true, nil
+			}
 			continue
 		}
 
@@ -349,7 +671,7 @@ func readBlock(chunk model.Chunk, block model.ReadableBlock, blockPath string, o
 		bucketBuilder.Pour(uint64(len(msg)))
 
 		if result == filter.Done {
-			break
+			return true, nil
 		}
 
 		if result == filter.SkipRead {
@@ -360,26 +682,63 @@ func readBlock(chunk model.Chunk, block model.ReadableBlock, blockPath string, o
 			continue
 		}
 
-		if req.EnableLogEntryFormat {
-			data, err := json.Marshal(model.NewEntry(ts, chunk, string(readBuffer)))
-			if err != nil {
-				glog.Error(err)
-				continue
+		if window != nil {
+			for _, line := range window.match() {
+				if err := writeLine(buffer, chunk, line.ts, line.data, true, req); err != nil {
+					return false, err
+				}
 			}
+		}
 
-			if _, err := buffer.Write(ts, append(data, '\n')); err != nil {
-				return false, err
-			}
-		} else {
-			if _, err := buffer.Write(ts, readBuffer); err != nil {
-				return false, err
-			}
+		if err := writeLine(buffer, chunk, ts, readBuffer, false, req); err != nil {
+			return false, err
+		}
+		if buffer.isFull() {
+			return true, nil
 		}
 	}
 
 	return false, nil
 }
 
+func writeLine(buffer *ReadBuffer, chunk model.Chunk, ts time.Time, line []byte, isContext bool, req query.Request) error {
+	if buffer.backward {
+		buffer.held = append(buffer.held, heldLine{ts, append([]byte(nil), line...), isContext})
+		return nil
+	}
+
+	return emitLine(buffer, chunk, ts, line, isContext, req)
+}
+
+func emitLine(buffer *ReadBuffer, chunk model.Chunk, ts time.Time, line []byte, isContext bool, req query.Request) error {
+	if !buffer.accept(ts) {
+		return nil
+	}
+
+	if req.EnableLogEntryFormat {
+		entry := model.NewEntry(ts, chunk, model.ExpandMultiline(string(line)))
+		entry.Context = isContext
+
+		data, err := json.Marshal(entry)
+		if err != nil {
+			glog.Error(err)
+			return nil
+		}
+
+		_, err = buffer.Write(ts, append(data, '\n'))
+		return err
+	}
+
+	if isContext {
+		if _, err := buffer.Write(ts, []byte(model.ContextLinePrefix)); err != nil {
+			return err
+		}
+	}
+
+	_, err := buffer.Write(ts, line)
+	return err
+}
+
 func readBytes(reader *bufio.Reader, delim byte) ([]byte, error) {
 	buf, err := reader.ReadSlice(delim)
 	if err != bufio.ErrBufferFull {
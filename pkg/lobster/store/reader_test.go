@@ -0,0 +1,139 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package store
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/naver/lobster/pkg/lobster/model"
+	"github.com/naver/lobster/pkg/lobster/query"
+	"github.com/naver/lobster/pkg/lobster/util"
+)
+
+func TestReadBufferBackwardFromCursor(t *testing.T) {
+	start := time.Date(2024, 1, 24, 1, 0, 0, 0, time.UTC)
+	at := func(sec int) time.Time { return start.Add(time.Duration(sec) * time.Second) }
+	chunk := model.Chunk{Namespace: "ns", Pod: "pod", Container: "app", Source: model.Source{Type: model.LogTypeStdStream}}
+
+	// the previous page returned the last log at 3s
+	req := query.Request{
+		Start:     util.Timestamp{Time: start},
+		End:       util.Timestamp{Time: at(10)},
+		Burst:     3,
+		Direction: query.DirectionBackward,
+		Cursor:    query.Cursor{Timestamp: at(3), Skips: map[string]int{chunk.Key(): 1}}.String(),
+	}
+
+	buffer, err := newRequestReadBuffer(chunk, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, sec := range []int{0, 1, 2, 3, 3} {
+		if err := writeLine(buffer, chunk, at(sec), []byte(string(rune('a'+i))+"\n"), false, req); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := buffer.flush(chunk, req); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := strings.ReplaceAll(buffer.buf.String(), "\n", ""); got != "dcb" {
+		t.Fatalf("expected dcb but %s", got)
+	}
+	if !buffer.isFull() {
+		t.Fatal("buffer should be full by burst")
+	}
+}
+
+func TestReadBlocksCanceled(t *testing.T) {
+	start := time.Date(2024, 1, 24, 1, 0, 0, 0, time.UTC)
+	chunk := model.Chunk{
+		Namespace: "ns",
+		Pod:       "pod",
+		Container: "app",
+		Source:    model.Source{Type: model.LogTypeStdStream},
+		Blocks:    []*model.Block{{StartedAt: start, EndedAt: start.Add(time.Minute)}},
+		TempBlock: &model.TempBlock{StartedAt: start.Add(time.Minute), EndedAt: start.Add(2 * time.Minute)},
+	}
+	req := query.Request{
+		Start: util.Timestamp{Time: start},
+		End:   util.Timestamp{Time: start.Add(time.Hour)},
+		Page:  1,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, _, err := readBlocks(ctx, chunk, t.TempDir(), nil, false, req); err != context.Canceled {
+		t.Fatalf("expected canceled but %v", err)
+	}
+}
+
+func TestReadCompressedBlockWithinRange(t *testing.T) {
+	start := time.Date(2024, 1, 24, 1, 0, 0, 0, time.UTC)
+	chunk := model.Chunk{Namespace: "ns", Pod: "pod", Container: "app", Source: model.Source{Type: model.LogTypeStdStream}}
+	data := makeTestBlockData(start, 1000)
+
+	sealed, err := compressBlock(CompressionZstd, data, 4096, start, start.Add(999*time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// frames out of range are neither read nor decoded, so breaking the first frame doesn't matter
+	index, _, err := parseBlockIndex(sealed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := index.frames[0].offset; i < index.frames[0].offset+index.frames[0].compressedSize; i++ {
+		sealed[i] = 0
+	}
+
+	block := model.NewBlock(start, start.Add(999*time.Second), 1000, int64(len(data)), int64(len(sealed)), 0)
+	blockPath := fmt.Sprintf("%s/%s", t.TempDir(), block.FileName())
+	if err := os.WriteFile(blockPath, sealed, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	req := query.Request{
+		Start: util.Timestamp{Time: start.Add(500 * time.Second)},
+		End:   util.Timestamp{Time: start.Add(509 * time.Second)},
+		Page:  1,
+	}
+	buffer, err := newRequestReadBuffer(chunk, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	skip, isCompressed, err := readCompressedBlock(chunk, block, blockPath, 0, false, buffer, model.NewBucketBuilder(req.Start.Time, chunk), req, nil)
+	if err != nil || skip || !isCompressed {
+		t.Fatalf("failed to read the compressed block: %v, %t, %t", err, skip, isCompressed)
+	}
+	if err := buffer.flush(chunk, req); err != nil {
+		t.Fatal(err)
+	}
+
+	if lines := strings.Split(strings.TrimSuffix(buffer.buf.String(), "\n"), "\n"); len(lines) != 10 || !strings.HasSuffix(lines[0], "line 500") {
+		t.Fatalf("unexpected lines %v", lines)
+	}
+}
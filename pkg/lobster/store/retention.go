@@ -17,20 +17,118 @@
 package store
 
 import (
+	"fmt"
+	"math"
+	"sort"
 	"time"
 
 	"github.com/naver/lobster/pkg/lobster/model"
+	"github.com/naver/lobster/pkg/lobster/policy"
 )
 
-type LimitFunc func(chunk *model.Chunk)
+// AnnotationPrefix is the prefix of pod annotations kept in chunks.
+const AnnotationPrefix = "lobster.naver.com/"
 
-func LimitChunkSize(retentionSize int64) LimitFunc {
-	return func(chunk *model.Chunk) {
-		remainder := retentionSize
+// AnnotationRetention selects a retention policy by name or sets the retention time of pod logs; e.g. `720h`.
+const AnnotationRetention = "lobster.naver.com/retention"
+
+// Retention is the resolved retention of a chunk.
+type Retention struct {
+	Time     time.Duration
+	Size     int64
+	Priority int
+}
+
+// RetentionPolicy overrides the default retention for chunks of namespaces and pods having labels.
+type RetentionPolicy struct {
+	Name string `json:"name"`
+	policy.Selector
+	// Default retention is used for empty values
+	RetentionTime string `json:"retentionTime,omitempty"`
+	RetentionSize int64  `json:"retentionSize,omitempty"`
+	// Chunks of lower priority are evicted first when the disk usage exceeds the soft limit
+	Priority int `json:"priority,omitempty"`
+
+	retention Retention
+}
+
+// RetentionPolicies resolves the retention of chunks by the annotation of pods and then by the first matched policy.
+type RetentionPolicies struct {
+	defaults Retention
+	policies []RetentionPolicy
+}
+
+func NewRetentionPolicies(defaults Retention, policies []RetentionPolicy) (RetentionPolicies, error) {
+	for i, p := range policies {
+		policies[i].retention = Retention{defaults.Time, defaults.Size, p.Priority}
+
+		if len(p.RetentionTime) > 0 {
+			retentionTime, err := time.ParseDuration(p.RetentionTime)
+			if err != nil || retentionTime <= 0 {
+				return RetentionPolicies{}, fmt.Errorf("invalid retention time of policy %q", p.Name)
+			}
+			policies[i].retention.Time = retentionTime
+		}
+
+		if p.RetentionSize < 0 {
+			return RetentionPolicies{}, fmt.Errorf("invalid retention size of policy %q", p.Name)
+		}
+		if p.RetentionSize > 0 {
+			policies[i].retention.Size = p.RetentionSize
+		}
+	}
+
+	return RetentionPolicies{defaults, policies}, nil
+}
+
+// LoadRetentionPolicies reads policies from a yaml or json file; only defaults are used if path is empty.
+func LoadRetentionPolicies(path string, defaults Retention) (RetentionPolicies, error) {
+	policies, err := policy.Load[RetentionPolicy](path)
+	if err != nil {
+		return RetentionPolicies{}, err
+	}
+
+	return NewRetentionPolicies(defaults, policies)
+}
+
+// IsEmpty returns true if no policies are loaded.
+func (r RetentionPolicies) IsEmpty() bool {
+	return len(r.policies) == 0
+}
+
+func (r RetentionPolicies) Of(chunk model.Chunk) Retention {
+	if annotation := chunk.Annotations[AnnotationRetention]; len(annotation) > 0 {
+		for _, p := range r.policies {
+			if p.Name == annotation {
+				return p.retention
+			}
+		}
+
+		if retentionTime, err := time.ParseDuration(annotation); err == nil && retentionTime > 0 {
+			retention := r.defaults
+			retention.Time = retentionTime
+			return retention
+		}
+	}
+
+	for _, p := range r.policies {
+		if p.Selects(chunk) {
+			return p.retention
+		}
+	}
+
+	return r.defaults
+}
+
+type LimitFunc func(chunk *model.Chunk, retention Retention)
+
+func LimitChunkSize() LimitFunc {
+	return func(chunk *model.Chunk, retention Retention) {
+		remainder := retention.Size
 		blocks := chunk.Blocks
 		for i := len(blocks) - 1; i >= 0; i-- {
 			if remainder > 0 {
-				remainder = remainder - blocks[i].Size
+				remainder = remainder - blocks[i].StoredSize()
 			} else {
 				chunk.DeletionMarkInBlock = true
 				blocks[i].DeletionMark = true
@@ -39,20 +137,65 @@ func LimitChunkSize(retentionSize int64) LimitFunc {
 	}
 }
 
-func LimitChunkTime(retentionTime time.Duration) LimitFunc {
-	return func(chunk *model.Chunk) {
+func LimitChunkTime() LimitFunc {
+	return func(chunk *model.Chunk, retention Retention) {
 		now := time.Now()
 
-		if chunk.IsOutdated(retentionTime) {
+		if chunk.IsOutdated(retention.Time) {
 			chunk.DeletionMark = true
 			return
 		}
 
 		for _, block := range chunk.Blocks {
-			if retentionTime < now.Sub(block.EndedAt) {
+			if retention.Time < now.Sub(block.EndedAt) {
 				chunk.DeletionMarkInBlock = true
 				block.DeletionMark = true
 			}
 		}
 	}
 }
+
+// markByRatio marks the oldest blocks of each chunk except the ratio of them.
+func markByRatio(chunks []*model.Chunk, ratio float64) {
+	for _, chunk := range chunks {
+		reductionIndex := int(math.Ceil(float64(len(chunk.Blocks)) * (1 - ratio)))
+
+		for i := 0; i < reductionIndex; i++ {
+			chunk.MarkBlockAt(i)
+		}
+	}
+}
+
+// markByPriority marks blocks to reclaim size; blocks of lower priority and then older ones are marked first.
+func markByPriority(chunks []*model.Chunk, policies RetentionPolicies, size int64) {
+	type candidate struct {
+		chunk    *model.Chunk
+		index    int
+		priority int
+	}
+
+	candidates := []candidate{}
+
+	for _, chunk := range chunks {
+		priority := policies.Of(*chunk).Priority
+		for i := range chunk.Blocks {
+			candidates = append(candidates, candidate{chunk, i, priority})
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].priority != candidates[j].priority {
+			return candidates[i].priority < candidates[j].priority
+		}
+		return candidates[i].chunk.Blocks[candidates[i].index].EndedAt.Before(candidates[j].chunk.Blocks[candidates[j].index].EndedAt)
+	})
+
+	for _, c := range candidates {
+		if size <= 0 {
+			return
+		}
+
+		c.chunk.MarkBlockAt(c.index)
+		size = size - c.chunk.Blocks[c.index].StoredSize()
+	}
+}
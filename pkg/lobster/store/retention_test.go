@@ -0,0 +1,105 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package store
+
+import (
+	"testing"
+	"time"
+
+	"github.com/naver/lobster/pkg/lobster/model"
+	"github.com/naver/lobster/pkg/lobster/policy"
+)
+
+func TestRetentionPoliciesOf(t *testing.T) {
+	defaults := Retention{Time: 7 * 24 * time.Hour, Size: 1 << 31}
+	policies, err := NewRetentionPolicies(defaults, []RetentionPolicy{
+		{Name: "production", Selector: policy.Selector{Namespaces: []string{"pay"}}, RetentionTime: "720h", Priority: 10},
+		{Name: "ci", Selector: policy.Selector{Labels: map[string]string{"app": "runner"}}, RetentionTime: "3h", RetentionSize: 1 << 20, Priority: -1},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		chunk    model.Chunk
+		expected Retention
+	}{
+		{model.Chunk{Namespace: "pay"}, Retention{720 * time.Hour, 1 << 31, 10}},
+		{model.Chunk{Namespace: "ci", Labels: model.Labels{"app": "runner"}}, Retention{3 * time.Hour, 1 << 20, -1}},
+		{model.Chunk{Namespace: "ci", Labels: model.Labels{"app": "web"}}, defaults},
+		{model.Chunk{Namespace: "ci", Annotations: model.Labels{AnnotationRetention: "production"}}, Retention{720 * time.Hour, 1 << 31, 10}},
+		{model.Chunk{Namespace: "pay", Annotations: model.Labels{AnnotationRetention: "1h"}}, Retention{time.Hour, 1 << 31, 0}},
+		{model.Chunk{Namespace: "etc", Annotations: model.Labels{AnnotationRetention: "unknown"}}, defaults},
+	}
+
+	for _, test := range tests {
+		if retention := policies.Of(test.chunk); retention != test.expected {
+			t.Fatalf("%v: expected %v but %v", test.chunk, test.expected, retention)
+		}
+	}
+
+	if _, err := NewRetentionPolicies(defaults, []RetentionPolicy{{Name: "invalid", RetentionTime: "a week"}}); err == nil {
+		t.Fatal("invalid retention time should fail")
+	}
+}
+
+func TestMarkByPriority(t *testing.T) {
+	now := time.Now()
+	newChunk := func(namespace string) *model.Chunk {
+		return &model.Chunk{
+			Namespace: namespace,
+			Blocks: []*model.Block{
+				model.NewBlock(now.Add(-3*time.Hour), now.Add(-2*time.Hour), 1, 100, 100, 0),
+				model.NewBlock(now.Add(-2*time.Hour), now.Add(-time.Hour), 1, 100, 100, 1),
+			},
+		}
+	}
+
+	policies, err := NewRetentionPolicies(Retention{Time: time.Hour, Size: 1000}, []RetentionPolicy{
+		{Name: "production", Selector: policy.Selector{Namespaces: []string{"pay"}}, Priority: 10},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	production, ci := newChunk("pay"), newChunk("ci")
+	markByPriority([]*model.Chunk{production, ci}, policies, 250)
+
+	if !ci.Blocks[0].DeletionMark || !ci.Blocks[1].DeletionMark || !ci.DeletionMarkInBlock {
+		t.Fatal("blocks of the lower priority should be marked first")
+	}
+	if !production.Blocks[0].DeletionMark || production.Blocks[1].DeletionMark {
+		t.Fatal("only the oldest block of the higher priority should be marked")
+	}
+}
+
+func TestMarkByRatio(t *testing.T) {
+	now := time.Now()
+	chunk := &model.Chunk{
+		Blocks: []*model.Block{
+			model.NewBlock(now.Add(-3*time.Hour), now.Add(-2*time.Hour), 1, 100, 100, 0),
+			model.NewBlock(now.Add(-2*time.Hour), now.Add(-time.Hour), 1, 100, 100, 1),
+			model.NewBlock(now.Add(-time.Hour), now, 1, 100, 100, 2),
+		},
+	}
+
+	markByRatio([]*model.Chunk{chunk}, 0.5)
+
+	if !chunk.Blocks[0].DeletionMark || !chunk.Blocks[1].DeletionMark || chunk.Blocks[2].DeletionMark {
+		t.Fatal("the oldest blocks of the chunk should be marked")
+	}
+}
@@ -0,0 +1,84 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package store
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/naver/lobster/pkg/lobster/model"
+	"github.com/naver/lobster/pkg/lobster/util"
+)
+
+// writeBlockSidecars writes the time index and the bloom filter of a block from its uncompressed logs.
+func writeBlockSidecars(dir string, block model.Block, data []byte) error {
+	return errors.Join(
+		util.WriteFile(dir, block.IndexFileName(), buildTimeIndex(data, *conf.BlockIndexInterval).bytes()),
+		util.WriteFile(dir, block.BloomFileName(), buildBloomFilter(data, *conf.BloomFalsePositiveRate).bytes()),
+	)
+}
+
+// rebuildBlockSidecarsIfNotExist restores sidecars of blocks written by earlier versions.
+func rebuildBlockSidecarsIfNotExist(dir string, block model.Block) error {
+	isMissing := false
+	for _, fileName := range block.SidecarFileNames() {
+		_, err := os.Stat(fmt.Sprintf("%s/%s", dir, fileName))
+		if err == nil {
+			continue
+		}
+		if !os.IsNotExist(err) {
+			return err
+		}
+		isMissing = true
+	}
+
+	if !isMissing {
+		return nil
+	}
+
+	data, err := readRawBlock(fmt.Sprintf("%s/%s", dir, block.FileName()))
+	if err != nil {
+		return err
+	}
+
+	return writeBlockSidecars(dir, block, data)
+}
+
+// readRawBlock returns the uncompressed logs of a block file.
+func readRawBlock(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	index, isCompressed, err := parseBlockIndex(data)
+	if err != nil || !isCompressed {
+		return data, err
+	}
+
+	raw := make([]byte, 0, index.rawSize())
+	for _, f := range index.frames {
+		decoded, err := decodeFrame(index.codec, nil, data[f.offset:f.offset+f.compressedSize])
+		if err != nil {
+			return nil, err
+		}
+		raw = append(raw, decoded...)
+	}
+
+	return raw, nil
+}
@@ -17,14 +17,17 @@
 package store
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"math"
 	"os"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/golang/glog"
+	"github.com/naver/lobster/pkg/lobster/coldtier"
 	"github.com/naver/lobster/pkg/lobster/logline"
 	"github.com/naver/lobster/pkg/lobster/metrics"
 
@@ -40,10 +43,17 @@ var conf config
 type LogHandler func(chunk *model.Chunk, logLine string, logTs time.Time)
 
 type Store struct {
-	chunkCache          sync.Map
-	lock                sync.RWMutex
-	limitFuncs          []LimitFunc
-	limiter             Limiter
+	chunkCache sync.Map
+	lock       sync.RWMutex
+	limitFuncs []LimitFunc
+	retentions RetentionPolicies
+	limits     LimitProfiles
+	tails      *tailHub
+	cold       *coldtier.Client
+	// paths of blocks uploaded to the cold tier; others are not cleaned unless marked to free the disk
+	offloaded sync.Map
+	// whether blocks are marked by the disk limit, which are cleaned even if they are not offloaded
+	markedForDisk       atomic.Bool
 	ReqMaxBurst         int64
 	ReqCooldownDuration time.Duration
 }
@@ -54,24 +64,54 @@ func init() {
 }
 
 func NewStore() (*Store, error) {
-	if len(*conf.StoreRootPath) == 0 || *conf.RetentionSize <= 0 || *conf.RetentionTime <= 0 || *conf.BlockSize <= 0 {
+	if len(*conf.StoreRootPath) == 0 || *conf.RetentionSize <= 0 || *conf.RetentionTime <= 0 || *conf.BlockSize <= 0 || *conf.BloomFalsePositiveRate <= 0 || *conf.BloomFalsePositiveRate >= 1 {
 		return nil, fmt.Errorf("invalid store arguments")
 	}
 
+	if _, err := codecOf(*conf.BlockCompression); err != nil {
+		return nil, err
+	}
+
+	retentions, err := LoadRetentionPolicies(*conf.RetentionPolicyFile, Retention{Time: *conf.RetentionTime, Size: *conf.RetentionSize})
+	if err != nil {
+		return nil, err
+	}
+
+	limits, err := LoadLimitProfiles(*conf.LimitProfileFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var cold *coldtier.Client
+	if coldtier.Enabled() {
+		client, err := coldtier.NewClient()
+		if err != nil {
+			return nil, err
+		}
+		cold = client
+	}
+
 	return &Store{
 		chunkCache: sync.Map{},
 		limitFuncs: []LimitFunc{
-			LimitChunkSize(*conf.RetentionSize),
-			LimitChunkTime(*conf.RetentionTime),
+			LimitChunkSize(),
+			LimitChunkTime(),
 		},
-		limiter:             NewLimiter(),
+		retentions:          retentions,
+		limits:              limits,
+		tails:               newTailHub(),
+		cold:                cold,
 		ReqMaxBurst:         *conf.ReqMaxBurst,
 		ReqCooldownDuration: *conf.ReqCooldownDuration,
 	}, nil
 }
 
 func (s *Store) GetLimits() []*Limit {
-	return s.limiter.GetLimits()
+	return s.limits.GetLimits()
+}
+
+func (s *Store) GetLimitStats() []LimitStat {
+	return s.limits.Stats()
 }
 
 func (s *Store) GetStoreRootPath() *string {
@@ -85,7 +125,7 @@ func (s *Store) SetStoreRootPath(path *string) {
 func (s *Store) GetChunks() (chunks []model.Chunk) {
 	s.chunkCache.Range(func(key, value interface{}) bool {
 		chunk := value.(*model.Chunk)
-		if chunk.HasBlocks() && !chunk.IsOutdated(*conf.RetentionTime) {
+		if chunk.HasBlocks() && !chunk.IsOutdated(s.retentions.Of(*chunk).Time) {
 			chunks = append(chunks, *chunk)
 		}
 		return true
@@ -93,10 +133,10 @@ func (s *Store) GetChunks() (chunks []model.Chunk) {
 	return
 }
 
-func (s *Store) GetChunksWithinRange(req query.Request) (chunks []model.Chunk, err error) {
+func (s *Store) GetChunksWithinRange(ctx context.Context, req query.Request) (chunks []model.Chunk, err error) {
 	s.chunkCache.Range(func(key, value interface{}) bool {
 		chunk := value.(*model.Chunk)
-		if chunk.HasBlocks() && !chunk.IsOutdated(*conf.RetentionTime) && chunk.UpdatedAt.After(req.Start.Time) && chunk.StartedAt.Before(req.End.Time) {
+		if chunk.HasBlocks() && !chunk.IsOutdated(s.retentions.Of(*chunk).Time) && chunk.UpdatedAt.After(req.Start.Time) && chunk.StartedAt.Before(req.End.Time) {
 			chunks = append(chunks, *chunk)
 		}
 		return true
@@ -106,19 +146,44 @@ func (s *Store) GetChunksWithinRange(req query.Request) (chunks []model.Chunk, e
 	return
 }
 
-func (s *Store) GetSeriesInBlocksWithinRange(req query.Request) (numOfChunk int, series model.SeriesData, err error) {
+// chunkMayContain returns false if no blocks of the chunk within the range can contain literals of the include expression.
+func (s *Store) chunkMayContain(chunk model.Chunk, req query.Request) bool {
+	literals := req.IncludeLiterals()
+	if len(literals) == 0 {
+		return true
+	}
+
+	for _, block := range chunk.GetBlocksAfterTime(req.Start.Time) {
+		if !block.StartTime().Before(req.End.Time) || !block.EndTime().After(req.Start.Time) {
+			continue
+		}
+		if blockMayContain(s.blockDirPath(chunk), block, literals) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *Store) GetSeriesInBlocksWithinRange(ctx context.Context, req query.Request) (numOfChunk int, series model.SeriesData, err error) {
 	var buckets []model.Bucket
 
 	s.lock.RLock()
 	defer s.lock.RUnlock()
 
-	chunk := s.LoadChunk(req.Source, req.PodUid, req.Container)
+	chunk, err := s.loadRequestedChunk(req)
+	if err != nil {
+		return
+	}
 
-	if chunk == nil {
+	if chunk == nil || !s.chunkMayContain(*chunk, req) {
 		return
 	}
 
-	_, buckets, err = readBlocks(*chunk, *conf.StoreRootPath, true, req)
+	_, buckets, err = readBlocks(ctx, *chunk, *conf.StoreRootPath, s.cold, true, req)
+	if ctx.Err() != nil {
+		return
+	}
 	if err != nil {
 		glog.Error(err)
 	}
@@ -130,7 +195,54 @@ func (s *Store) GetSeriesInBlocksWithinRange(req query.Request) (numOfChunk int,
 	return
 }
 
-func (s *Store) GetBlocksWithinRange(req query.Request) (data []byte, start, end time.Time, numOfChunk int, pageInfo model.PageInfo, err error) {
+func (s *Store) GetAggregationWithinRange(ctx context.Context, req query.Request) (numOfChunk int, data model.AggregationData, err error) {
+	var (
+		buckets []model.Bucket
+		step    time.Duration
+	)
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	chunk, err := s.loadRequestedChunk(req)
+	if err != nil {
+		return
+	}
+
+	if chunk == nil || req.Aggregation == nil || !s.chunkMayContain(*chunk, req) {
+		return
+	}
+
+	step, err = req.Aggregation.StepDuration()
+	if err != nil {
+		return
+	}
+
+	_, buckets, err = readBlocks(ctx, *chunk, *conf.StoreRootPath, s.cold, true, req)
+	if ctx.Err() != nil {
+		return
+	}
+	if err != nil {
+		glog.Error(err)
+	}
+
+	series := &model.AggregatedSeries{Labels: req.Aggregation.GroupLabels(*chunk)}
+	for _, bucket := range buckets {
+		series.Samples = append(series.Samples, model.Sample{
+			Timestamp: query.BucketStart(req.Start.Time, bucket.Start, step),
+			Lines:     bucket.Lines,
+			Size:      bucket.Size,
+		})
+	}
+
+	data = model.AggregationData{series}.Merge()
+	numOfChunk = 1
+
+	glog.V(3).Infof("chunks %d | buckets %d | series %d | %s", numOfChunk, len(buckets), len(data), req.String())
+	return
+}
+
+func (s *Store) GetBlocksWithinRange(ctx context.Context, req query.Request) (data []byte, start, end time.Time, numOfChunk int, pageInfo model.PageInfo, err error) {
 	var (
 		buckets    []model.Bucket
 		readBuffer *ReadBuffer
@@ -139,15 +251,19 @@ func (s *Store) GetBlocksWithinRange(req query.Request) (data []byte, start, end
 	s.lock.RLock()
 	defer s.lock.RUnlock()
 
-	chunk := s.LoadChunk(req.Source, req.PodUid, req.Container)
+	chunk, err := s.loadRequestedChunk(req)
+	if err != nil {
+		return
+	}
 
-	if chunk == nil {
+	if chunk == nil || !s.chunkMayContain(*chunk, req) {
 		return
 	}
 
-	readBuffer, buckets, err = readBlocks(*chunk, *conf.StoreRootPath, false, req)
+	readBuffer, buckets, err = readBlocks(ctx, *chunk, *conf.StoreRootPath, s.cold, false, req)
 	if err != nil {
 		glog.Error(err)
+		return
 	}
 
 	data = readBuffer.buf.Bytes()
@@ -172,11 +288,48 @@ func (s *Store) GetBlocksWithinRange(req query.Request) (data []byte, start, end
 	return
 }
 
-func (s *Store) GetEntriesWithinRange(req query.Request) ([]model.Entry, int, model.PageInfo, error) {
+func (s *Store) GetEntriesWithinRange(ctx context.Context, req query.Request) ([]model.Entry, int, model.PageInfo, error) {
 	// do nothing
 	return nil, 0, model.PageInfo{}, errors.ErrNotImplemented
 }
 
+// loadRequestedChunk returns the chunk of blocks offloaded to the cold tier for requests to cold chunks.
+// Offloaded blocks still on the node are excluded since they are read from the hot chunk.
+func (s *Store) loadRequestedChunk(req query.Request) (*model.Chunk, error) {
+	if !req.Cold {
+		return s.LoadChunk(req.Source, req.PodUid, req.Container), nil
+	}
+
+	if s.cold == nil {
+		return nil, fmt.Errorf("cold tier is not enabled")
+	}
+
+	chunk, err := s.cold.LoadChunk(req.PodUid, req.Container, req.Source)
+	if err != nil || chunk == nil {
+		return nil, err
+	}
+
+	hot := s.LoadChunk(req.Source, req.PodUid, req.Container)
+	if hot == nil {
+		return chunk, nil
+	}
+
+	local := map[string]bool{}
+	for _, block := range hot.Blocks {
+		local[block.FileName()] = true
+	}
+
+	blocks := []*model.Block{}
+	for _, block := range chunk.Blocks {
+		if !local[block.FileName()] {
+			blocks = append(blocks, block)
+		}
+	}
+	chunk.Blocks = blocks
+
+	return chunk, nil
+}
+
 func (s *Store) Validate(req query.Request) error {
 	if len(req.PodUid) == 0 || len(req.Container) == 0 {
 		return fmt.Errorf("invalid pod uid or container name")
@@ -202,6 +355,10 @@ func (s *Store) InitChunks() {
 				glog.Error(err)
 				return
 			}
+			// workloads are recorded after chunks are created and resolved again while pods exist
+			if workloads, err := model.NewWorkloadsFromFile(fmt.Sprintf("%s/%s", s.podDirPath(*chunk), model.WorkloadsFileName)); err == nil {
+				chunk.Workloads = workloads
+			}
 			s.StoreChunk(file.Source, file.PodUid, file.Container, chunk)
 		}
 
@@ -235,6 +392,12 @@ func (s *Store) WriteLabelsFile(chunk *model.Chunk) {
 	}
 }
 
+func (s *Store) WriteWorkloadsFile(chunk *model.Chunk) {
+	if err := util.WriteFile(s.podDirPath(*chunk), model.WorkloadsFileName, chunk.Workloads.ToBytes()); err != nil {
+		glog.V(3).Infof("failed to write workloads in %s: %s", s.podDirPath(*chunk), err.Error())
+	}
+}
+
 func (s *Store) LoadChunk(source model.Source, podUid, container string) *model.Chunk {
 	chunk, ok := s.chunkCache.Load(storeKey(podUid, container, source.String()))
 	if !ok {
@@ -271,14 +434,14 @@ func (s *Store) WriteTailedLogs(chunk *model.Chunk, fileNum int64, logChan chan
 	tempBlockFilePath := s.blockFilePath(*chunk, model.TempBlockFileName)
 
 	if err := setupBlockPathIfNotExist(blockDirPath); err != nil {
-		glog.Infof("chunk updated: %v | is outdated: %v | reason: %s", chunk.UpdatedAt, chunk.IsOutdated(*conf.RetentionTime), err.Error())
+		glog.Infof("chunk updated: %v | is outdated: %v | reason: %s", chunk.UpdatedAt, chunk.IsOutdated(s.retentions.Of(*chunk).Time), err.Error())
 		return err
 	}
 
-	bucket := NewLeakyBucket(s.limiter, *conf.LeakyBucketInterval)
+	bucket := NewLeakyBucket(s.limits, chunk, *conf.LeakyBucketInterval)
 	defer bucket.Release()
 
-	return writeTailedLogs(chunk, blockDirPath, tempBlockFilePath, fileNum, *conf.BlockSize, logChan, stopChan, bucket, logHandler)
+	return writeTailedLogs(chunk, blockDirPath, tempBlockFilePath, fileNum, *conf.BlockSize, logChan, stopChan, bucket, logHandler, s.tails.publish)
 }
 
 func (s *Store) MoveTempblock(chunk *model.Chunk, oldFileNum, newFileNum int64) error {
@@ -297,13 +460,112 @@ func (s *Store) Mark() {
 	metrics.SetDiskUsed(float64(used))
 	metrics.SetDiskLimit(limit)
 
-	if s.shouldMarkEntire(used, uint64(limit)) {
-		s.markByEntireSize()
+	markedForDisk := s.shouldMarkEntire(used, uint64(limit))
+	s.markedForDisk.Store(markedForDisk)
+
+	if markedForDisk {
+		s.markByEntireSize(int64(used) - int64(limit))
 	} else {
 		s.markByRetention()
 	}
 }
 
+// Offload uploads sealed blocks marked for deletion to the cold tier before they are cleaned until ctx is done.
+// Blocks are uploaded without the lock, so that logs are written and read meanwhile.
+func (s *Store) Offload(ctx context.Context) {
+	if s.cold == nil {
+		return
+	}
+
+	type offloadedBlock struct {
+		chunk model.Chunk
+		block model.Block
+		path  string
+	}
+
+	blocks := []offloadedBlock{}
+
+	s.lock.RLock()
+	s.chunkCache.Range(func(key, value interface{}) bool {
+		chunk := value.(*model.Chunk)
+		if !chunk.DeletionMark && !chunk.DeletionMarkInBlock {
+			return true
+		}
+
+		for _, block := range chunk.Copy() {
+			if !chunk.DeletionMark && !block.DeletionMark {
+				continue
+			}
+
+			path := s.blockFilePath(*chunk, block.FileName())
+			if _, ok := s.offloaded.Load(path); ok {
+				continue
+			}
+			blocks = append(blocks, offloadedBlock{*chunk, *block, path})
+		}
+		return true
+	})
+	s.lock.RUnlock()
+
+	for _, b := range blocks {
+		if ctx.Err() != nil {
+			glog.Warningf("offload is stopped: %s", ctx.Err().Error())
+			return
+		}
+
+		data, err := os.ReadFile(b.path)
+		if err != nil {
+			glog.Error(err)
+			continue
+		}
+
+		if err := s.cold.Upload(ctx, b.chunk, b.block, data); err != nil {
+			glog.Error(err)
+			continue
+		}
+		s.offloaded.Store(b.path, true)
+		glog.V(3).Infof("offload block : %s | [%v ~ %v]\n", b.block.FileName(), b.block.StartedAt, b.block.EndedAt)
+	}
+}
+
+// keepBlocksNotOffloaded unmarks blocks failed to be uploaded to the cold tier, so that they are kept until the next offload.
+// Blocks marked to free the disk are cleaned anyway and counted as not offloaded.
+func (s *Store) keepBlocksNotOffloaded(chunk *model.Chunk) {
+	if s.cold == nil || (!chunk.DeletionMark && !chunk.DeletionMarkInBlock) {
+		return
+	}
+
+	kept := false
+	lost := 0
+	for _, block := range chunk.Blocks {
+		if !chunk.DeletionMark && !block.DeletionMark {
+			continue
+		}
+
+		if _, ok := s.offloaded.Load(s.blockFilePath(*chunk, block.FileName())); ok {
+			block.DeletionMark = true
+			continue
+		}
+		if s.markedForDisk.Load() {
+			block.DeletionMark = true
+			lost = lost + 1
+			continue
+		}
+		block.DeletionMark = false
+		kept = true
+	}
+
+	if lost > 0 {
+		metrics.AddNotOffloadedBlocks(lost)
+		glog.Warningf("%d blocks of %s are deleted to free the disk before they are offloaded", lost, chunk.Key())
+	}
+
+	if kept && chunk.DeletionMark {
+		chunk.DeletionMark = false
+		chunk.DeletionMarkInBlock = true
+	}
+}
+
 func (s *Store) Clear() {
 	s.chunkCache.Range(func(key, value any) bool {
 		s.chunkCache.Delete(key)
@@ -315,23 +577,40 @@ func (s *Store) shouldMarkEntire(used, limit uint64) bool {
 	return used > limit
 }
 
-func (s *Store) markByEntireSize() {
+// markByEntireSize marks blocks of lower priority first to reclaim the excess or the ratio of blocks, whichever is larger.
+// Without policies, the ratio of blocks is marked in each chunk.
+func (s *Store) markByEntireSize(excess int64) {
+	chunks := []*model.Chunk{}
+	total := int64(0)
+
 	s.chunkCache.Range(func(key, value interface{}) bool {
 		chunk := value.(*model.Chunk)
-		totalBlocks := len(chunk.Blocks)
-		reductionIndex := int(math.Ceil(float64(totalBlocks) * (1 - *conf.SoftLimitRatioForBlocks)))
-
-		for i := 0; i < reductionIndex; i++ {
-			chunk.MarkBlockAt(i)
+		for _, block := range chunk.Blocks {
+			total = total + block.StoredSize()
 		}
+		chunks = append(chunks, chunk)
 		return true
 	})
+
+	if s.retentions.IsEmpty() {
+		markByRatio(chunks, *conf.SoftLimitRatioForBlocks)
+		return
+	}
+
+	size := int64(math.Ceil(float64(total) * (1 - *conf.SoftLimitRatioForBlocks)))
+	if excess > size {
+		size = excess
+	}
+
+	markByPriority(chunks, s.retentions, size)
 }
 
 func (s *Store) markByRetention() {
 	s.chunkCache.Range(func(key, value interface{}) bool {
+		chunk := value.(*model.Chunk)
+		retention := s.retentions.Of(*chunk)
 		for _, f := range s.limitFuncs {
-			f(value.(*model.Chunk))
+			f(chunk, retention)
 		}
 		return true
 	})
@@ -348,7 +627,12 @@ func (s *Store) Clean() {
 func (s *Store) cleanChunks() {
 	s.chunkCache.Range(func(key, value interface{}) bool {
 		chunk := (value.(*model.Chunk))
+		s.keepBlocksNotOffloaded(chunk)
+
 		if chunk.DeletionMark {
+			for _, block := range chunk.Blocks {
+				s.offloaded.Delete(s.blockFilePath(*chunk, block.FileName()))
+			}
 			if err := chunk.DeleteContainerFiles(*conf.StoreRootPath); err != nil {
 				glog.Error(err)
 			}
@@ -363,6 +647,7 @@ func (s *Store) cleanChunks() {
 			offset := 0
 			for i, block := range tmp {
 				if block.DeletionMark {
+					s.offloaded.Delete(s.blockFilePath(*chunk, block.FileName()))
 					if err := chunk.DeleteBlockAt(i-offset, *conf.StoreRootPath); err != nil {
 						glog.Error(err)
 					}
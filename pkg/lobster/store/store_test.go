@@ -0,0 +1,72 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package store
+
+import (
+	"testing"
+	"time"
+
+	"github.com/naver/lobster/pkg/lobster/coldtier"
+	"github.com/naver/lobster/pkg/lobster/model"
+)
+
+func TestKeepBlocksNotOffloaded(t *testing.T) {
+	store := newTestStore()
+	store.cold = &coldtier.Client{}
+
+	now := time.Now()
+	chunk := newTestChunk("pod-a", "uid-a", true)
+	chunk.DeletionMark = true
+	for i := 0; i < 3; i++ {
+		chunk.Blocks = append(chunk.Blocks, model.NewBlock(now.Add(time.Duration(i)*time.Minute), now.Add(time.Duration(i+1)*time.Minute), 1, 1, 1, 0))
+	}
+	store.offloaded.Store(store.blockFilePath(*chunk, chunk.Blocks[0].FileName()), true)
+
+	store.keepBlocksNotOffloaded(chunk)
+
+	if chunk.DeletionMark || !chunk.DeletionMarkInBlock {
+		t.Fatal("chunk having blocks not offloaded should not be deleted")
+	}
+	for i, block := range chunk.Blocks {
+		if block.DeletionMark != (i == 0) {
+			t.Errorf("block %d: expected deletion mark %t", i, i == 0)
+		}
+	}
+}
+
+func TestCleanBlocksMarkedForDiskNotOffloaded(t *testing.T) {
+	store := newTestStore()
+	store.cold = &coldtier.Client{}
+	store.markedForDisk.Store(true)
+
+	now := time.Now()
+	chunk := newTestChunk("pod-a", "uid-a", true)
+	chunk.DeletionMarkInBlock = true
+	for i := 0; i < 3; i++ {
+		chunk.Blocks = append(chunk.Blocks, model.NewBlock(now.Add(time.Duration(i)*time.Minute), now.Add(time.Duration(i+1)*time.Minute), 1, 1, 1, 0))
+	}
+	chunk.Blocks[0].DeletionMark = true
+	chunk.Blocks[1].DeletionMark = true
+
+	store.keepBlocksNotOffloaded(chunk)
+
+	for i, block := range chunk.Blocks {
+		if block.DeletionMark != (i < 2) {
+			t.Errorf("block %d: blocks marked to free the disk should be deleted without offload", i)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package store
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/naver/lobster/pkg/lobster/logline"
+	"github.com/naver/lobster/pkg/lobster/model"
+	"github.com/naver/lobster/pkg/lobster/query"
+	"github.com/naver/lobster/pkg/lobster/query/filter"
+)
+
+const tailBufferSize = 1000
+
+type tailSubscriber struct {
+	req     query.Request
+	matcher func(model.Chunk) bool
+	entries chan model.Entry
+}
+
+// tailHub delivers written logs to subscribers following them.
+// Slow subscribers lose entries instead of blocking writers.
+type tailHub struct {
+	lock        sync.RWMutex
+	subscribers map[*tailSubscriber]struct{}
+}
+
+func newTailHub() *tailHub {
+	return &tailHub{subscribers: map[*tailSubscriber]struct{}{}}
+}
+
+func (h *tailHub) subscribe(req query.Request) *tailSubscriber {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	subscriber := &tailSubscriber{
+		req:     req,
+		matcher: query.NewChunkMatcher(req).IsRequestedChunk,
+		entries: make(chan model.Entry, tailBufferSize),
+	}
+	h.subscribers[subscriber] = struct{}{}
+
+	return subscriber
+}
+
+func (h *tailHub) unsubscribe(subscriber *tailSubscriber) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	delete(h.subscribers, subscriber)
+}
+
+func (h *tailHub) publish(chunk *model.Chunk, line string, ts time.Time) {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	if len(h.subscribers) == 0 {
+		return
+	}
+
+	for subscriber := range h.subscribers {
+		if !subscriber.isMatched(*chunk) {
+			continue
+		}
+
+		msg, err := logline.ParseLogMessageBySource(chunk.Source.Type, line)
+		if err != nil {
+			continue
+		}
+
+		if result, err := filter.DoFilter(msg, ts, subscriber.req.Filterers...); err != nil || result == filter.Filtered {
+			continue
+		}
+
+		entry, err := query.ParseEntry(line, *chunk)
+		if err != nil {
+			continue
+		}
+
+		select {
+		case subscriber.entries <- entry:
+		default:
+			glog.V(3).Infof("tail subscriber is too slow to receive logs of %s", chunk.RelativeBlockDir)
+		}
+	}
+}
+
+func (s *tailSubscriber) isMatched(chunk model.Chunk) bool {
+	if len(s.req.Namespace) > 0 && s.req.Namespace != chunk.Namespace {
+		return false
+	}
+	if len(s.req.Namespaces) > 0 && !slices.Contains(s.req.Namespaces, chunk.Namespace) {
+		return false
+	}
+	return s.matcher(chunk)
+}
+
+// Tail sends logs matched with the request as they are written until ctx is done.
+func (s *Store) Tail(ctx context.Context, req query.Request, send func(model.Entry) error) error {
+	subscriber := s.tails.subscribe(req)
+	defer s.tails.unsubscribe(subscriber)
+
+	for {
+		select {
+		case entry := <-subscriber.entries:
+			if err := send(entry); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
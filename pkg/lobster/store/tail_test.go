@@ -0,0 +1,61 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package store
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/naver/lobster/pkg/lobster/model"
+	"github.com/naver/lobster/pkg/lobster/query"
+)
+
+func TestTailHubPublish(t *testing.T) {
+	hub := newTailHub()
+	req, err := query.ParseTailRequestWithBody([]byte(`{"namespace":"ns","pods":["pod-a"],"include":"error"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	subscriber := hub.subscribe(req)
+	defer hub.unsubscribe(subscriber)
+
+	source := model.Source{Type: model.LogTypeStdStream}
+	matched := &model.Chunk{Namespace: "ns", Pod: "pod-a", Container: "app", Source: source}
+	otherPod := &model.Chunk{Namespace: "ns", Pod: "pod-b", Container: "app", Source: source}
+	otherNamespace := &model.Chunk{Namespace: "other", Pod: "pod-a", Container: "app", Source: source}
+
+	ts := time.Now()
+	line := func(msg string) string {
+		return fmt.Sprintf("%s stdout F %s\n", ts.Format(time.RFC3339Nano), msg)
+	}
+
+	hub.publish(otherPod, line("error from other pod"), ts)
+	hub.publish(otherNamespace, line("error from other namespace"), ts)
+	hub.publish(matched, line("info is filtered"), ts)
+	hub.publish(matched, line("error is matched"), ts)
+
+	if len(subscriber.entries) != 1 {
+		t.Fatalf("subscriber should receive 1 entry but %d", len(subscriber.entries))
+	}
+
+	entry := <-subscriber.entries
+	if entry.Pod != "pod-a" || entry.Message != "error is matched" {
+		t.Fatalf("unexpected entry: %v", entry)
+	}
+}
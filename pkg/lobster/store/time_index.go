@@ -0,0 +1,125 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package store
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/naver/lobster/pkg/lobster/logline"
+	"github.com/naver/lobster/pkg/lobster/model"
+	"github.com/naver/lobster/pkg/lobster/util"
+)
+
+const timeIndexEntrySize = 16
+
+var errInvalidTimeIndex = errors.New("invalid time index")
+
+// timeIndex is a sparse index of a block.
+// Each entry points to the logical(uncompressed) offset of a line and its timestamp.
+type timeIndex []timeIndexEntry
+
+type timeIndexEntry struct {
+	ts     time.Time
+	offset int64
+}
+
+// buildTimeIndex makes an entry for the first line and every interval lines after it.
+func buildTimeIndex(data []byte, interval int) timeIndex {
+	var (
+		index   = timeIndex{}
+		lines   = 0
+		pending = false
+	)
+
+	if interval <= 0 {
+		return index
+	}
+
+	for pos := 0; pos < len(data); {
+		next := bytes.IndexByte(data[pos:], '\n')
+		if next < 0 {
+			break
+		}
+
+		if lines%interval == 0 {
+			pending = true
+		}
+
+		if pending {
+			if ts, err := logline.ParseTimestamp(util.BytesToString(data[pos : pos+next+1])); err == nil {
+				index = append(index, timeIndexEntry{ts, int64(pos)})
+				pending = false
+			}
+		}
+
+		lines = lines + 1
+		pos = pos + next + 1
+	}
+
+	return index
+}
+
+// seek returns the offset from which lines at or after ts are found.
+func (i timeIndex) seek(ts time.Time) int64 {
+	offset := int64(0)
+	for _, entry := range i {
+		if !entry.ts.Before(ts) {
+			break
+		}
+		offset = entry.offset
+	}
+	return offset
+}
+
+func (i timeIndex) bytes() []byte {
+	data := make([]byte, 0, len(i)*timeIndexEntrySize)
+	for _, entry := range i {
+		data = binary.BigEndian.AppendUint64(data, uint64(entry.ts.UnixNano()))
+		data = binary.BigEndian.AppendUint64(data, uint64(entry.offset))
+	}
+	return data
+}
+
+func parseTimeIndex(data []byte) (timeIndex, error) {
+	if len(data)%timeIndexEntrySize != 0 {
+		return nil, errInvalidTimeIndex
+	}
+
+	index := make(timeIndex, 0, len(data)/timeIndexEntrySize)
+	for pos := 0; pos < len(data); pos = pos + timeIndexEntrySize {
+		index = append(index, timeIndexEntry{
+			ts:     time.Unix(0, int64(binary.BigEndian.Uint64(data[pos:pos+8]))),
+			offset: int64(binary.BigEndian.Uint64(data[pos+8 : pos+16])),
+		})
+	}
+
+	return index, nil
+}
+
+func loadTimeIndex(dir string, block model.Block) (timeIndex, error) {
+	data, err := os.ReadFile(fmt.Sprintf("%s/%s", dir, block.IndexFileName()))
+	if err != nil {
+		return nil, err
+	}
+
+	return parseTimeIndex(data)
+}
@@ -0,0 +1,58 @@
+/*
+ * Copyright (c) 2024-present NAVER Corp
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package store
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/naver/lobster/pkg/lobster/logline"
+	"github.com/naver/lobster/pkg/lobster/util"
+)
+
+func TestTimeIndexSeek(t *testing.T) {
+	start := time.Date(2024, 1, 24, 1, 0, 0, 0, time.UTC)
+	data := makeTestBlockData(start, 1000)
+
+	index, err := parseTimeIndex(buildTimeIndex(data, 100).bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(index) != 10 {
+		t.Fatalf("index should have 10 entries but %d", len(index))
+	}
+
+	target := start.Add(550 * time.Second)
+	offset := index.seek(target)
+	if offset == 0 {
+		t.Fatal("offset should not be the beginning of the block")
+	}
+
+	line := data[offset : offset+int64(bytes.IndexByte(data[offset:], '\n'))]
+	ts, err := logline.ParseTimestamp(util.BytesToString(line))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ts.Before(target) || target.Sub(ts) > 100*time.Second {
+		t.Fatalf("seeked line(%s) should be right before %s", ts, target)
+	}
+
+	if index.seek(start) != 0 {
+		t.Fatal("seek to the start of the block should return 0")
+	}
+}
@@ -22,12 +22,15 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/golang/glog"
 	"github.com/naver/lobster/pkg/lobster/logline"
 	"github.com/naver/lobster/pkg/lobster/metrics"
 	"github.com/naver/lobster/pkg/lobster/model"
+	"github.com/naver/lobster/pkg/lobster/multiline"
+	"github.com/naver/lobster/pkg/lobster/redaction"
 	"github.com/naver/lobster/pkg/lobster/util"
 )
 
@@ -48,8 +51,12 @@ func writeFiledLogs(chunk *model.Chunk, files []model.LogFile, blockDirPath stri
 
 func writeBlocks(chunk *model.Chunk, file model.LogFile, buf *writeBuffer, blockDirPath string, maxBlockSize int64, logHandler LogHandler) ([]*model.Block, error) {
 	var (
-		readLine string
-		blocks   = []*model.Block{}
+		readLine  string
+		consumed  int64
+		lastTs    time.Time
+		joiner    *logline.PartialJoiner
+		assembler *multiline.Assembler
+		blocks    = []*model.Block{}
 	)
 
 	f, err := os.Open(file.Path)
@@ -61,47 +68,169 @@ func writeBlocks(chunk *model.Chunk, file model.LogFile, buf *writeBuffer, block
 		err = errors.Join(err, errors.Join(f.Sync(), f.Close()))
 	}()
 
+	if file.Source.Type == model.LogTypeStdStream {
+		joiner = logline.NewPartialJoiner(func() {
+			metrics.AddTruncatedCount(file.Namespace, file.Pod, file.Container, file.Source.Type, file.Source.Path)
+		})
+	}
+
+	if rule := multiline.RuleOf(file.Namespace, file.Container, file.Source); rule != nil {
+		assembler = multiline.NewAssembler(rule)
+	}
+
 	reader := bufio.NewReader(f)
 	buf.resetFileOffset()
 
-	for {
-		readLine, err = reader.ReadString('\n')
-		if err != nil {
-			if err == io.EOF {
-				break
+	redactor := redaction.NewRedactor(chunk)
+
+	writeLine := func(ts time.Time, line string) error {
+		if redactor != nil {
+			var ok bool
+			if line, ok = redactor.Redact(line); !ok {
+				return nil
 			}
+		}
 
-			return blocks, err
+		buf.write(ts, line)
+
+		go logHandler(chunk, line, ts)
+
+		if int64(buf.size()) < maxBlockSize {
+			return nil
+		}
+
+		block, err := writeBlock(blockDirPath, buf, file.Number)
+		if err != nil {
+			return err
+		}
+		if block != nil {
+			blocks = append(blocks, block)
+		}
+		buf.reset()
+		return nil
+	}
+
+	// lines are written as read unless they are joined or assembled
+	writeReadLine := func(readLine string, fallback time.Time) error {
+		msg := readLine
+
+		if file.Source.Type == model.LogTypeHostFile {
+			readLine = logline.StampHostLogLine(readLine, fallback)
 		}
 
 		ts, err := logline.ParseTimestamp(readLine)
 		if err != nil {
 			glog.V(3).Info("failed to parse timestamp for %s: %s", file.Path, readLine)
 			if file.Source.Type == model.LogTypeStdStream || buf.start.IsZero() {
-				continue
+				return nil
 			}
 
 			readLine = logline.MakeUnreliableTimestamp(ts, readLine)
+		} else {
+			lastTs = ts
 		}
 
-		buf.write(ts, readLine)
+		if joiner != nil {
+			joinedTs, joined, ok := joiner.Join(ts, strings.TrimSuffix(readLine, "\n"))
+			if !ok {
+				return nil
+			}
+			ts, readLine = joinedTs, joined+"\n"
+		}
 
-		go logHandler(chunk, readLine, ts)
+		if assembler != nil {
+			if file.Source.Type == model.LogTypeStdStream {
+				if msg, err = logline.ParseLogMessage(readLine); err != nil {
+					return nil
+				}
+			}
 
-		if int64(buf.size()) < maxBlockSize {
-			continue
+			event, ok := assembler.Add(ts, readLine, msg, consumed, time.Now())
+			if !ok {
+				return nil
+			}
+			ts, readLine = event.Timestamp, event.Line+"\n"
 		}
 
-		block, err := writeBlock(blockDirPath, buf, file.Number)
+		return writeLine(ts, readLine)
+	}
+
+	// lines without timestamps are as old as the previous line, or the next one at the start of host log files
+	leadingLines := []string{}
+
+	for {
+		readLine, err = reader.ReadString('\n')
 		if err != nil {
+			if err == io.EOF {
+				break
+			}
+
 			return blocks, err
 		}
-		if block != nil {
-			blocks = append(blocks, block)
+
+		consumed = consumed + int64(len(readLine))
+		fallback := lastTs
+
+		if file.Source.Type == model.LogTypeHostFile && lastTs.IsZero() {
+			ts, ok := logline.ParseHostTimestamp(readLine, file.ModTime)
+			if !ok {
+				leadingLines = append(leadingLines, readLine)
+				continue
+			}
+
+			for _, line := range leadingLines {
+				if err := writeReadLine(line, ts); err != nil {
+					return blocks, err
+				}
+			}
+			leadingLines = leadingLines[:0]
+			fallback = ts
+		}
+
+		if err := writeReadLine(readLine, fallback); err != nil {
+			return blocks, err
 		}
-		buf.reset()
 	}
 
+	// no line of the file has a timestamp
+	for _, line := range leadingLines {
+		if err := writeReadLine(line, file.ModTime); err != nil {
+			return blocks, err
+		}
+	}
+
+	if joiner != nil {
+		for _, joined := range joiner.Flush() {
+			if assembler == nil {
+				if err := writeLine(joined.Timestamp, joined.Line+"\n"); err != nil {
+					return blocks, err
+				}
+				continue
+			}
+
+			msg, err := logline.ParseLogMessage(joined.Line)
+			if err != nil {
+				continue
+			}
+			if event, ok := assembler.Add(joined.Timestamp, joined.Line, msg, consumed, time.Now()); ok {
+				if err := writeLine(event.Timestamp, event.Line+"\n"); err != nil {
+					return blocks, err
+				}
+			}
+		}
+	}
+
+	if assembler != nil {
+		if event, ok := assembler.Flush(); ok {
+			if err := writeLine(event.Timestamp, event.Line+"\n"); err != nil {
+				return blocks, err
+			}
+		}
+	}
+
+	// joined and assembled lines are shorter than lines of the file
+	buf.fileOffset = consumed
+
 	if buf.size() == 0 {
 		return blocks, nil
 	}
@@ -124,26 +253,39 @@ func writeBlock(dir string, buf *writeBuffer, fileNumber int64) (*model.Block, e
 		return nil, fmt.Errorf("invalid timestamp order [%v - %v] %s", buf.start, buf.end, dir)
 	}
 
-	block = model.NewBlock(buf.start, buf.end, buf.lines, int64(buf.size()), fileNumber)
+	data, err := sealBlock(buf.bytes(), buf.start, buf.end)
+	if err != nil {
+		return nil, err
+	}
+
+	block = model.NewBlock(buf.start, buf.end, buf.lines, int64(buf.size()), int64(len(data)), fileNumber)
 	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
 		return nil, err
 	}
 	filePath := fmt.Sprintf("%s/%s", dir, block.FileName())
-	_, err := os.Stat(filePath)
+	_, err = os.Stat(filePath)
 	if err == nil { // skip if already exists
 		return nil, nil
 	}
 	if !os.IsNotExist(err) {
 		return nil, err
 	}
-	if err := os.WriteFile(filePath, buf.bytes(), 0600); err != nil {
+	if err := os.WriteFile(filePath, data, 0600); err != nil {
 		return nil, err
 	}
+	if err := writeBlockSidecars(dir, *block, buf.bytes()); err != nil {
+		glog.Errorf("failed to write sidecars of %s: %s", filePath, err.Error())
+	}
 	return block, nil
 }
 
-func writeTailedLogs(chunk *model.Chunk, blockDirPath, tempBlockFilePath string, fileNum int64, maxBlockSize int64, logChan chan logline.LogLine, stopChan chan struct{}, bucket *leakyBucket, logHandler LogHandler) error {
+// writeTailedLogs writes lines passing the limiter and publishes them to live tails as they are written,
+// so tails and blocks have the same logs.
+func writeTailedLogs(chunk *model.Chunk, blockDirPath, tempBlockFilePath string, fileNum int64, maxBlockSize int64, logChan chan logline.LogLine, stopChan chan struct{}, bucket *leakyBucket, logHandler LogHandler, publish LogHandler) error {
 	buf := emptyWriteBuffer()
+	buf.onWrite = func(ts time.Time, msg string) {
+		publish(chunk, msg, ts)
+	}
 
 	tempFile, err := os.OpenFile(tempBlockFilePath, os.O_APPEND|os.O_WRONLY|os.O_CREATE, os.ModeAppend)
 	if err != nil {
@@ -153,10 +295,12 @@ func writeTailedLogs(chunk *model.Chunk, blockDirPath, tempBlockFilePath string,
 		err = errors.Join(err, errors.Join(tempFile.Sync(), tempFile.Close()))
 	}()
 
+	redactor := redaction.NewRedactor(chunk)
 	flushTicker := time.NewTicker(bucket.interval)
 
 	defer func() {
 		flushTicker.Stop()
+		bucket.flushOverflow(buf)
 		if err := flushWriteBuffer(buf, tempFile, chunk, blockDirPath, fileNum, maxBlockSize); err != nil {
 			glog.Error(err)
 		}
@@ -180,19 +324,36 @@ func writeTailedLogs(chunk *model.Chunk, blockDirPath, tempBlockFilePath string,
 			buf.lastOffset = line.Offset
 			msg := line.Line + "\n"
 
-			buf.write(line.Timestamp, msg)
+			if redactor != nil {
+				if msg, ok = redactor.Redact(msg); !ok {
+					continue
+				}
+			}
 
 			go logHandler(chunk, msg, line.Timestamp)
 
-			if ok, description := bucket.Pour(int64(len(msg))); !ok {
-				buf.write(line.Timestamp, fmt.Sprintf("%s stdout F (lobster: Logs exceeding %s were limited)\n", line.Timestamp.Format(time.RFC3339Nano), description))
-				metrics.AddOverloadedCount(chunk.Namespace, chunk.Pod, chunk.Container, chunk.Source.Type, chunk.Source.Path, description)
+			ok, description := bucket.Pour(int64(len(msg)))
+			if ok {
+				buf.write(line.Timestamp, msg)
+				continue
+			}
 
-				return fmt.Errorf("logs are limit(%s) for %s_%s_%s", description, chunk.Namespace, chunk.Pod, chunk.Container)
+			if !bucket.ShouldStop() {
+				if bucket.Overflow(line.Timestamp, msg) {
+					buf.write(line.Timestamp, msg)
+				}
+				continue
 			}
 
+			buf.write(line.Timestamp, msg)
+			buf.write(line.Timestamp, fmt.Sprintf("%s stdout F (lobster: Logs exceeding %s were limited)\n", line.Timestamp.Format(time.RFC3339Nano), description))
+			metrics.AddOverloadedCount(chunk.Namespace, chunk.Pod, chunk.Container, chunk.Source.Type, chunk.Source.Path, description)
+
+			return fmt.Errorf("logs are limit(%s) for %s_%s_%s", description, chunk.Namespace, chunk.Pod, chunk.Container)
+
 		case <-flushTicker.C:
 			start := time.Now()
+			bucket.flushOverflow(buf)
 			if err := flushWriteBuffer(buf, tempFile, chunk, blockDirPath, fileNum, maxBlockSize); err != nil {
 				return err
 			}
@@ -249,12 +410,29 @@ func flushWriteBuffer(buf *writeBuffer, tempFile *os.File, chunk *model.Chunk, b
 }
 
 func moveTempblock(chunk *model.Chunk, blockDirPath string, oldFileNum, newFileNum int64) error {
-	newBlock := model.NewBlockFromTempBlock(*chunk.TempBlock, oldFileNum)
-
 	tempBlockFilePath := fmt.Sprintf("%s/%s", blockDirPath, model.TempBlockFileName)
-	newBlockFilePath := fmt.Sprintf("%s/%s", blockDirPath, newBlock.FileName())
 
-	if err := util.MoveFileContents(tempBlockFilePath, newBlockFilePath); err != nil {
+	contents, err := os.ReadFile(tempBlockFilePath)
+	if err != nil {
+		return err
+	}
+
+	data, err := sealBlock(contents, chunk.TempBlock.StartedAt, chunk.TempBlock.EndedAt)
+	if err != nil {
+		return err
+	}
+
+	newBlock := model.NewBlockFromTempBlock(*chunk.TempBlock, int64(len(data)), oldFileNum)
+
+	if err := util.WriteFile(blockDirPath, newBlock.FileName(), data); err != nil {
+		return err
+	}
+
+	if err := writeBlockSidecars(blockDirPath, *newBlock, contents); err != nil {
+		glog.Errorf("failed to write sidecars of %s: %s", newBlock.FileName(), err.Error())
+	}
+
+	if err := os.Truncate(tempBlockFilePath, 0); err != nil {
 		return err
 	}
 
@@ -263,6 +441,11 @@ func moveTempblock(chunk *model.Chunk, blockDirPath string, oldFileNum, newFileN
 	return nil
 }
 
+// sealBlock returns the contents of a block file for the given logs within start and end compressed by store.blockCompression.
+func sealBlock(data []byte, start, end time.Time) ([]byte, error) {
+	return compressBlock(*conf.BlockCompression, data, *conf.BlockFrameSize, start, end)
+}
+
 func setupBlockPathIfNotExist(dir string) error {
 	if err := os.MkdirAll(dir, 0750); err != nil {
 		return err
@@ -19,11 +19,14 @@ package store
 import (
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/naver/lobster/pkg/lobster/logline"
+	"github.com/naver/lobster/pkg/lobster/model"
 )
 
 func TestWriteBufferInsertOutOfOrderReal(t *testing.T) {
@@ -249,3 +252,64 @@ func TestWriteBufferInsertOutOfOrderFrontBufferStatus(t *testing.T) {
 		t.FailNow()
 	}
 }
+
+func TestWriteBlocksOfHostFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "syslog")
+	data := "continued from the rotated file\nMar 10 11:00:00 node-a kubelet started\nwithout timestamp\n"
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	modTime := time.Date(2024, 3, 10, 12, 0, 0, 0, time.Local)
+	file := model.LogFile{Path: path, ModTime: modTime, Source: model.Source{Type: model.LogTypeHostFile, Path: "syslog"}}
+	buf := emptyWriteBuffer()
+
+	if _, err := writeBlocks(&model.Chunk{}, file, buf, dir, 1<<20, func(*model.Chunk, string, time.Time) {}); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := time.Date(2024, 3, 10, 11, 0, 0, 0, time.Local)
+	if !buf.start.Equal(expected) || !buf.end.Equal(expected) || buf.lines != 3 {
+		t.Errorf("lines without timestamps should be as old as their neighbours: %v ~ %v %d", buf.start, buf.end, buf.lines)
+	}
+}
+
+func TestWriteTailedLogsPublishesWrittenLines(t *testing.T) {
+	profiles, err := NewLimitProfiles([]*LimitProfile{{Name: DefaultLimitProfile, Tiers: []LimitTier{{Capacity: 1, Size: 1000, Lines: 3}}, Overflow: OverflowDrop}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	chunk := &model.Chunk{TempBlock: &model.TempBlock{}, CheckPoint: model.NewCheckPoint(0, 0)}
+	bucket := NewLeakyBucket(profiles, chunk, time.Hour)
+	bucket.Init(time.Now())
+	defer bucket.Release()
+
+	logChan := make(chan logline.LogLine, 10)
+	now := time.Now().UTC()
+	for i := 0; i < 10; i++ {
+		ts := now.Add(time.Duration(i) * time.Millisecond)
+		logChan <- logline.LogLine{Timestamp: ts, Line: fmt.Sprintf("%s stdout F line %d", ts.Format(time.RFC3339Nano), i)}
+	}
+	close(logChan)
+
+	published := strings.Builder{}
+	publish := func(_ *model.Chunk, line string, _ time.Time) { published.WriteString(line) }
+	tempBlockFilePath := filepath.Join(dir, model.TempBlockFileName)
+
+	if err := writeTailedLogs(chunk, dir, tempBlockFilePath, 0, 1<<20, logChan, make(chan struct{}), bucket, func(*model.Chunk, string, time.Time) {}, publish); err != nil {
+		t.Fatal(err)
+	}
+
+	written, err := os.ReadFile(tempBlockFilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// lines dropped by the limiter are neither written nor published
+	if published.String() != string(written) || strings.Contains(string(written), "line 3") {
+		t.Fatalf("published lines are different from written lines:\n%s\n%s", published.String(), written)
+	}
+}
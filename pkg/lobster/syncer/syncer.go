@@ -27,6 +27,7 @@ import (
 	"time"
 
 	"github.com/golang/glog"
+	"github.com/naver/lobster/pkg/lobster/mtls"
 	"github.com/naver/lobster/pkg/lobster/query"
 	"github.com/naver/lobster/pkg/lobster/sink/order"
 	sinkV1 "github.com/naver/lobster/pkg/operator/api/v1"
@@ -34,11 +35,13 @@ import (
 )
 
 const (
-	scheme   = "http"
 	pathSync = "/sync"
 )
 
-var conf config
+var (
+	conf       config
+	httpClient = &http.Client{Transport: &http.Transport{TLSClientConfig: mtls.ClientConfig()}}
+)
 
 func init() {
 	conf = setup()
@@ -148,10 +151,10 @@ func (r *Syncer) mapPreordersFromSinks(sinks []v1.Sink) map[string][]order.Order
 func (r *Syncer) requestSinks() ([]v1.Sink, error) {
 	data := []v1.Sink{}
 
-	resp, err := http.DefaultClient.Do(&http.Request{
+	resp, err := httpClient.Do(&http.Request{
 		Method: http.MethodGet,
 		URL: &url.URL{
-			Scheme: scheme,
+			Scheme: mtls.Scheme(),
 			Host:   *conf.LobsterSinkOperator,
 			Path:   pathSync,
 		},
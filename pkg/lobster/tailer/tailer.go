@@ -27,7 +27,9 @@ import (
 	"github.com/golang/glog"
 
 	"github.com/naver/lobster/pkg/lobster/logline"
+	"github.com/naver/lobster/pkg/lobster/metrics"
 	"github.com/naver/lobster/pkg/lobster/model"
+	"github.com/naver/lobster/pkg/lobster/multiline"
 	"github.com/naver/lobster/pkg/lobster/tailer/tail"
 )
 
@@ -39,13 +41,15 @@ const (
 type status int
 
 type Tailer struct {
-	tail    *tail.Tail
-	file    model.LogFile
-	ticker  *time.Ticker
-	LogChan chan logline.LogLine
-	pause   bool
-	status  status
-	once    sync.Once
+	tail      *tail.Tail
+	file      model.LogFile
+	ticker    *time.Ticker
+	joiner    *logline.PartialJoiner
+	assembler *multiline.Assembler
+	LogChan   chan logline.LogLine
+	pause     bool
+	status    status
+	once      sync.Once
 }
 
 var (
@@ -82,18 +86,41 @@ func NewTailer(file model.LogFile, offset int64, whence int) (*Tailer, error) {
 		return &Tailer{}, err
 	}
 
+	var joiner *logline.PartialJoiner
+	if file.Source.Type == model.LogTypeStdStream {
+		joiner = logline.NewPartialJoiner(func() {
+			metrics.AddTruncatedCount(file.Namespace, file.Pod, file.Container, file.Source.Type, file.Source.Path)
+		})
+	}
+
+	var assembler *multiline.Assembler
+	if rule := multiline.RuleOf(file.Namespace, file.Container, file.Source); rule != nil {
+		assembler = multiline.NewAssembler(rule)
+	}
+
 	return &Tailer{
-		tail:    tf,
-		file:    file,
-		ticker:  time.NewTicker(*conf.TimeToLive),
-		LogChan: make(chan logline.LogLine),
-		pause:   false,
-		status:  statusRunning,
+		tail:      tf,
+		file:      file,
+		ticker:    time.NewTicker(*conf.TimeToLive),
+		joiner:    joiner,
+		assembler: assembler,
+		LogChan:   make(chan logline.LogLine),
+		pause:     false,
+		status:    statusRunning,
 	}, nil
 }
 
 func (t *Tailer) Run(stopChan chan struct{}) {
-	var prevTs time.Time
+	var (
+		prevTs     time.Time
+		assembleCh <-chan time.Time
+	)
+
+	if t.assembler != nil {
+		assembleTicker := time.NewTicker(t.assembler.MaxWait())
+		defer assembleTicker.Stop()
+		assembleCh = assembleTicker.C
+	}
 
 	defer func() {
 		if err := recover(); err != nil {
@@ -120,6 +147,12 @@ func (t *Tailer) Run(stopChan chan struct{}) {
 				continue
 			}
 
+			msg := line.Text
+
+			if t.file.Source.Type == model.LogTypeHostFile {
+				line.Text = logline.StampHostLogLine(line.Text, time.Now())
+			}
+
 			lineTs, err := logline.ParseTimestamp(line.Text)
 			if err != nil {
 				glog.V(3).Info("failed to parse timestamp for %s: %s", t.file.Path, line.Text)
@@ -144,8 +177,36 @@ func (t *Tailer) Run(stopChan chan struct{}) {
 				continue
 			}
 
-			t.LogChan <- logline.LogLine{Timestamp: lineTs, Line: line.Text, Offset: offset, Err: nil}
+			if t.joiner != nil {
+				var ok bool
+				if lineTs, line.Text, ok = t.joiner.Join(lineTs, line.Text); !ok {
+					continue
+				}
+			}
+
+			if t.assembler == nil {
+				t.LogChan <- logline.LogLine{Timestamp: lineTs, Line: line.Text, Offset: offset, Err: nil}
+				t.status = statusRunning
+				continue
+			}
+
+			if t.file.Source.Type == model.LogTypeStdStream {
+				if msg, err = logline.ParseLogMessage(line.Text); err != nil {
+					continue
+				}
+			}
+
+			if event, ok := t.assembler.Add(lineTs, line.Text, msg, offset, time.Now()); ok {
+				t.LogChan <- logline.LogLine{Timestamp: event.Timestamp, Line: event.Line, Offset: event.Offset, Err: nil}
+			}
 			t.status = statusRunning
+		case <-assembleCh:
+			if !t.assembler.Expired(time.Now()) {
+				continue
+			}
+			if event, ok := t.assembler.Flush(); ok {
+				t.LogChan <- logline.LogLine{Timestamp: event.Timestamp, Line: event.Line, Offset: event.Offset, Err: nil}
+			}
 		case <-t.ticker.C:
 			if t.status == statusIdle {
 				glog.V(3).Infof("stop to tail because of idle status for %s_%s", t.file.Pod, t.file.Container)
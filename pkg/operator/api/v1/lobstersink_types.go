@@ -33,7 +33,9 @@ limitations under the License.
 package v1
 
 import (
+	"fmt"
 	"regexp"
+	"strconv"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -83,6 +85,19 @@ type Filter struct {
 	FilterIncludeExpr string `json:"include,omitempty"`
 	// Filter only logs that do not match the re2 expression(https://github.com/google/re2/wiki/Syntax)
 	FilterExcludeExpr string `json:"exclude,omitempty"`
+	// Filter only logs whose json or logfmt messages satisfy all conditions on fields
+	Fields []FieldFilter `json:"fields,omitempty"`
+}
+
+type FieldFilter struct {
+	// Format of log messages; `json` or `logfmt`
+	Format string `json:"format"`
+	// Dot-separated path of the field; e.g. `level`, `http.status` or `items.0.id`
+	Path string `json:"path"`
+	// One of `=`, `!=`, `=~`, `!~`, `>`, `>=`, `<`, `<=`, `exists` and `!exists`
+	Operator string `json:"operator"`
+	// Value to compare with; numbers are compared numerically
+	Value string `json:"value,omitempty"`
 }
 
 func (f Filter) Validate() ValidationErrors {
@@ -108,9 +123,41 @@ func (f Filter) Validate() ValidationErrors {
 		}
 	}
 
+	for i, field := range f.Fields {
+		if err := field.validate(); err != nil {
+			validationErrors.AppendErrorWithFields(fmt.Sprintf("filter.fields[%d]", i), err.Error())
+		}
+	}
+
 	return validationErrors
 }
 
+func (f FieldFilter) validate() error {
+	if f.Format != "json" && f.Format != "logfmt" {
+		return fmt.Errorf("unsupported format %q", f.Format)
+	}
+
+	if len(f.Path) == 0 {
+		return fmt.Errorf("`path` must not be empty")
+	}
+
+	switch f.Operator {
+	case "=", "!=", "exists", "!exists":
+	case "=~", "!~":
+		if _, err := regexp.Compile(f.Value); err != nil {
+			return err
+		}
+	case ">", ">=", "<", "<=":
+		if _, err := strconv.ParseFloat(f.Value, 64); err != nil {
+			return fmt.Errorf("%s requires a number but %q", f.Operator, f.Value)
+		}
+	default:
+		return fmt.Errorf("unsupported operator %q", f.Operator)
+	}
+
+	return nil
+}
+
 type Source struct {
 	Type string `json:"type,omitempty"`
 	Path string `json:"path,omitempty"`
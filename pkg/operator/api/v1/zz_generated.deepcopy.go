@@ -61,6 +61,21 @@ func (in *BasicBucket) DeepCopy() *BasicBucket {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FieldFilter) DeepCopyInto(out *FieldFilter) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FieldFilter.
+func (in *FieldFilter) DeepCopy() *FieldFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(FieldFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Filter) DeepCopyInto(out *Filter) {
 	*out = *in
@@ -102,6 +117,11 @@ func (in *Filter) DeepCopyInto(out *Filter) {
 		*out = make([]Source, len(*in))
 		copy(*out, *in)
 	}
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = make([]FieldFilter, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Filter.
@@ -32,6 +32,7 @@ import (
 
 	httpSwagger "github.com/swaggo/http-swagger"
 
+	"github.com/naver/lobster/pkg/lobster/mtls"
 	"github.com/naver/lobster/pkg/lobster/server/middleware"
 	"github.com/naver/lobster/pkg/operator/server/controller"
 	"github.com/naver/lobster/pkg/operator/server/handler"
@@ -47,6 +48,10 @@ var conf = &config{}
 
 func Run(sinkClient client.Client, logger logr.Logger) {
 	flag.Parse()
+	if err := mtls.Check(); err != nil {
+		logger.Error(err, "invalid mtls configuration")
+		os.Exit(1)
+	}
 	runtime.GOMAXPROCS(runtime.NumCPU())
 	stopChan := make(chan struct{})
 	sigs := make(chan os.Signal, 1)
@@ -71,6 +76,13 @@ func Run(sinkClient client.Client, logger logr.Logger) {
 	}()
 
 	logger.Info("Start server")
+	if mtls.Enabled() {
+		server.TLSConfig = mtls.ServerConfig(false)
+		if err := server.ListenAndServeTLS("", ""); err != nil {
+			logger.Error(err, "server error")
+		}
+		return
+	}
 	if err := server.ListenAndServe(); err != nil {
 		logger.Error(err, "server error")
 	}
@@ -88,7 +100,7 @@ func setupRouter(sinkClient client.Client, logger logr.Logger) *mux.Router {
 	))
 
 	ctrl := controller.SinkController{Client: sinkClient, MaxSinkRule: conf.MaxSinkRule, Logger: logger}
-	router.Handle(handler.PathSync, handler.InternalSyncHandler{Ctrl: ctrl, Logger: logger})
+	router.Handle(handler.PathSync, middleware.ClientCertVerifier{}.Middleware(handler.InternalSyncHandler{Ctrl: ctrl, Logger: logger}))
 
 	routerV1 := router.PathPrefix(handler.PathApi).Subrouter()
 	routerV1.Use(middleware.Inspector{}.Middleware)
@@ -34,8 +34,24 @@ $(function() {
     if (urlParams.has('exclude')) {
         document.getElementById("exclude").setValue(decodeURIComponent(urlParams.get('exclude')));
     }
+    if (urlParams.has('query')) {
+        document.getElementById("query").setValue(decodeURIComponent(urlParams.get('query')));
+    }
+    if (urlParams.has('context')) {
+        document.getElementById("context").value = urlParams.get('context');
+    }
 });
 
+// setToken keeps the bearer token in the cookie read by queriers; requests without it get no logs if authorization is enabled
+function setToken(token) {
+    var cookie = 'lobster_token='.concat(token, '; path=/; SameSite=Strict');
+    if (window.location.protocol === 'https:') {
+        cookie = cookie.concat('; Secure');
+    }
+    document.cookie = cookie;
+    window.location.reload();
+}
+
 function refreshRange() {
     var urlParams = new URLSearchParams(window.location.search);
     var t = new Date();
@@ -90,6 +106,8 @@ function setFilter() {
         const urlParams = new URLSearchParams(window.location.search);
         const include = document.getElementById('include').value;
         const exclude = document.getElementById('exclude').value;
+        const query = document.getElementById('query').value;
+        const context = document.getElementById('context').value;
         
         if (include) {
             urlParams.set('include', encodeURIComponent(include));
@@ -103,6 +121,18 @@ function setFilter() {
             urlParams.delete('exclude');
         }
 
+        if (query) {
+            urlParams.set('query', encodeURIComponent(query));
+        } else {
+            urlParams.delete('query');
+        }
+
+        if (context > 0) {
+            urlParams.set('context', context);
+        } else {
+            urlParams.delete('context');
+        }
+
         window.location.search = urlParams;
 }
 